@@ -1,18 +1,21 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/fintrack/fintrack/internal/commands"
 	"github.com/fintrack/fintrack/internal/config"
 	"github.com/fintrack/fintrack/internal/db"
+	"github.com/fintrack/fintrack/internal/services"
 	"github.com/spf13/cobra"
 )
 
 var (
 	version = "0.1.0-dev"
 	cfgFile string
+	profile string
 )
 
 func main() {
@@ -34,20 +37,32 @@ Privacy-first: All data stored locally in PostgreSQL.
 Unix philosophy: Composable commands, text output, scriptable.`,
 		Version: version,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-			// Initialize configuration
-			if err := config.Init(cfgFile); err != nil {
-				return fmt.Errorf("failed to initialize config: %w", err)
+			// Initialize configuration; configuration commands still run with an
+			// unknown profile so it can be corrected
+			if err := config.Init(cfgFile, profile); err != nil {
+				if commands.NeedsDatabase(cmd) || !errors.Is(err, config.ErrUnknownProfile) {
+					return fmt.Errorf("failed to initialize config: %w", err)
+				}
+			}
+			// Configuration commands work before a database is set up
+			if !commands.NeedsDatabase(cmd) {
+				return nil
 			}
 			// Initialize database connection
 			if err := db.Init(); err != nil {
 				return fmt.Errorf("failed to initialize database: %w", err)
 			}
+			// React to transaction changes (budget alerts, low balances, bill reminders)
+			services.RegisterHooks()
+			// Show due reminders before the command's own output
+			commands.PrintReminderBanner(cmd)
 			return nil
 		},
 	}
 
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default: $HOME/.config/fintrack/config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&profile, "profile", "", "config profile to use (default: $FINTRACK_PROFILE or profile in the config file)")
 	rootCmd.PersistentFlags().Bool("json", false, "output in JSON format")
 
 	// Add subcommands - only working features
@@ -55,15 +70,13 @@ Unix philosophy: Composable commands, text output, scriptable.`,
 	rootCmd.AddCommand(commands.NewCategoryCmd())
 	rootCmd.AddCommand(commands.NewTransactionCmd())
 	rootCmd.AddCommand(commands.NewImportCmd())
-
-	// Note: These commands are stubbed out for future development
-	// rootCmd.AddCommand(commands.NewBudgetCmd())
-	// rootCmd.AddCommand(commands.NewScheduleCmd())
-	// rootCmd.AddCommand(commands.NewRemindCmd())
-	// rootCmd.AddCommand(commands.NewProjectCmd())
-	// rootCmd.AddCommand(commands.NewReportCmd())
-	// rootCmd.AddCommand(commands.NewCalendarCmd())
-	// rootCmd.AddCommand(commands.NewConfigCmd())
+	rootCmd.AddCommand(commands.NewBudgetCmd())
+	rootCmd.AddCommand(commands.NewScheduleCmd())
+	rootCmd.AddCommand(commands.NewRemindCmd())
+	rootCmd.AddCommand(commands.NewProjectCmd())
+	rootCmd.AddCommand(commands.NewReportCmd())
+	rootCmd.AddCommand(commands.NewCalendarCmd())
+	rootCmd.AddCommand(commands.NewConfigCmd())
 
 	return rootCmd
 }
@@ -0,0 +1,801 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/config"
+	"github.com/fintrack/fintrack/internal/db"
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/fintrack/fintrack/internal/output"
+	"github.com/fintrack/fintrack/internal/services"
+	"github.com/spf13/cobra"
+)
+
+// NewBudgetCmd creates the budget command
+func NewBudgetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "budget",
+		Aliases: []string{"b"},
+		Short:   "Manage budgets",
+		Long: `Manage spending limits by category and time period.
+
+Examples:
+  fintrack budget create "Groceries" --limit 600 --category Groceries
+  fintrack budget list
+  fintrack budget status
+  fintrack budget update 1 --limit 650
+  fintrack budget delete 1
+
+Envelope (zero-based) budgeting:
+  fintrack budget assign Groceries 400
+  fintrack budget move Dining Groceries 50
+  fintrack budget available`,
+	}
+
+	cmd.AddCommand(newBudgetCreateCmd())
+	cmd.AddCommand(newBudgetListCmd())
+	cmd.AddCommand(newBudgetShowCmd())
+	cmd.AddCommand(newBudgetUpdateCmd())
+	cmd.AddCommand(newBudgetDeleteCmd())
+	cmd.AddCommand(newBudgetStatusCmd())
+	cmd.AddCommand(newBudgetRolloverCmd())
+	cmd.AddCommand(newBudgetAssignCmd())
+	cmd.AddCommand(newBudgetMoveCmd())
+	cmd.AddCommand(newBudgetAvailableCmd())
+
+	return cmd
+}
+
+func newBudgetCreateCmd() *cobra.Command {
+	var (
+		limit      float64
+		periodType string
+		category   string
+		start      string
+		end        string
+		rollover   bool
+		threshold  float64
+	)
+
+	cmd := &cobra.Command{
+		Use:     "create NAME",
+		Aliases: []string{"add", "new"},
+		Short:   "Create a new budget",
+		Long: `Create a spending limit for a period.
+
+Period must be one of: weekly, monthly, quarterly, annual
+Without --start the budget covers the current period. Without --end the
+period end is derived from the period type. Without --category the budget
+covers all expense spending.
+
+Examples:
+  fintrack budget create "Groceries" --limit 600 --category Groceries
+  fintrack budget create "Travel" --limit 3000 --period annual --rollover
+  fintrack b create "Dining" --limit 200 --category "Restaurants" --threshold 0.9`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !services.IsValidBudgetPeriod(periodType) {
+				return output.PrintError(cmd, fmt.Errorf("invalid period: %s (valid: weekly, monthly, quarterly, annual)", periodType))
+			}
+			if limit <= 0 {
+				return output.PrintError(cmd, fmt.Errorf("--limit must be greater than zero"))
+			}
+
+			if !cmd.Flags().Changed("threshold") {
+				threshold = defaultAlertThreshold()
+			}
+			if threshold < 0 || threshold > 1 {
+				return output.PrintError(cmd, fmt.Errorf("--threshold must be between 0 and 1"))
+			}
+
+			periodStart := services.BudgetPeriodStart(periodType, time.Now())
+			if start != "" {
+				t, err := time.Parse("2006-01-02", start)
+				if err != nil {
+					return output.PrintError(cmd, fmt.Errorf("invalid start date format (use YYYY-MM-DD): %v", err))
+				}
+				periodStart = t
+			}
+
+			periodEnd := services.BudgetPeriodEnd(periodType, periodStart)
+			if end != "" {
+				t, err := time.Parse("2006-01-02", end)
+				if err != nil {
+					return output.PrintError(cmd, fmt.Errorf("invalid end date format (use YYYY-MM-DD): %v", err))
+				}
+				periodEnd = t
+			}
+			if periodEnd.Before(periodStart) {
+				return output.PrintError(cmd, fmt.Errorf("end date must not be before start date"))
+			}
+
+			budget := &models.Budget{
+				Name:             args[0],
+				PeriodType:       periodType,
+				PeriodStart:      periodStart,
+				PeriodEnd:        periodEnd,
+				LimitAmountCents: models.DollarsToCents(limit),
+				RolloverEnabled:  rollover,
+				AlertThreshold:   threshold,
+				IsActive:         true,
+			}
+
+			if category != "" {
+				cat, err := resolveCategory(category, models.CategoryTypeExpense)
+				if err != nil {
+					return output.PrintError(cmd, err)
+				}
+				budget.CategoryID = &cat.ID
+				budget.Category = cat
+			}
+
+			repo := repositories.NewBudgetRepository(db.Get())
+			if err := repo.Create(budget); err != nil {
+				return output.PrintError(cmd, fmt.Errorf("failed to create budget: %w", err))
+			}
+
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.Print(cmd, budget)
+			}
+
+			fmt.Printf("✓ Created budget #%d\n", budget.ID)
+			fmt.Printf("Name: %s\n", budget.Name)
+			fmt.Printf("Category: %s\n", budgetCategoryName(budget))
+			fmt.Printf("Period: %s (%s to %s)\n", budget.PeriodType,
+				budget.PeriodStart.Format("2006-01-02"), budget.PeriodEnd.Format("2006-01-02"))
+			fmt.Printf("Limit: %s\n", output.FormatCurrencyCents(budget.LimitAmountCents, "USD"))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Float64VarP(&limit, "limit", "l", 0, "Spending limit in dollars (required)")
+	cmd.Flags().StringVarP(&periodType, "period", "p", models.BudgetPeriodMonthly, "Period type (weekly, monthly, quarterly, annual)")
+	cmd.Flags().StringVar(&category, "category", "", "Expense category ID or name (default: all spending)")
+	cmd.Flags().StringVar(&start, "start", "", "Period start date (YYYY-MM-DD, default: start of current period)")
+	cmd.Flags().StringVar(&end, "end", "", "Period end date (YYYY-MM-DD, default: derived from period)")
+	cmd.Flags().BoolVar(&rollover, "rollover", false, "Carry unspent amounts into the next period")
+	cmd.Flags().Float64Var(&threshold, "threshold", 0.80, "Alert threshold as a fraction of the limit (0.0-1.0)")
+
+	mustMarkRequired(cmd, "limit")
+
+	return cmd
+}
+
+func newBudgetListCmd() *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List budgets",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repo := repositories.NewBudgetRepository(db.Get())
+			budgets, err := repo.List(!all)
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.Print(cmd, budgets)
+			}
+
+			if len(budgets) == 0 {
+				fmt.Println("No budgets found.")
+				return nil
+			}
+
+			table := output.NewTable("ID", "NAME", "CATEGORY", "PERIOD", "START", "END", "LIMIT", "ROLLOVER")
+			for _, b := range budgets {
+				table.AddRow(
+					fmt.Sprintf("%d", b.ID),
+					b.Name,
+					budgetCategoryName(b),
+					b.PeriodType,
+					b.PeriodStart.Format("2006-01-02"),
+					b.PeriodEnd.Format("2006-01-02"),
+					output.FormatCurrencyCents(b.LimitAmountCents, "USD"),
+					output.FormatCurrencyCents(b.RolloverAmountCents, "USD"),
+				)
+			}
+			table.Print()
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Include inactive budgets")
+
+	return cmd
+}
+
+func newBudgetShowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "show ID",
+		Aliases: []string{"get"},
+		Short:   "Show budget details and status",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			budget, err := resolveBudget(args[0])
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			status, err := services.NewBudgetService(db.Get()).Status(budget)
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.Print(cmd, status)
+			}
+
+			fmt.Printf("Budget #%d\n", budget.ID)
+			fmt.Printf("Name: %s\n", budget.Name)
+			fmt.Printf("Category: %s\n", budgetCategoryName(budget))
+			fmt.Printf("Period: %s (%s to %s)\n", budget.PeriodType,
+				budget.PeriodStart.Format("2006-01-02"), budget.PeriodEnd.Format("2006-01-02"))
+			fmt.Printf("Limit: %s\n", output.FormatCurrencyCents(budget.LimitAmountCents, "USD"))
+			if budget.RolloverEnabled || budget.RolloverAmountCents != 0 {
+				fmt.Printf("Rollover: %s\n", output.FormatCurrencyCents(budget.RolloverAmountCents, "USD"))
+			}
+			fmt.Printf("Spent: %s\n", output.FormatCurrencyCents(status.SpentCents, "USD"))
+			fmt.Printf("Remaining: %s\n", output.FormatCurrencyCents(status.RemainingCents, "USD"))
+			fmt.Printf("Used: %s (alert at %s)\n", output.FormatPercentage(status.PercentUsed),
+				output.FormatPercentage(budget.AlertThreshold))
+			fmt.Printf("Status: %s\n", status.State)
+			fmt.Printf("Active: %v\n", budget.IsActive)
+
+			if len(status.Breakdown) > 0 {
+				fmt.Println("\nBreakdown:")
+				for _, b := range status.Breakdown {
+					name := b.CategoryName
+					if b.Direct {
+						name += " (direct)"
+					}
+					fmt.Printf("  - %s: %s (%s of limit)\n", name,
+						output.FormatCurrencyCents(b.SpentCents, "USD"), output.FormatPercentage(b.PercentUsed))
+				}
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func newBudgetUpdateCmd() *cobra.Command {
+	var (
+		name      string
+		limit     float64
+		category  string
+		threshold float64
+		rollover  bool
+		active    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update ID",
+		Short: "Update a budget",
+		Long: `Update an existing budget's properties.
+
+Examples:
+  fintrack budget update 1 --limit 650
+  fintrack budget update Groceries --threshold 0.9 --rollover
+  fintrack b update 3 --active=false`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			budget, err := resolveBudget(args[0])
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			updated := false
+			if cmd.Flags().Changed("name") {
+				budget.Name = name
+				updated = true
+			}
+			if cmd.Flags().Changed("limit") {
+				if limit <= 0 {
+					return output.PrintError(cmd, fmt.Errorf("--limit must be greater than zero"))
+				}
+				budget.LimitAmountCents = models.DollarsToCents(limit)
+				updated = true
+			}
+			if cmd.Flags().Changed("category") {
+				cat, err := resolveCategory(category, models.CategoryTypeExpense)
+				if err != nil {
+					return output.PrintError(cmd, err)
+				}
+				budget.CategoryID = &cat.ID
+				budget.Category = cat
+				updated = true
+			}
+			if cmd.Flags().Changed("threshold") {
+				if threshold < 0 || threshold > 1 {
+					return output.PrintError(cmd, fmt.Errorf("--threshold must be between 0 and 1"))
+				}
+				budget.AlertThreshold = threshold
+				updated = true
+			}
+			if cmd.Flags().Changed("rollover") {
+				budget.RolloverEnabled = rollover
+				updated = true
+			}
+			if cmd.Flags().Changed("active") {
+				budget.IsActive = active
+				updated = true
+			}
+
+			if !updated {
+				return output.PrintError(cmd, fmt.Errorf("no updates specified"))
+			}
+
+			repo := repositories.NewBudgetRepository(db.Get())
+			if err := repo.Update(budget); err != nil {
+				return output.PrintError(cmd, fmt.Errorf("failed to update budget: %w", err))
+			}
+
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.Print(cmd, budget)
+			}
+
+			fmt.Printf("Budget #%d updated successfully\n", budget.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "New budget name")
+	cmd.Flags().Float64VarP(&limit, "limit", "l", 0, "New spending limit in dollars")
+	cmd.Flags().StringVar(&category, "category", "", "New expense category ID or name")
+	cmd.Flags().Float64Var(&threshold, "threshold", 0, "New alert threshold (0.0-1.0)")
+	cmd.Flags().BoolVar(&rollover, "rollover", false, "Enable or disable rollover")
+	cmd.Flags().BoolVar(&active, "active", true, "Mark budget as active or inactive")
+
+	return cmd
+}
+
+func newBudgetDeleteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "delete ID",
+		Aliases: []string{"rm", "remove"},
+		Short:   "Delete a budget",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			budget, err := resolveBudget(args[0])
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			repo := repositories.NewBudgetRepository(db.Get())
+			if err := repo.Delete(budget.ID); err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			return output.PrintSuccess(cmd, fmt.Sprintf("Budget #%d deleted successfully", budget.ID))
+		},
+	}
+
+	return cmd
+}
+
+func newBudgetStatusCmd() *cobra.Command {
+	var (
+		all       bool
+		breakdown bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "status [ID]",
+		Short: "Show spending against budgets",
+		Long: `Show spent, remaining and percent used for each budget period.
+
+Spending in subcategories counts toward a parent category's budget. Use
+--breakdown (implied when a single budget is given) to list the spending of
+each subcategory under its parent's limit.
+
+Examples:
+  fintrack budget status
+  fintrack budget status 3
+  fintrack b status --all --breakdown`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			svc := services.NewBudgetService(db.Get())
+
+			var statuses []*services.BudgetStatus
+			if len(args) == 1 {
+				budget, err := resolveBudget(args[0])
+				if err != nil {
+					return output.PrintError(cmd, err)
+				}
+				status, err := svc.Status(budget)
+				if err != nil {
+					return output.PrintError(cmd, err)
+				}
+				statuses = append(statuses, status)
+				breakdown = true
+			} else {
+				var err error
+				statuses, err = svc.StatusAll(!all)
+				if err != nil {
+					return output.PrintError(cmd, err)
+				}
+			}
+
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.Print(cmd, statuses)
+			}
+
+			if len(statuses) == 0 {
+				fmt.Println("No budgets found.")
+				return nil
+			}
+
+			printBudgetStatusTable(statuses, breakdown)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Include inactive budgets")
+	cmd.Flags().BoolVar(&breakdown, "breakdown", false, "Show spending per subcategory")
+
+	return cmd
+}
+
+func newBudgetRolloverCmd() *cobra.Command {
+	var (
+		asOf   string
+		dryRun bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "rollover",
+		Short: "Start new periods for budgets whose period has ended",
+		Long: `Create the next period for every active budget whose period has ended.
+
+Budgets with rollover enabled carry their unspent amount (or overspending,
+as a negative amount) into the new period. Several missed periods are
+backfilled in order. Running the command again for the same periods has
+no effect.
+
+Examples:
+  fintrack budget rollover
+  fintrack budget rollover --dry-run
+  fintrack b rollover --as-of 2026-11-01`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			date := time.Now()
+			if asOf != "" {
+				t, err := time.Parse("2006-01-02", asOf)
+				if err != nil {
+					return output.PrintError(cmd, fmt.Errorf("invalid as-of date format (use YYYY-MM-DD): %v", err))
+				}
+				date = t
+			}
+
+			result, err := services.NewBudgetRolloverService(db.Get()).Run(date, dryRun)
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.Print(cmd, result)
+			}
+
+			if len(result.Entries) == 0 {
+				fmt.Println("No budget periods to roll over.")
+				return nil
+			}
+
+			table := output.NewTable("FROM", "NAME", "NEW PERIOD", "SPENT", "CARRIED")
+			for _, e := range result.Entries {
+				table.AddRow(
+					fmt.Sprintf("#%d", e.FromBudgetID),
+					e.Budget.Name,
+					fmt.Sprintf("%s..%s", e.Budget.PeriodStart.Format("2006-01-02"), e.Budget.PeriodEnd.Format("2006-01-02")),
+					output.FormatCurrencyCents(e.SpentCents, "USD"),
+					output.FormatCurrencyCents(e.CarriedCents, "USD"),
+				)
+			}
+			table.Print()
+
+			if dryRun {
+				fmt.Println("\nThis was a dry run. No budgets were changed.")
+			} else {
+				fmt.Printf("\n✓ Created %d budget period(s)\n", len(result.Entries))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&asOf, "as-of", "", "Treat periods ending before this date as closed (YYYY-MM-DD, default: today)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview rollover without saving")
+
+	return cmd
+}
+
+func newBudgetAssignCmd() *cobra.Command {
+	var (
+		month string
+		note  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "assign CATEGORY AMOUNT",
+		Short: "Assign money to a category envelope",
+		Long: `Assign money from "to be budgeted" to a category envelope for a month.
+
+A negative amount returns money from the envelope to "to be budgeted";
+put it after "--" so it is not read as a flag. Unspent money stays in the
+envelope from month to month.
+
+Examples:
+  fintrack budget assign Groceries 400
+  fintrack budget assign Rent 1500 --month 2026-11
+  fintrack b assign --note "cutting back" Dining -- -25`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			category, err := resolveCategory(args[0], models.CategoryTypeExpense)
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+			amount, err := parseEnvelopeAmount(args[1])
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+			m, err := parseEnvelopeMonth(month)
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			state, err := services.NewEnvelopeService(db.Get()).Assign(category.ID, m, amount, note)
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.Print(cmd, state)
+			}
+
+			fmt.Printf("✓ Assigned %s to %s for %s\n", output.FormatCurrencyCents(amount, "USD"), category.Name, state.Month.Format("January 2006"))
+			if env := state.Envelope(category.ID); env != nil {
+				fmt.Printf("  Available:       %s\n", output.FormatCurrencyCents(env.AvailableCents, "USD"))
+			}
+			fmt.Printf("  To be budgeted:  %s\n", output.FormatCurrencyCents(state.ToBeBudgetedCents, "USD"))
+			if state.ToBeBudgetedCents < 0 {
+				fmt.Println("\n⚠ You have assigned more money than you have")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&month, "month", "", "Month to assign to (YYYY-MM, default: current month)")
+	cmd.Flags().StringVar(&note, "note", "", "Note for the assignment")
+
+	return cmd
+}
+
+func newBudgetMoveCmd() *cobra.Command {
+	var (
+		month string
+		note  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "move FROM TO AMOUNT",
+		Short: "Move money between category envelopes",
+		Long: `Move money from one category envelope to another for a month.
+
+The source envelope must have enough money available.
+
+Examples:
+  fintrack budget move Dining Groceries 50
+  fintrack budget move Vacation "Car Repair" 300 --month 2026-11`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, err := resolveCategory(args[0], models.CategoryTypeExpense)
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+			to, err := resolveCategory(args[1], models.CategoryTypeExpense)
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+			amount, err := parseEnvelopeAmount(args[2])
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+			m, err := parseEnvelopeMonth(month)
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			state, err := services.NewEnvelopeService(db.Get()).Move(from.ID, to.ID, m, amount, note)
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.Print(cmd, state)
+			}
+
+			fmt.Printf("✓ Moved %s from %s to %s\n", output.FormatCurrencyCents(amount, "USD"), from.Name, to.Name)
+			for _, id := range []uint{from.ID, to.ID} {
+				if env := state.Envelope(id); env != nil {
+					fmt.Printf("  %s available: %s\n", env.CategoryName, output.FormatCurrencyCents(env.AvailableCents, "USD"))
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&month, "month", "", "Month to move money in (YYYY-MM, default: current month)")
+	cmd.Flags().StringVar(&note, "note", "", "Note for the move")
+
+	return cmd
+}
+
+func newBudgetAvailableCmd() *cobra.Command {
+	var month string
+
+	cmd := &cobra.Command{
+		Use:   "available",
+		Short: "Show envelope balances and money to be budgeted",
+		Long: `Show assigned, activity and available money for each category envelope.
+
+"To be budgeted" is the money in checking, savings, cash and credit accounts
+that has not been assigned to an envelope. Each account's stored balance is
+reconciled against its transactions; any difference is reported.
+
+Examples:
+  fintrack budget available
+  fintrack budget available --month 2026-11
+  fintrack b available --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			m, err := parseEnvelopeMonth(month)
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			state, err := services.NewEnvelopeService(db.Get()).Available(m)
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.Print(cmd, state)
+			}
+
+			fmt.Printf("Envelopes for %s\n\n", state.Month.Format("January 2006"))
+			if len(state.Envelopes) == 0 {
+				fmt.Println("No envelopes yet. Assign money with 'fintrack budget assign'.")
+			} else {
+				table := output.NewTable("CATEGORY", "ASSIGNED", "ACTIVITY", "AVAILABLE")
+				for _, e := range state.Envelopes {
+					table.AddRow(
+						e.CategoryName,
+						output.FormatCurrencyCents(e.AssignedCents, "USD"),
+						output.FormatCurrencyCents(e.ActivityCents, "USD"),
+						output.FormatCurrencyCents(e.AvailableCents, "USD"),
+					)
+				}
+				table.Print()
+			}
+
+			fmt.Printf("\nFunds:           %s\n", output.FormatCurrencyCents(state.FundsCents, "USD"))
+			fmt.Printf("To be budgeted:  %s\n", output.FormatCurrencyCents(state.ToBeBudgetedCents, "USD"))
+
+			if !state.Reconciled {
+				fmt.Println("\n⚠ Account balances do not match their transactions:")
+				for _, a := range state.Accounts {
+					if a.DifferenceCents != 0 {
+						fmt.Printf("  %s: balance %s, ledger %s (difference %s)\n", a.AccountName,
+							output.FormatCurrencyCents(a.CurrentCents, "USD"),
+							output.FormatCurrencyCents(a.LedgerCents, "USD"),
+							output.FormatCurrencyCents(a.DifferenceCents, "USD"))
+					}
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&month, "month", "", "Month to show (YYYY-MM, default: current month)")
+
+	return cmd
+}
+
+// parseEnvelopeAmount parses a dollar amount argument into cents
+func parseEnvelopeAmount(s string) (int64, error) {
+	amount, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount: %s", s)
+	}
+	if amount == 0 {
+		return 0, fmt.Errorf("amount cannot be zero")
+	}
+	return models.DollarsToCents(amount), nil
+}
+
+// parseEnvelopeMonth parses a YYYY-MM month flag, defaulting to the current month
+func parseEnvelopeMonth(s string) (time.Time, error) {
+	if s == "" {
+		return services.MonthStart(time.Now()), nil
+	}
+	t, err := time.Parse("2006-01", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid month format (use YYYY-MM): %v", err)
+	}
+	return t, nil
+}
+
+// printBudgetStatusTable prints one row per budget status, optionally followed by
+// indented rows for each subcategory in its breakdown
+func printBudgetStatusTable(statuses []*services.BudgetStatus, breakdown bool) {
+	table := output.NewTable("ID", "NAME", "CATEGORY", "PERIOD", "LIMIT", "SPENT", "REMAINING", "USED", "STATUS")
+	for _, s := range statuses {
+		table.AddRow(
+			fmt.Sprintf("%d", s.Budget.ID),
+			s.Budget.Name,
+			budgetCategoryName(s.Budget),
+			fmt.Sprintf("%s..%s", s.Budget.PeriodStart.Format("01/02"), s.Budget.PeriodEnd.Format("01/02")),
+			output.FormatCurrencyCents(s.LimitCents, "USD"),
+			output.FormatCurrencyCents(s.SpentCents, "USD"),
+			output.FormatCurrencyCents(s.RemainingCents, "USD"),
+			output.FormatPercentage(s.PercentUsed),
+			s.State,
+		)
+
+		if !breakdown {
+			continue
+		}
+		for _, b := range s.Breakdown {
+			name := "  - " + b.CategoryName
+			if b.Direct {
+				name += " (direct)"
+			}
+			table.AddRow("", "", name, "", "",
+				output.FormatCurrencyCents(b.SpentCents, "USD"), "",
+				output.FormatPercentage(b.PercentUsed), "")
+		}
+	}
+	table.Print()
+}
+
+// resolveBudget looks up a budget by ID or by name
+func resolveBudget(idOrName string) (*models.Budget, error) {
+	repo := repositories.NewBudgetRepository(db.Get())
+
+	if id, err := strconv.ParseUint(idOrName, 10, 32); err == nil {
+		return repo.GetByID(uint(id))
+	}
+
+	budget, err := repo.GetByName(idOrName)
+	if err != nil {
+		return nil, fmt.Errorf("budget not found: %s", idOrName)
+	}
+	return budget, nil
+}
+
+func budgetCategoryName(budget *models.Budget) string {
+	if budget.Category != nil {
+		return budget.Category.Name
+	}
+	if budget.CategoryID == nil {
+		return "(all spending)"
+	}
+	return fmt.Sprintf("#%d", *budget.CategoryID)
+}
+
+// defaultAlertThreshold returns the configured alert threshold, falling back to 80%
+func defaultAlertThreshold() float64 {
+	if t := config.Get().Alerts.Threshold; t > 0 && t <= 1 {
+		return t
+	}
+	return 0.80
+}
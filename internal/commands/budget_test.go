@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBudgetCmd(t *testing.T) {
+	cmd := NewBudgetCmd()
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "budget", cmd.Use)
+	assert.Contains(t, cmd.Aliases, "b")
+	assert.Equal(t, "Manage budgets", cmd.Short)
+	assert.True(t, cmd.HasSubCommands())
+}
+
+func TestBudgetCmd_Subcommands(t *testing.T) {
+	cmd := NewBudgetCmd()
+
+	subcommands := []string{"create", "list", "show", "update", "delete", "status", "rollover", "assign", "move", "available"}
+	for _, sub := range subcommands {
+		found, _, err := cmd.Find([]string{sub})
+		assert.NoError(t, err)
+		assert.Equal(t, sub, found.Name(), "Expected subcommand '%s' not found", sub)
+	}
+}
+
+func TestBudgetCreateCmd_Flags(t *testing.T) {
+	cmd := NewBudgetCmd()
+	createCmd, _, _ := cmd.Find([]string{"create"})
+
+	flags := []string{"limit", "period", "category", "start", "end", "rollover", "threshold"}
+	for _, flag := range flags {
+		assert.NotNil(t, createCmd.Flags().Lookup(flag), "Expected flag '%s' not found", flag)
+	}
+}
+
+func TestBudgetCategoryName(t *testing.T) {
+	assert.Equal(t, "(all spending)", budgetCategoryName(&models.Budget{}))
+
+	categoryID := uint(7)
+	assert.Equal(t, "#7", budgetCategoryName(&models.Budget{CategoryID: &categoryID}))
+	assert.Equal(t, "Groceries", budgetCategoryName(&models.Budget{
+		CategoryID: &categoryID,
+		Category:   &models.Category{Name: "Groceries"},
+	}))
+}
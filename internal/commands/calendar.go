@@ -0,0 +1,357 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/fintrack/fintrack/internal/config"
+	"github.com/fintrack/fintrack/internal/db"
+	"github.com/fintrack/fintrack/internal/output"
+	"github.com/fintrack/fintrack/internal/services"
+	"github.com/spf13/cobra"
+)
+
+// calendarCellWidth is the width of one day in the month grid
+const calendarCellWidth = 9
+
+// asciiCalendarSymbols stand in for calendar.symbols entries that are unset, or
+// that aren't plain ASCII when output.unicode is off
+var asciiCalendarSymbols = map[string]string{
+	services.CalendarEventIncome:      "+",
+	services.CalendarEventExpense:     "-",
+	services.CalendarEventTransfer:    "~",
+	services.CalendarEventBill:        "$",
+	services.CalendarEventReminder:    "*",
+	services.CalendarEventBudgetAlert: "!",
+}
+
+// NewCalendarCmd creates the calendar command
+func NewCalendarCmd() *cobra.Command {
+	var month string
+
+	cmd := &cobra.Command{
+		Use:     "cal",
+		Aliases: []string{"c"},
+		Short:   "Calendar view",
+		Long: `Show a month calendar of posted transactions, upcoming recurring items and
+reminders, with each day's net amount.
+
+Weeks start on the day set by calendar.week_start (0 = Sunday, 1 = Monday).
+Each kind of event is marked with its calendar.symbols entry; amounts are left
+out of the grid when calendar.show_amounts is off.
+
+Examples:
+  fintrack cal
+  fintrack cal --month 2026-11
+  fintrack cal upcoming --count 20
+  fintrack cal export --ics > fintrack.ics`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			monthStart, err := parseEnvelopeMonth(month)
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			cal, err := services.NewCalendarService(db.Get()).Month(monthStart)
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.Print(cmd, cal)
+			}
+
+			cfg := config.Get().Calendar
+			fmt.Print(calendarGrid(cal, cfg.WeekStart, cfg.ShowAmounts, time.Now()))
+			printCalendarEvents(cal, cfg.ShowAmounts)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&month, "month", "", "Month to show (YYYY-MM, default: this month)")
+
+	cmd.AddCommand(newCalendarUpcomingCmd())
+	cmd.AddCommand(newCalendarExportCmd())
+
+	return cmd
+}
+
+func newCalendarUpcomingCmd() *cobra.Command {
+	var count int
+
+	cmd := &cobra.Command{
+		Use:   "upcoming",
+		Short: "List upcoming bills, paydays and reminders",
+		Long: `List the next scheduled occurrences of recurring items and pending reminders,
+soonest first, with a running total so you can see when bills fall relative
+to paydays.
+
+Examples:
+  fintrack cal upcoming
+  fintrack cal upcoming --count 25
+  fintrack cal upcoming --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("count") && config.Get().Calendar.UpcomingCount > 0 {
+				count = config.Get().Calendar.UpcomingCount
+			}
+
+			events, err := services.NewCalendarService(db.Get()).Upcoming(count)
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.Print(cmd, events)
+			}
+
+			if len(events) == 0 {
+				fmt.Println("Nothing upcoming.")
+				return nil
+			}
+			printUpcomingEvents(events, time.Now())
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&count, "count", "n", 10, "Number of events to list (default: calendar.upcoming_count)")
+
+	return cmd
+}
+
+func newCalendarExportCmd() *cobra.Command {
+	var (
+		ics     bool
+		outFile string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export bills, paydays and reminders to a calendar file",
+		Long: `Export active recurring items and pending reminders as an iCalendar (.ics)
+document for phone and desktop calendar apps.
+
+Each recurring item becomes a repeating all-day event from its next due date,
+and recurring expenses get an alarm reminder_days_before days ahead. Event IDs
+stay the same between exports, so importing a newer file updates the events
+instead of duplicating them.
+
+Examples:
+  fintrack cal export --ics > fintrack.ics
+  fintrack cal export --ics --output ~/calendars/fintrack.ics`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !ics {
+				return output.PrintError(cmd, fmt.Errorf("no export format given (use --ics)"))
+			}
+
+			svc := services.NewCalendarService(db.Get())
+			if outFile == "" {
+				if err := svc.ExportICS(os.Stdout); err != nil {
+					return output.PrintError(cmd, err)
+				}
+				return nil
+			}
+
+			f, err := os.Create(outFile)
+			if err != nil {
+				return output.PrintError(cmd, fmt.Errorf("failed to create %s: %w", outFile, err))
+			}
+			if err := svc.ExportICS(f); err != nil {
+				f.Close()
+				return output.PrintError(cmd, err)
+			}
+			if err := f.Close(); err != nil {
+				return output.PrintError(cmd, fmt.Errorf("failed to write %s: %w", outFile, err))
+			}
+
+			fmt.Printf("Exported calendar to %s\n", outFile)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&ics, "ics", false, "Export in iCalendar (RFC 5545) format")
+	cmd.Flags().StringVarP(&outFile, "output", "o", "", "File to write (default: standard output)")
+
+	return cmd
+}
+
+// calendarGrid renders the month as a grid of weeks starting on weekStart, each
+// day showing its date and, when showAmounts is set, its net amount in whole
+// dollars or otherwise its number of events. Today is marked with an asterisk.
+func calendarGrid(cal *services.CalendarMonth, weekStart int, showAmounts bool, today time.Time) string {
+	weekStart = ((weekStart % 7) + 7) % 7
+	width := calendarCellWidth * 7
+
+	var b strings.Builder
+	title := cal.Month.Format("January 2006")
+	fmt.Fprintf(&b, "%*s\n", (width+len(title))/2, title)
+	var header strings.Builder
+	for i := 0; i < 7; i++ {
+		header.WriteString(padCell(time.Weekday((weekStart + i) % 7).String()[:3]))
+	}
+	b.WriteString(strings.TrimRight(header.String(), " ") + "\n")
+
+	lead := (int(cal.Month.Weekday()) - weekStart + 7) % 7
+	cells := make([]*services.CalendarDay, lead, lead+len(cal.Days)+6)
+	cells = append(cells, cal.Days...)
+	for len(cells)%7 != 0 {
+		cells = append(cells, nil)
+	}
+
+	for week := 0; week < len(cells); week += 7 {
+		var dates, details strings.Builder
+		for _, day := range cells[week : week+7] {
+			if day == nil {
+				dates.WriteString(padCell(""))
+				details.WriteString(padCell(""))
+				continue
+			}
+			date := fmt.Sprintf("%2d", day.Date.Day())
+			if sameDay(day.Date, today) {
+				date += "*"
+			}
+			dates.WriteString(padCell(date))
+			details.WriteString(padCell(calendarCellDetail(day, showAmounts)))
+		}
+		b.WriteString(strings.TrimRight(dates.String(), " ") + "\n")
+		b.WriteString(strings.TrimRight(details.String(), " ") + "\n")
+	}
+	return b.String()
+}
+
+// calendarCellDetail is the second line of a day in the grid: its net amount,
+// or its number of events when amounts are hidden
+func calendarCellDetail(day *services.CalendarDay, showAmounts bool) string {
+	if !showAmounts {
+		if len(day.Events) == 0 {
+			return ""
+		}
+		return fmt.Sprintf("(%d)", len(day.Events))
+	}
+	if day.NetCents == 0 {
+		return ""
+	}
+	return compactAmountCents(day.NetCents)
+}
+
+// compactAmountCents formats a signed amount in whole dollars, switching to
+// thousands once it no longer fits a grid cell
+func compactAmountCents(cents int64) string {
+	dollars := (cents + 50) / 100
+	if cents < 0 {
+		dollars = (cents - 50) / 100
+	}
+	if dollars >= 1000000 || dollars <= -1000000 {
+		return fmt.Sprintf("%+dk", dollars/1000)
+	}
+	return fmt.Sprintf("%+d", dollars)
+}
+
+// padCell left-aligns s in a grid cell, leaving at least one space after it
+func padCell(s string) string {
+	if len(s) >= calendarCellWidth {
+		s = s[:calendarCellWidth-1]
+	}
+	return s + strings.Repeat(" ", calendarCellWidth-len(s))
+}
+
+// sameDay reports whether a and b fall on the same calendar date
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
+
+// calendarSymbol returns the calendar.symbols entry for an event kind, falling
+// back to ASCII when it is unset or Unicode output is off
+func calendarSymbol(kind string) string {
+	cfg := config.Get()
+	symbols := cfg.Calendar.Symbols
+	symbol := map[string]string{
+		services.CalendarEventIncome:      symbols.Income,
+		services.CalendarEventExpense:     symbols.Expense,
+		services.CalendarEventTransfer:    symbols.Transfer,
+		services.CalendarEventBill:        symbols.Bill,
+		services.CalendarEventReminder:    symbols.Reminder,
+		services.CalendarEventBudgetAlert: symbols.BudgetAlert,
+	}[kind]
+	if symbol == "" || (!cfg.Output.Unicode && utf8.RuneCountInString(symbol) != len(symbol)) {
+		return asciiCalendarSymbols[kind]
+	}
+	return symbol
+}
+
+// calendarEventText describes an event for lists, symbol first
+func calendarEventText(event *services.CalendarEvent) string {
+	text := calendarSymbol(event.Kind) + " " + event.Title
+	if event.Scheduled {
+		text += " (scheduled)"
+	}
+	return text
+}
+
+func printCalendarEvents(cal *services.CalendarMonth, showAmounts bool) {
+	var listed bool
+	for _, day := range cal.Days {
+		for i, event := range day.Events {
+			if !listed {
+				fmt.Println()
+				listed = true
+			}
+			date := ""
+			if i == 0 {
+				date = day.Date.Format("Mon Jan 02")
+			}
+			amount := ""
+			if showAmounts && event.AmountCents != nil {
+				amount = formatAmountCents(*event.AmountCents)
+			}
+			fmt.Printf("%-10s  %12s  %s\n", date, amount, calendarEventText(event))
+		}
+	}
+	if !listed {
+		fmt.Println("\nNothing scheduled this month.")
+	}
+
+	if showAmounts {
+		fmt.Printf("\nIncome:    %s\n", output.FormatCurrencyCents(cal.IncomeCents, "USD"))
+		fmt.Printf("Expenses:  %s\n", output.FormatCurrencyCents(cal.ExpensesCents, "USD"))
+		fmt.Printf("Net:       %s\n", formatAmountCents(cal.NetCents))
+	}
+}
+
+func printUpcomingEvents(events []*services.CalendarEvent, now time.Time) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var running int64
+	table := output.NewTable("DATE", "WHEN", "AMOUNT", "RUNNING", "EVENT")
+	for _, event := range events {
+		amount, total := "", ""
+		if event.AmountCents != nil {
+			running += *event.AmountCents
+			amount, total = formatAmountCents(*event.AmountCents), formatAmountCents(running)
+		}
+		table.AddRow(
+			event.Date.Format("Mon Jan 02"),
+			daysUntil(event.Date, today),
+			amount,
+			total,
+			calendarEventText(event),
+		)
+	}
+	table.Print()
+}
+
+// daysUntil describes how far date is from today
+func daysUntil(date, today time.Time) string {
+	days := int(date.Sub(today).Hours() / 24)
+	switch days {
+	case 0:
+		return "today"
+	case 1:
+		return "tomorrow"
+	}
+	return fmt.Sprintf("in %d days", days)
+}
@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCalendarCmd(t *testing.T) {
+	cmd := NewCalendarCmd()
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "cal", cmd.Use)
+	assert.Contains(t, cmd.Aliases, "c")
+	assert.Equal(t, "Calendar view", cmd.Short)
+	assert.NotNil(t, cmd.Flags().Lookup("month"))
+}
+
+func TestCalendarCmd_Subcommands(t *testing.T) {
+	cmd := NewCalendarCmd()
+
+	for _, sub := range []string{"upcoming", "export"} {
+		found, _, err := cmd.Find([]string{sub})
+		assert.NoError(t, err)
+		assert.Equal(t, sub, found.Name(), "Expected subcommand '%s' not found", sub)
+	}
+}
+
+func TestCalendarUpcomingCmd_Flags(t *testing.T) {
+	cmd := newCalendarUpcomingCmd()
+
+	flag := cmd.Flags().Lookup("count")
+	assert.NotNil(t, flag)
+	assert.Equal(t, "n", flag.Shorthand)
+	assert.Equal(t, "10", flag.DefValue)
+}
+
+func TestCalendarExportCmd_Flags(t *testing.T) {
+	cmd := newCalendarExportCmd()
+
+	assert.Equal(t, "false", cmd.Flags().Lookup("ics").DefValue)
+	output := cmd.Flags().Lookup("output")
+	assert.NotNil(t, output)
+	assert.Equal(t, "o", output.Shorthand)
+}
+
+func calendarMonth(year int, month time.Month) *services.CalendarMonth {
+	cal := &services.CalendarMonth{Month: time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)}
+	for day := cal.Month; day.Month() == month; day = day.AddDate(0, 0, 1) {
+		cal.Days = append(cal.Days, &services.CalendarDay{Date: day})
+	}
+	return cal
+}
+
+func TestCalendarGrid_WeekStart(t *testing.T) {
+	cal := calendarMonth(2026, time.November) // Starts on a Sunday
+	cal.Days[0].NetCents = -150000
+	cal.Days[5].NetCents = 300000
+	today := time.Date(2026, 11, 3, 15, 0, 0, 0, time.Local)
+
+	lines := strings.Split(calendarGrid(cal, 0, true, today), "\n")
+	assert.Equal(t, "November 2026", strings.TrimSpace(lines[0]))
+	assert.Equal(t, "Sun      Mon      Tue      Wed      Thu      Fri      Sat", lines[1])
+	assert.Equal(t, " 1        2        3*       4        5        6        7", lines[2])
+	assert.Equal(t, "-1500"+strings.Repeat(" ", 40)+"+3000", lines[3])
+
+	lines = strings.Split(calendarGrid(cal, 1, true, today), "\n")
+	assert.Equal(t, "Mon      Tue      Wed      Thu      Fri      Sat      Sun", lines[1])
+	assert.Equal(t, strings.Repeat(" ", 54)+" 1", lines[2])
+	assert.Equal(t, strings.Repeat(" ", 54)+"-1500", lines[3])
+	assert.Equal(t, " 2        3*       4        5        6        7        8", lines[4])
+}
+
+func TestCalendarGrid_HiddenAmounts(t *testing.T) {
+	cal := calendarMonth(2026, time.February)
+	cal.Days[0].NetCents = 5000
+	cal.Days[0].Events = []*services.CalendarEvent{{Kind: services.CalendarEventIncome}, {Kind: services.CalendarEventReminder}}
+
+	lines := strings.Split(calendarGrid(cal, 0, false, time.Time{}), "\n")
+	assert.Equal(t, "(2)", lines[3])
+	// February 2026 starts on a Sunday and fills exactly four weeks
+	assert.Len(t, lines, 2+4*2+1)
+}
+
+func TestCompactAmountCents(t *testing.T) {
+	assert.Equal(t, "+3000", compactAmountCents(300000))
+	assert.Equal(t, "-13", compactAmountCents(-1250))
+	assert.Equal(t, "+0", compactAmountCents(49))
+	assert.Equal(t, "-1234k", compactAmountCents(-123456789))
+}
+
+func TestCalendarSymbol_ASCIIFallback(t *testing.T) {
+	// Without a loaded config every symbol is unset
+	assert.Equal(t, "$", calendarSymbol(services.CalendarEventBill))
+	assert.Equal(t, "!", calendarSymbol(services.CalendarEventBudgetAlert))
+}
+
+func TestDaysUntil(t *testing.T) {
+	today := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "today", daysUntil(today, today))
+	assert.Equal(t, "tomorrow", daysUntil(today.AddDate(0, 0, 1), today))
+	assert.Equal(t, "in 17 days", daysUntil(today.AddDate(0, 0, 17), today))
+}
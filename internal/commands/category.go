@@ -337,6 +337,21 @@ Examples:
 	return cmd
 }
 
+// resolveCategory looks up a category by ID or by name within the given type
+func resolveCategory(idOrName string, categoryType string) (*models.Category, error) {
+	repo := repositories.NewCategoryRepository(db.Get())
+
+	if id, err := strconv.ParseUint(idOrName, 10, 32); err == nil {
+		return repo.GetByID(uint(id))
+	}
+
+	category, err := repo.GetByName(idOrName, categoryType)
+	if err != nil {
+		return nil, fmt.Errorf("category not found: %s", idOrName)
+	}
+	return category, nil
+}
+
 func newCategoryDeleteCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "delete <id>",
@@ -0,0 +1,524 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fintrack/fintrack/internal/config"
+	"github.com/fintrack/fintrack/internal/db"
+	"github.com/fintrack/fintrack/internal/output"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// noDatabaseAnnotation marks a command, and its subcommands, as working without
+// a database connection
+const noDatabaseAnnotation = "fintrack/no-database"
+
+// errInvalidConfig makes config validate exit with a failure status
+var errInvalidConfig = errors.New("configuration is invalid")
+
+// ConfigValidation is the result of checking the configuration
+type ConfigValidation struct {
+	File        string   `json:"file"`
+	FileExists  bool     `json:"file_exists"`
+	UnknownKeys []string `json:"unknown_keys"`
+	Errors      []string `json:"errors"`
+	Valid       bool     `json:"valid"`
+}
+
+// ConfigProfile describes a named profile in the config file
+type ConfigProfile struct {
+	Name     string `json:"name"`
+	Active   bool   `json:"active"`
+	Database string `json:"database"`
+	Account  string `json:"default_account"`
+	Currency string `json:"default_currency"`
+}
+
+// NeedsDatabase reports whether cmd needs the database connected before it
+// runs. Configuration commands don't, so they work before one is set up.
+func NeedsDatabase(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if _, ok := c.Annotations[noDatabaseAnnotation]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+// NewConfigCmd creates the config command
+func NewConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Configuration management",
+		Long: `View and change FinTrack settings.
+
+Settings are read from ~/.config/fintrack/config.yaml (or the file given with
+--config), with defaults for anything not set. Environment variables named
+FINTRACK_<SECTION>_<KEY> override the file. With a profile selected (--profile
+or FINTRACK_PROFILE), its database and defaults settings take precedence.
+
+None of these commands need a database connection.`,
+		Annotations: map[string]string{noDatabaseAnnotation: "true"},
+	}
+
+	cmd.AddCommand(newConfigShowCmd())
+	cmd.AddCommand(newConfigGetCmd())
+	cmd.AddCommand(newConfigSetCmd())
+	cmd.AddCommand(newConfigValidateCmd())
+	cmd.AddCommand(newConfigInitCmd())
+	cmd.AddCommand(newConfigProfilesCmd())
+
+	return cmd
+}
+
+func newConfigShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show",
+		Short: "Show current configuration",
+		Long: `Show every setting in effect, from defaults, the config file and the
+environment. The database password is redacted.
+
+Examples:
+  fintrack config show
+  fintrack config show --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			settings := config.Settings()
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.Print(cmd, settings)
+			}
+
+			path, err := config.FilePath()
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+			if _, err := os.Stat(path); err != nil {
+				fmt.Printf("# %s not found; showing defaults\n", path)
+			} else {
+				fmt.Printf("# %s\n", path)
+			}
+
+			text, err := formatYAML(settings)
+			if err != nil {
+				return output.PrintError(cmd, fmt.Errorf("failed to format configuration: %w", err))
+			}
+			fmt.Print(text)
+			return nil
+		},
+	}
+}
+
+func newConfigGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get KEY",
+		Short: "Show one setting",
+		Long: `Show the value in effect for a setting, or a whole section, by its dotted key.
+
+Examples:
+  fintrack config get database.host
+  fintrack config get reports
+  fintrack config get alerts.low_balance.thresholds.checking`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key := strings.ToLower(args[0])
+			if !config.IsKnownKey(key) {
+				return output.PrintError(cmd, fmt.Errorf("unknown config key: %s", args[0]))
+			}
+
+			value, _ := config.Value(key)
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.Print(cmd, map[string]interface{}{"key": key, "value": value})
+			}
+
+			switch value.(type) {
+			case nil:
+				fmt.Println()
+			case map[string]interface{}:
+				text, err := formatYAML(value)
+				if err != nil {
+					return output.PrintError(cmd, fmt.Errorf("failed to format %s: %w", key, err))
+				}
+				fmt.Print(text)
+			default:
+				fmt.Println(value)
+			}
+			return nil
+		},
+	}
+}
+
+func newConfigSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set KEY VALUE",
+		Short: "Change a setting in the config file",
+		Long: `Change a setting in the config file, creating the file if needed. Comments
+and the rest of the file are kept. The value must suit the setting; invalid
+values are rejected without touching the file. While a profile is active,
+database and defaults settings are changed in that profile.
+
+Examples:
+  fintrack config set database.host db.example.com
+  fintrack config set reports.show_charts false
+  fintrack config set alerts.low_balance.thresholds.checking 250`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, value := strings.ToLower(args[0]), args[1]
+			path, err := config.FilePath()
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			// Check the value in context before saving it
+			if config.IsKnownKey(key) {
+				if err := config.Override(key, value); err != nil {
+					return output.PrintError(cmd, fmt.Errorf("invalid value for %s: %v", key, err))
+				}
+				for _, problem := range config.Get().Validate() {
+					if strings.HasPrefix(problem.Error(), key+":") {
+						return output.PrintError(cmd, problem)
+					}
+				}
+			}
+
+			key = config.ProfileKey(key)
+			if err := config.SetValue(path, key, value); err != nil {
+				return output.PrintError(cmd, err)
+			}
+			return output.PrintSuccess(cmd, fmt.Sprintf("Set %s in %s", key, path))
+		},
+	}
+}
+
+func newConfigValidateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Check the configuration for mistakes",
+		Long: `Check the config file for keys FinTrack doesn't know, usually typos, and every
+setting for values out of range or not among the allowed choices. Exits with
+status 1 when there are problems, for use in scripts.
+
+Examples:
+  fintrack config validate
+  fintrack config validate --config ./test-config.yaml`,
+		Args: cobra.NoArgs,
+		// The problems are already listed; main reports the error and exits 1
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := config.FilePath()
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			result := &ConfigValidation{File: path, UnknownKeys: []string{}, Errors: []string{}}
+			if _, err := os.Stat(path); err == nil {
+				result.FileExists = true
+				unknown, err := config.UnknownKeys(path)
+				if err != nil {
+					return output.PrintError(cmd, err)
+				}
+				result.UnknownKeys = append(result.UnknownKeys, unknown...)
+			}
+			for _, problem := range config.Get().Validate() {
+				result.Errors = append(result.Errors, problem.Error())
+			}
+			result.Valid = len(result.UnknownKeys) == 0 && len(result.Errors) == 0
+
+			if output.GetFormat(cmd) == output.FormatJSON {
+				if err := output.Print(cmd, result); err != nil {
+					return err
+				}
+			} else {
+				printConfigValidation(result)
+			}
+			if !result.Valid {
+				return errInvalidConfig
+			}
+			return nil
+		},
+	}
+}
+
+func newConfigInitCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "init",
+		Short: "Set up the database connection interactively",
+		Long: `Ask for the database connection settings and default currency, test the
+connection and save the answers to the config file. Press Enter to keep the
+value shown in brackets. Other settings and comments in an existing file are
+kept.
+
+The password is saved in plain text in a file only you can read; leave it
+blank to supply it through FINTRACK_DB_PASSWORD, database.password_file or
+database.password_command instead. A database.url in the config file takes
+precedence over these settings. While a profile is active, the answers are
+saved in that profile.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := config.FilePath()
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			out := cmd.OutOrStdout()
+			reader := bufio.NewReader(cmd.InOrStdin())
+			ask := func(label, current string) string {
+				if current != "" {
+					fmt.Fprintf(out, "%s [%s]: ", label, current)
+				} else {
+					fmt.Fprintf(out, "%s: ", label)
+				}
+				answer, _ := reader.ReadString('\n')
+				if answer = strings.TrimSpace(answer); answer != "" {
+					return answer
+				}
+				return current
+			}
+
+			if profile := config.ActiveProfile(); profile != "" {
+				fmt.Fprintf(out, "Configuring profile %s in %s\n\n", profile, path)
+			} else {
+				fmt.Fprintf(out, "Configuring %s\n\n", path)
+			}
+
+			values := map[string]string{}
+			for _, prompt := range configInitPrompts {
+				current, _ := config.Value(prompt.key)
+				if current == nil {
+					current = ""
+				}
+				if value := ask(prompt.label, fmt.Sprint(current)); value != "" {
+					values[prompt.key] = value
+				}
+			}
+			if password := ask("Database password (blank to use FINTRACK_DB_PASSWORD)", ""); password != "" {
+				values["database.password"] = password
+			}
+
+			keys := make([]string, 0, len(values))
+			for key := range values {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				if err := config.Override(key, values[key]); err != nil {
+					return output.PrintError(cmd, fmt.Errorf("invalid value for %s: %v", key, err))
+				}
+			}
+			for _, problem := range config.Get().Validate() {
+				if key := strings.SplitN(problem.Error(), ":", 2)[0]; values[key] != "" {
+					return output.PrintError(cmd, problem)
+				}
+			}
+
+			fmt.Fprint(out, "\nTesting database connection... ")
+			if err := db.Init(); err != nil {
+				fmt.Fprintf(out, "failed\n  %v\n\n", err)
+				if !strings.HasPrefix(strings.ToLower(ask("Save anyway? [y/N]", "")), "y") {
+					fmt.Fprintln(out, "Nothing saved.")
+					return nil
+				}
+			} else {
+				fmt.Fprintln(out, "ok")
+			}
+
+			saved := make(map[string]string, len(values))
+			for key, value := range values {
+				saved[config.ProfileKey(key)] = value
+			}
+			if err := config.SetValues(path, saved); err != nil {
+				return output.PrintError(cmd, err)
+			}
+			fmt.Fprintf(out, "Saved configuration to %s\n", path)
+			return nil
+		},
+	}
+}
+
+func newConfigProfilesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profiles",
+		Short: "Manage named profiles",
+		Long: `Profiles keep separate ledgers, such as personal, business and test, each
+with its own database and defaults. They are defined under profiles in the
+config file; settings a profile leaves out come from the top level, except
+that a profile giving any connection setting (url, host, port, database, user,
+sslmode or a password setting) uses defaults for the others:
+
+  profiles:
+    business:
+      database:
+        url: "postgresql://localhost:5432/fintrack_business"
+      defaults:
+        account: "Business Checking"
+
+A profile is selected by --profile, then FINTRACK_PROFILE, then the profile
+key in the config file, which "config profiles use" sets.`,
+	}
+
+	cmd.AddCommand(newConfigProfilesListCmd())
+	cmd.AddCommand(newConfigProfilesUseCmd())
+
+	return cmd
+}
+
+func newConfigProfilesListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List profiles",
+		Long: `List the profiles in the config file with their database and default
+account. The active profile is marked with *; settings a profile leaves to the
+top level show as "inherited".
+
+Examples:
+  fintrack config profiles list
+  fintrack config profiles list --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := config.Get()
+			profiles := make([]ConfigProfile, 0, len(cfg.Profiles))
+			for _, name := range config.ProfileNames() {
+				p := cfg.Profiles[name]
+				profiles = append(profiles, ConfigProfile{
+					Name:     name,
+					Active:   name == config.ActiveProfile(),
+					Database: config.ProfileDatabase(p),
+					Account:  p.Defaults.Account,
+					Currency: p.Defaults.Currency,
+				})
+			}
+
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.Print(cmd, profiles)
+			}
+			if len(profiles) == 0 {
+				fmt.Println("No profiles defined. Add them under profiles in the config file.")
+				return nil
+			}
+
+			table := output.NewTable("", "NAME", "DATABASE", "DEFAULT ACCOUNT", "CURRENCY")
+			for _, p := range profiles {
+				marker := ""
+				if p.Active {
+					marker = "*"
+				}
+				table.AddRow(marker, p.Name, inherited(p.Database), inherited(p.Account), inherited(p.Currency))
+			}
+			table.Print()
+			return nil
+		},
+	}
+}
+
+func newConfigProfilesUseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use NAME",
+		Short: "Make a profile the default",
+		Long: `Save NAME as the profile used when neither --profile nor FINTRACK_PROFILE
+is given. Run "fintrack config set profile ''" to go back to the top-level
+settings.
+
+Examples:
+  fintrack config profiles use business`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := strings.ToLower(args[0])
+			if _, ok := config.Get().Profiles[name]; !ok {
+				return output.PrintError(cmd, fmt.Errorf("unknown profile %q (profiles: %s)",
+					args[0], strings.Join(config.ProfileNames(), ", ")))
+			}
+
+			path, err := config.FilePath()
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+			if err := config.SetValue(path, "profile", name); err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			if env := os.Getenv("FINTRACK_PROFILE"); env != "" && !strings.EqualFold(env, name) {
+				fmt.Fprintf(os.Stderr, "Note: FINTRACK_PROFILE=%s takes precedence in this shell\n", env)
+			}
+			return output.PrintSuccess(cmd, fmt.Sprintf("Using profile %s (saved in %s)", name, path))
+		},
+	}
+}
+
+// inherited shows a profile setting, or that the top-level setting applies
+func inherited(value string) string {
+	if value == "" {
+		return "inherited"
+	}
+	return value
+}
+
+// configInitPrompts are the settings config init asks for, besides the password
+var configInitPrompts = []struct {
+	key   string
+	label string
+}{
+	{"database.host", "Database host"},
+	{"database.port", "Database port"},
+	{"database.database", "Database name"},
+	{"database.user", "Database user"},
+	{"database.sslmode", "SSL mode (disable, require, verify-full, ...)"},
+	{"defaults.currency", "Default currency"},
+}
+
+// yamlRuneEscape matches the escapes YAML uses for characters beyond the
+// Basic Multilingual Plane, such as emoji, unless the backslash is itself escaped
+var yamlRuneEscape = regexp.MustCompile(`(^|[^\\])\\U([0-9A-F]{8})`)
+
+// formatYAML formats settings as YAML with two-space indentation, keeping emoji
+// such as calendar symbols readable
+func formatYAML(v interface{}) (string, error) {
+	var b strings.Builder
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return yamlRuneEscape.ReplaceAllStringFunc(b.String(), func(match string) string {
+		parts := yamlRuneEscape.FindStringSubmatch(match)
+		code, _ := strconv.ParseUint(parts[2], 16, 32)
+		return parts[1] + string(rune(code))
+	}), nil
+}
+
+func printConfigValidation(result *ConfigValidation) {
+	if result.FileExists {
+		fmt.Printf("Config file: %s\n", result.File)
+	} else {
+		fmt.Printf("Config file: %s (not found; checking defaults)\n", result.File)
+	}
+
+	for _, key := range result.UnknownKeys {
+		fmt.Printf("  unknown key: %s\n", key)
+	}
+	for _, problem := range result.Errors {
+		fmt.Printf("  invalid: %s\n", problem)
+	}
+
+	if result.Valid {
+		fmt.Println("Configuration is valid.")
+		return
+	}
+	n := len(result.UnknownKeys) + len(result.Errors)
+	noun := "problems"
+	if n == 1 {
+		noun = "problem"
+	}
+	fmt.Printf("Found %d %s.\n", n, noun)
+}
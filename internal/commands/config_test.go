@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/fintrack/fintrack/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConfigCmd(t *testing.T) {
+	cmd := NewConfigCmd()
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "config", cmd.Use)
+	assert.Equal(t, "Configuration management", cmd.Short)
+	assert.True(t, cmd.HasSubCommands())
+}
+
+func TestConfigCmd_Subcommands(t *testing.T) {
+	cmd := NewConfigCmd()
+
+	subcommands := []string{"show", "get", "set", "validate", "init", "profiles"}
+	for _, sub := range subcommands {
+		found, _, err := cmd.Find([]string{sub})
+		assert.NoError(t, err)
+		assert.Equal(t, sub, found.Name(), "Expected subcommand '%s' not found", sub)
+	}
+}
+
+func TestConfigProfilesCmd_Subcommands(t *testing.T) {
+	cmd := newConfigProfilesCmd()
+
+	for _, sub := range []string{"list", "use"} {
+		found, _, err := cmd.Find([]string{sub})
+		assert.NoError(t, err)
+		assert.Equal(t, sub, found.Name(), "Expected subcommand '%s' not found", sub)
+	}
+
+	found, _, err := cmd.Find([]string{"ls"})
+	assert.NoError(t, err)
+	assert.Equal(t, "list", found.Name())
+
+	assert.Error(t, newConfigProfilesUseCmd().Args(nil, []string{}))
+	assert.NoError(t, newConfigProfilesUseCmd().Args(nil, []string{"business"}))
+}
+
+func TestInherited(t *testing.T) {
+	assert.Equal(t, "inherited", inherited(""))
+	assert.Equal(t, "Business Checking", inherited("Business Checking"))
+}
+
+func TestConfigCmd_Args(t *testing.T) {
+	assert.Error(t, newConfigGetCmd().Args(nil, []string{}))
+	assert.NoError(t, newConfigGetCmd().Args(nil, []string{"database.host"}))
+	assert.Error(t, newConfigSetCmd().Args(nil, []string{"database.host"}))
+	assert.NoError(t, newConfigSetCmd().Args(nil, []string{"database.host", "db"}))
+}
+
+func TestNeedsDatabase(t *testing.T) {
+	root := &cobra.Command{Use: "fintrack"}
+	configCmd := NewConfigCmd()
+	accountCmd := NewAccountCmd()
+	root.AddCommand(configCmd, accountCmd)
+
+	show, _, err := root.Find([]string{"config", "show"})
+	assert.NoError(t, err)
+	assert.False(t, NeedsDatabase(show))
+	assert.False(t, NeedsDatabase(configCmd))
+	assert.True(t, NeedsDatabase(accountCmd))
+	assert.True(t, NeedsDatabase(root))
+}
+
+func TestConfigValidate_ExitStatus(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Reset()
+	assert.NoError(t, config.Init("", ""))
+
+	cmd := newConfigValidateCmd()
+	cmd.SetArgs([]string{})
+	assert.NoError(t, cmd.Execute())
+
+	assert.NoError(t, config.Override("calendar.week_start", "5"))
+	cmd = newConfigValidateCmd()
+	cmd.SetArgs([]string{})
+	assert.Equal(t, errInvalidConfig, cmd.Execute())
+}
+
+func TestFormatYAML(t *testing.T) {
+	text, err := formatYAML(map[string]interface{}{
+		"calendar": map[string]interface{}{"symbols": map[string]interface{}{"bill": "📄", "path": `C:\Users`}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "calendar:\n  symbols:\n    bill: \"📄\"\n    path: C:\\Users\n", text)
+}
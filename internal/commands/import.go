@@ -42,13 +42,21 @@ func newImportCSVCmd() *cobra.Command {
 		dryRun         bool
 		skipDuplicates bool
 		batchSize      int
+		matchRecurring bool
+		matchWindow    int
 	)
 
 	cmd := &cobra.Command{
 		Use:   "csv FILE",
 		Short: "Import transactions from CSV file",
-		Long:  "Import transactions from CSV files.",
-		Args:  cobra.ExactArgs(1),
+		Long: `Import transactions from CSV files.
+
+Rows that pay an expected recurring item on the account (a similar payee, an
+amount within 10% and a date within --match-window days of the due date) are
+linked to the item, which advances to its next due date. Transactions already
+generated for that due date are replaced by the imported row. Recurring bills due
+within the imported period with no matching row are reported as overdue.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			filePath := args[0]
 
@@ -73,11 +81,13 @@ func newImportCSVCmd() *cobra.Command {
 
 			// Build import options
 			opts := services.ImportOptions{
-				AccountID:      accID,
-				Mapping:        mapping,
-				DryRun:         dryRun,
-				SkipDuplicates: skipDuplicates,
-				BatchSize:      batchSize,
+				AccountID:       accID,
+				Mapping:         mapping,
+				DryRun:          dryRun,
+				SkipDuplicates:  skipDuplicates,
+				BatchSize:       batchSize,
+				MatchRecurring:  matchRecurring,
+				MatchWindowDays: matchWindow,
 			}
 
 			// Run import
@@ -86,6 +96,12 @@ func newImportCSVCmd() *cobra.Command {
 			if err != nil {
 				return output.PrintError(cmd, err)
 			}
+			if !dryRun {
+				// Matched payments can advance schedules, so bring bill reminders up to date
+				if _, err := syncReminders(); err != nil {
+					return output.PrintError(cmd, err)
+				}
+			}
 
 			// Output results
 			if output.GetFormat(cmd) == output.FormatJSON {
@@ -109,6 +125,8 @@ func newImportCSVCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview import without saving")
 	cmd.Flags().BoolVar(&skipDuplicates, "skip-duplicates", false, "Skip duplicate transactions")
 	cmd.Flags().IntVar(&batchSize, "batch-size", 100, "Batch size for database inserts")
+	cmd.Flags().BoolVar(&matchRecurring, "match-recurring", true, "Link rows to expected recurring payments")
+	cmd.Flags().IntVar(&matchWindow, "match-window", 5, "Days either side of a due date to match recurring payments")
 
 	mustMarkRequired(cmd, "account")
 
@@ -198,6 +216,15 @@ func printImportSummary(cmd *cobra.Command, filePath string, result *services.Im
 	fmt.Printf("Imported: %d\n", result.ImportedRecords)
 	fmt.Printf("Skipped: %d\n", result.SkippedRecords)
 	fmt.Printf("Failed: %d\n", result.FailedRecords)
+	fmt.Printf("Matched schedules: %d\n", result.MatchedRecurring)
+
+	if len(result.OverdueBills) > 0 {
+		fmt.Println("\nOverdue bills (no matching payment found):")
+		for _, bill := range result.OverdueBills {
+			fmt.Printf("  %s  %s  %s  (%d days overdue)\n",
+				bill.DueDate.Format("2006-01-02"), bill.Name, formatAmountCents(bill.AmountCents), bill.DaysOverdue)
+		}
+	}
 
 	if len(result.Errors) > 0 {
 		fmt.Println("\nErrors:")
@@ -0,0 +1,21 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewImportCmd(t *testing.T) {
+	cmd := NewImportCmd()
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "import", cmd.Use)
+	assert.Equal(t, "Import data from external files (experimental)", cmd.Short)
+
+	// Check for subcommands
+	csvCmd, _, _ := cmd.Find([]string{"csv"})
+	assert.NotNil(t, csvCmd)
+
+	histCmd, _, _ := cmd.Find([]string{"history"})
+	assert.NotNil(t, histCmd)
+}
@@ -0,0 +1,371 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/config"
+	"github.com/fintrack/fintrack/internal/db"
+	"github.com/fintrack/fintrack/internal/output"
+	"github.com/fintrack/fintrack/internal/services"
+	"github.com/spf13/cobra"
+)
+
+// NewProjectCmd creates the project command
+func NewProjectCmd() *cobra.Command {
+	var (
+		days     int
+		account  string
+		scenario string
+		daily    bool
+		noSave   bool
+		simulate int
+		seed     int64
+		whatIf   string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "project",
+		Aliases: []string{"p"},
+		Short:   "Cash flow projection",
+		Long: `Project account balances day by day from current balances, scheduled
+recurring items and historical discretionary spending.
+
+Discretionary spending is every expense in the last
+projection.historical_window_days days that didn't come from a recurring item.
+The scenario picks a percentile of its weekly totals (from projection.scenarios):
+conservative 75th, moderate 50th, optimistic 25th.
+
+The projection is saved for later use, replacing the previous projection of the
+same scenario and account; custom scenarios added to projection.scenarios are
+shown but not saved. By default only days with scheduled items are listed;
+use --daily to list every day.
+
+--simulate N runs N Monte Carlo simulations instead, drawing each day's
+spending from a random day of the historical window, and reports the 10th, 50th
+and 90th percentile balances and each account's chance of being overdrawn. The
+bands are saved as the conservative (P10), moderate (P50) and optimistic (P90)
+projections, so one simulation replaces all three saved projections for the
+account, not just the --scenario one; use --no-save to keep them. Runs with the
+same --seed give the same result.
+
+--what-if FILE compares the projection with a hypothetical one in which the
+changes listed in a YAML file are applied, without touching real data or saving
+either projection. Each change adds, removes or modifies a recurring item, or
+adds a one-off transaction:
+
+  name: Rent increase
+  changes:
+    - action: modify        # change the amount from a date
+      item: Rent
+      amount: -1700
+      from: 2027-03-01
+    - action: remove        # cancel, optionally from a date
+      item: Netflix
+    - action: add           # new recurring item
+      name: Car payment
+      amount: -350
+      account: Checking
+      frequency: monthly
+      day: 15
+      start: 2026-12-15
+    - action: once          # one-off transaction
+      name: Vacation
+      amount: -2000
+      account: Checking
+      date: 2027-02-10
+
+Examples:
+  fintrack project
+  fintrack project --days 30 --account Checking
+  fintrack project --scenario conservative --daily
+  fintrack project --days 90 --json
+  fintrack project --simulate 1000 --seed 42
+  fintrack project --what-if rent-increase.yaml`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := config.Get().Projection
+			if !cmd.Flags().Changed("days") && cfg.DefaultDays > 0 {
+				days = cfg.DefaultDays
+			}
+			if days <= 0 {
+				return output.PrintError(cmd, fmt.Errorf("--days must be positive"))
+			}
+			if simulate != 0 && whatIf != "" {
+				return output.PrintError(cmd, fmt.Errorf("--simulate and --what-if cannot be used together"))
+			}
+			if simulate != 0 {
+				if !cmd.Flags().Changed("seed") {
+					seed = time.Now().UnixNano()
+				}
+				return runSimulation(cmd, services.SimulationOptions{
+					Days:                 days,
+					HistoricalWindowDays: cfg.HistoricalWindowDays,
+					Runs:                 simulate,
+					Seed:                 seed,
+				}, account, daily, noSave)
+			}
+
+			if !cmd.Flags().Changed("scenario") && cfg.Scenario != "" {
+				scenario = cfg.Scenario
+			}
+			scenario = strings.ToLower(scenario)
+
+			percentile, err := services.ScenarioPercentile(cfg.Scenarios, scenario)
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			opts := services.ProjectionOptions{
+				Days:                 days,
+				Scenario:             scenario,
+				Percentile:           percentile,
+				HistoricalWindowDays: cfg.HistoricalWindowDays,
+			}
+			if account != "" {
+				accountID, err := resolveAccountID(account)
+				if err != nil {
+					return output.PrintError(cmd, err)
+				}
+				opts.AccountID = &accountID
+			}
+
+			svc := services.NewProjectionService(db.Get())
+			if whatIf != "" {
+				scenario, err := services.LoadWhatIf(whatIf)
+				if err != nil {
+					return output.PrintError(cmd, err)
+				}
+				comparison, err := svc.CompareWhatIf(opts, scenario)
+				if err != nil {
+					return output.PrintError(cmd, err)
+				}
+				if output.GetFormat(cmd) == output.FormatJSON {
+					return output.Print(cmd, comparison)
+				}
+				printWhatIf(comparison, daily)
+				return nil
+			}
+
+			projection, err := svc.Project(opts)
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+			if !noSave {
+				if services.IsStoredScenario(scenario) {
+					if err := svc.Save(projection); err != nil {
+						return output.PrintError(cmd, err)
+					}
+				} else {
+					fmt.Fprintf(os.Stderr, "Note: projections of custom scenario %s aren't saved\n", scenario)
+				}
+			}
+
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.Print(cmd, projection)
+			}
+
+			printProjection(projection, cfg.ConfidenceThreshold, daily)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&days, "days", "d", 90, "Days to project (default: projection.default_days)")
+	cmd.Flags().StringVar(&account, "account", "", "Account ID or name (default: all active accounts)")
+	cmd.Flags().StringVarP(&scenario, "scenario", "s", services.ScenarioModerate, "Scenario (conservative, moderate, optimistic; default: projection.scenario)")
+	cmd.Flags().BoolVar(&daily, "daily", false, "List every day, not just days with scheduled items")
+	cmd.Flags().BoolVar(&noSave, "no-save", false, "Don't store the projection")
+	cmd.Flags().IntVar(&simulate, "simulate", 0, "Run N Monte Carlo simulations")
+	cmd.Flags().Int64Var(&seed, "seed", 0, "Random seed for --simulate (default: based on the time)")
+	cmd.Flags().StringVar(&whatIf, "what-if", "", "Compare with the changes in a what-if YAML file")
+
+	return cmd
+}
+
+// runSimulation runs, saves and prints a Monte Carlo simulation
+func runSimulation(cmd *cobra.Command, opts services.SimulationOptions, account string, daily, noSave bool) error {
+	if account != "" {
+		accountID, err := resolveAccountID(account)
+		if err != nil {
+			return output.PrintError(cmd, err)
+		}
+		opts.AccountID = &accountID
+	}
+
+	svc := services.NewProjectionService(db.Get())
+	sim, err := svc.Simulate(opts)
+	if err != nil {
+		return output.PrintError(cmd, err)
+	}
+	if !noSave {
+		if err := svc.SaveSimulation(sim); err != nil {
+			return output.PrintError(cmd, err)
+		}
+	}
+
+	if output.GetFormat(cmd) == output.FormatJSON {
+		return output.Print(cmd, sim)
+	}
+
+	printSimulation(sim, daily)
+	return nil
+}
+
+// printProjection prints a projection as a table followed by its summary
+func printProjection(p *services.Projection, threshold float64, daily bool) {
+	scope := "all accounts"
+	if len(p.Accounts) == 1 && p.AccountID != nil {
+		scope = p.Accounts[0].Name
+	}
+	fmt.Printf("CASH FLOW PROJECTION (%d days, %s scenario, %s)\n\n", len(p.Days), p.Scenario, scope)
+
+	table := output.NewTable("DATE", "INCOME", "EXPENSES", "BALANCE", "SCHEDULED")
+	for i, day := range p.Days {
+		if !daily && len(day.Events) == 0 && i != len(p.Days)-1 {
+			continue
+		}
+		table.AddRow(
+			day.Date.Format("2006-01-02"),
+			output.FormatCurrencyCents(day.IncomeCents, "USD"),
+			output.FormatCurrencyCents(day.ExpensesCents, "USD"),
+			output.FormatCurrencyCents(day.BalanceCents, "USD"),
+			strings.Join(day.Events, ", "),
+		)
+	}
+	table.Print()
+
+	if chart := reportChart(); chart != nil && len(p.Days) > 0 {
+		balances := make([]float64, 0, len(p.Days))
+		for _, day := range p.Days {
+			balances = append(balances, float64(day.BalanceCents))
+		}
+		fmt.Println()
+		fmt.Print(chart.Line(balances, 0, chartCurrency))
+	}
+
+	spend := int64(0)
+	for _, account := range p.Accounts {
+		spend += account.DailySpendCents
+	}
+
+	fmt.Println("\nSummary:")
+	fmt.Printf("  Starting Balance:    %s\n", output.FormatCurrencyCents(p.StartBalanceCents, "USD"))
+	fmt.Printf("  Scheduled Income:    %s\n", output.FormatCurrencyCents(p.ScheduledIncomeCents, "USD"))
+	fmt.Printf("  Scheduled Expenses:  %s\n", output.FormatCurrencyCents(p.ScheduledExpensesCents, "USD"))
+	fmt.Printf("  Other Spending:      %s (about %s a day)\n",
+		output.FormatCurrencyCents(p.DiscretionaryCents, "USD"), output.FormatCurrencyCents(spend, "USD"))
+	fmt.Printf("  Net Change:          %s\n", formatAmountCents(p.EndingBalanceCents-p.StartBalanceCents))
+	fmt.Printf("  Ending Balance:      %s\n", output.FormatCurrencyCents(p.EndingBalanceCents, "USD"))
+
+	fmt.Printf("\nConfidence: %s (based on %d days of transaction history)\n",
+		output.FormatPercentage(p.Confidence), p.HistoryDays)
+	for _, day := range p.Days {
+		if day.Confidence < threshold {
+			fmt.Printf("Projections from %s fall below the %s confidence threshold.\n",
+				day.Date.Format("2006-01-02"), output.FormatPercentage(threshold))
+			break
+		}
+	}
+
+	if p.FirstNegative != nil {
+		symbol := "!"
+		if config.Get().Output.Unicode {
+			symbol = "⚠"
+		}
+		fmt.Printf("\n%s Warning: %s is projected to go negative on %s (%s)\n", symbol,
+			p.FirstNegative.AccountName,
+			p.FirstNegative.Date.Format("2006-01-02"),
+			output.FormatCurrencyCents(p.FirstNegative.BalanceCents, "USD"))
+	}
+}
+
+// printWhatIf prints the baseline and what-if balances side by side, on days with
+// scheduled items in either unless daily is set, and the difference at the end
+func printWhatIf(c *services.WhatIfComparison, daily bool) {
+	fmt.Printf("WHAT-IF: %s (%d days, %s scenario)\n\n", c.Name, len(c.Baseline.Days), c.Baseline.Scenario)
+
+	if len(c.Changes) > 0 {
+		fmt.Println("Changes:")
+		for _, change := range c.Changes {
+			fmt.Printf("  %s\n", change)
+		}
+		fmt.Println()
+	}
+
+	table := output.NewTable("DATE", "BASELINE", "WHAT-IF", "DIFFERENCE", "SCHEDULED")
+	for i, base := range c.Baseline.Days {
+		scenario := c.Scenario.Days[i]
+		if !daily && len(base.Events) == 0 && len(scenario.Events) == 0 && i != len(c.Baseline.Days)-1 {
+			continue
+		}
+		table.AddRow(
+			base.Date.Format("2006-01-02"),
+			output.FormatCurrencyCents(base.BalanceCents, "USD"),
+			output.FormatCurrencyCents(scenario.BalanceCents, "USD"),
+			formatAmountCents(scenario.BalanceCents-base.BalanceCents),
+			strings.Join(scenario.Events, ", "),
+		)
+	}
+	table.Print()
+
+	fmt.Println("\nEnding Balance:")
+	fmt.Printf("  Baseline:    %s\n", output.FormatCurrencyCents(c.Baseline.EndingBalanceCents, "USD"))
+	fmt.Printf("  What-if:     %s\n", output.FormatCurrencyCents(c.Scenario.EndingBalanceCents, "USD"))
+	fmt.Printf("  Difference:  %s\n", formatAmountCents(c.DeltaCents))
+
+	var warnings []string
+	for _, p := range []struct {
+		label      string
+		projection *services.Projection
+	}{{"Baseline", c.Baseline}, {"What-if", c.Scenario}} {
+		if neg := p.projection.FirstNegative; neg != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: %s is projected to go negative on %s (%s)", p.label,
+				neg.AccountName, neg.Date.Format("2006-01-02"), output.FormatCurrencyCents(neg.BalanceCents, "USD")))
+		}
+	}
+	if len(warnings) > 0 {
+		fmt.Printf("\n%s\n", strings.Join(warnings, "\n"))
+	}
+}
+
+// printSimulation prints a simulation's balance bands, weekly unless daily is
+// set, followed by each account's outcomes
+func printSimulation(sim *services.Simulation, daily bool) {
+	fmt.Printf("CASH FLOW SIMULATION (%d days, %d runs, seed %d)\n\n", len(sim.Days), sim.Runs, sim.Seed)
+
+	table := output.NewTable("DATE", "P10", "P50", "P90")
+	for i, day := range sim.Days {
+		if !daily && (i+1)%7 != 0 && i != len(sim.Days)-1 {
+			continue
+		}
+		table.AddRow(
+			day.Date.Format("2006-01-02"),
+			output.FormatCurrencyCents(day.P10Cents, "USD"),
+			output.FormatCurrencyCents(day.P50Cents, "USD"),
+			output.FormatCurrencyCents(day.P90Cents, "USD"),
+		)
+	}
+	table.Print()
+
+	fmt.Println("\nEnding balances by account:")
+	accounts := output.NewTable("ACCOUNT", "START", "P10", "P50", "P90", "OVERDRAFT RISK")
+	for _, account := range sim.Accounts {
+		risk := output.FormatPercentage(account.OverdraftProbability)
+		if services.IsLiabilityAccount(account.Type) {
+			risk = "-"
+		}
+		accounts.AddRow(
+			account.Name,
+			output.FormatCurrencyCents(account.StartBalanceCents, "USD"),
+			output.FormatCurrencyCents(account.P10EndingCents, "USD"),
+			output.FormatCurrencyCents(account.P50EndingCents, "USD"),
+			output.FormatCurrencyCents(account.P90EndingCents, "USD"),
+			risk,
+		)
+	}
+	accounts.Print()
+
+	fmt.Printf("\nBased on %d days of transaction history. Rerun with --seed %d to reproduce.\n", sim.HistoryDays, sim.Seed)
+}
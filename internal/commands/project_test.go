@@ -0,0 +1,27 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/fintrack/fintrack/internal/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProjectCmd(t *testing.T) {
+	cmd := NewProjectCmd()
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "project", cmd.Use)
+	assert.Contains(t, cmd.Aliases, "p")
+	assert.Equal(t, "Cash flow projection", cmd.Short)
+	assert.NotNil(t, cmd.RunE)
+}
+
+func TestProjectCmd_Flags(t *testing.T) {
+	cmd := NewProjectCmd()
+
+	for _, flag := range []string{"days", "account", "scenario", "daily", "no-save", "simulate", "seed", "what-if"} {
+		assert.NotNil(t, cmd.Flags().Lookup(flag), "Expected flag '%s' not found", flag)
+	}
+	assert.Equal(t, "90", cmd.Flags().Lookup("days").DefValue)
+	assert.Equal(t, services.ScenarioModerate, cmd.Flags().Lookup("scenario").DefValue)
+}
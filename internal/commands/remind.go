@@ -0,0 +1,445 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/config"
+	"github.com/fintrack/fintrack/internal/db"
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/fintrack/fintrack/internal/output"
+	"github.com/fintrack/fintrack/internal/services"
+	"github.com/spf13/cobra"
+)
+
+// NewRemindCmd creates the remind command
+func NewRemindCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "remind",
+		Aliases: []string{"r"},
+		Short:   "Manage reminders",
+		Long: `Manage reminders for bills, budgets and anything else you need to follow up on.
+
+Budget alerts and bill reminders are created automatically. Reminders due today
+or earlier are shown before every command when reminders.show_on_startup is set.
+
+Examples:
+  fintrack remind add "Call the bank" --date 2026-11-02 --priority high
+  fintrack remind list
+  fintrack remind overdue
+  fintrack remind snooze 4 --days 3
+  fintrack remind dismiss 4 7
+  fintrack remind sync`,
+	}
+
+	cmd.AddCommand(newRemindAddCmd())
+	cmd.AddCommand(newRemindListCmd())
+	cmd.AddCommand(newRemindDismissCmd())
+	cmd.AddCommand(newRemindSnoozeCmd())
+	cmd.AddCommand(newRemindOverdueCmd())
+	cmd.AddCommand(newRemindSyncCmd())
+
+	return cmd
+}
+
+func newRemindAddCmd() *cobra.Command {
+	var (
+		date     string
+		at       string
+		message  string
+		priority string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "add TITLE",
+		Aliases: []string{"create", "new"},
+		Short:   "Add a reminder",
+		Long: `Add a custom reminder.
+
+The reminder is due today unless --date is given. --time defaults to
+reminders.default_time from the config.
+
+Priority must be one of: low, normal, high, urgent
+
+Examples:
+  fintrack remind add "Renew car registration" --date 2026-11-30
+  fintrack remind add "Dispute card charge" -p urgent -m "Ref 88123"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !services.IsValidReminderPriority(priority) {
+				return output.PrintError(cmd, fmt.Errorf("invalid priority: %s (valid: low, normal, high, urgent)", priority))
+			}
+
+			remindDate := reminderToday()
+			if date != "" {
+				t, err := time.Parse("2006-01-02", date)
+				if err != nil {
+					return output.PrintError(cmd, fmt.Errorf("invalid date format (use YYYY-MM-DD): %v", err))
+				}
+				remindDate = t
+			}
+
+			if at == "" {
+				at = config.Get().Reminders.DefaultTime
+			}
+
+			reminder := &models.Reminder{
+				Type:       models.ReminderTypeCustom,
+				Title:      args[0],
+				Message:    message,
+				RemindDate: remindDate,
+				Priority:   priority,
+			}
+			if at != "" {
+				t, err := time.Parse("15:04", at)
+				if err != nil {
+					return output.PrintError(cmd, fmt.Errorf("invalid time format (use HH:MM): %v", err))
+				}
+				remindAt := time.Date(remindDate.Year(), remindDate.Month(), remindDate.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC)
+				reminder.RemindTime = &remindAt
+			}
+
+			repo := repositories.NewReminderRepository(db.Get())
+			if err := repo.Create(reminder); err != nil {
+				return output.PrintError(cmd, fmt.Errorf("failed to create reminder: %w", err))
+			}
+
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.Print(cmd, reminder)
+			}
+
+			fmt.Printf("✓ Reminder created successfully (ID: %d)\n", reminder.ID)
+			fmt.Printf("  Title: %s\n", reminder.Title)
+			fmt.Printf("  Due: %s\n", formatReminderWhen(reminder))
+			fmt.Printf("  Priority: %s\n", reminder.Priority)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&date, "date", "", "Due date (YYYY-MM-DD, default: today)")
+	cmd.Flags().StringVar(&at, "time", "", "Time of day (HH:MM, default: reminders.default_time)")
+	cmd.Flags().StringVarP(&message, "message", "m", "", "Longer message")
+	cmd.Flags().StringVarP(&priority, "priority", "p", models.ReminderPriorityNormal, "Priority (low, normal, high, urgent)")
+
+	return cmd
+}
+
+func newRemindListCmd() *cobra.Command {
+	var (
+		all         bool
+		due         bool
+		reminderTyp string
+		priority    string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List reminders",
+		Long: `List reminders that have not been dismissed, oldest first.
+
+Examples:
+  fintrack remind list
+  fintrack remind list --due --priority high
+  fintrack remind list --type bill --all`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if priority != "" && !services.IsValidReminderPriority(priority) {
+				return output.PrintError(cmd, fmt.Errorf("invalid priority: %s (valid: low, normal, high, urgent)", priority))
+			}
+
+			if _, err := syncReminders(); err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			filter := repositories.ReminderFilter{Type: reminderTyp, IncludeDismissed: all}
+			if due {
+				today := reminderToday()
+				filter.Through = &today
+			}
+
+			repo := repositories.NewReminderRepository(db.Get())
+			reminders, err := repo.List(filter)
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			if priority != "" {
+				filtered := []*models.Reminder{}
+				for _, r := range reminders {
+					if services.PriorityAtLeast(r.Priority, priority) {
+						filtered = append(filtered, r)
+					}
+				}
+				reminders = filtered
+			}
+
+			return printReminders(cmd, reminders, "No reminders found.")
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Include dismissed reminders")
+	cmd.Flags().BoolVar(&due, "due", false, "Only reminders due today or earlier")
+	cmd.Flags().StringVarP(&reminderTyp, "type", "t", "", "Only this type (transaction, budget, bill, low_balance, custom)")
+	cmd.Flags().StringVarP(&priority, "priority", "p", "", "Only this priority or higher (low, normal, high, urgent)")
+
+	return cmd
+}
+
+func newRemindDismissCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "dismiss ID...",
+		Aliases: []string{"done"},
+		Short:   "Dismiss reminders",
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ids, err := parseReminderIDs(args)
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			repo := repositories.NewReminderRepository(db.Get())
+			for _, id := range ids {
+				if err := repo.Dismiss(id); err != nil {
+					return output.PrintError(cmd, fmt.Errorf("failed to dismiss reminder %d: %w", id, err))
+				}
+			}
+
+			if len(ids) == 1 {
+				return output.PrintSuccess(cmd, fmt.Sprintf("Reminder #%d dismissed", ids[0]))
+			}
+			return output.PrintSuccess(cmd, fmt.Sprintf("%d reminders dismissed", len(ids)))
+		},
+	}
+
+	return cmd
+}
+
+func newRemindSnoozeCmd() *cobra.Command {
+	var (
+		days  int
+		until string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "snooze ID",
+		Short: "Postpone a reminder",
+		Long: `Postpone a reminder to a later day. Snoozing a dismissed reminder brings it back.
+
+Examples:
+  fintrack remind snooze 4
+  fintrack remind snooze 4 --days 7
+  fintrack remind snooze 4 --until 2026-11-01`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ids, err := parseReminderIDs(args)
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			var snoozeUntil time.Time
+			if until != "" {
+				snoozeUntil, err = time.Parse("2006-01-02", until)
+				if err != nil {
+					return output.PrintError(cmd, fmt.Errorf("invalid until date format (use YYYY-MM-DD): %v", err))
+				}
+			} else {
+				if days <= 0 {
+					return output.PrintError(cmd, fmt.Errorf("--days must be greater than zero"))
+				}
+				snoozeUntil = reminderToday().AddDate(0, 0, days)
+			}
+			if !snoozeUntil.After(reminderToday()) {
+				return output.PrintError(cmd, fmt.Errorf("snooze date must be in the future"))
+			}
+
+			repo := repositories.NewReminderRepository(db.Get())
+			if err := repo.Snooze(ids[0], snoozeUntil); err != nil {
+				return output.PrintError(cmd, fmt.Errorf("failed to snooze reminder: %w", err))
+			}
+
+			return output.PrintSuccess(cmd, fmt.Sprintf("Reminder #%d snoozed until %s", ids[0], snoozeUntil.Format("2006-01-02")))
+		},
+	}
+
+	cmd.Flags().IntVar(&days, "days", 1, "Days to postpone by")
+	cmd.Flags().StringVar(&until, "until", "", "Postpone until this date (YYYY-MM-DD)")
+
+	return cmd
+}
+
+func newRemindOverdueCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "overdue",
+		Short: "List reminders past their due date",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := syncReminders(); err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			reminders, err := services.NewReminderService(db.Get()).Overdue()
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			return printReminders(cmd, reminders, "No overdue reminders.")
+		},
+	}
+
+	return cmd
+}
+
+func newRemindSyncCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Bring bill reminders up to date with scheduled payments",
+		Long: `Create or move a bill reminder for every active recurring expense, due
+reminder_days_before days ahead of its next date, and dismiss reminders for
+paused or ended schedules. Reminders left open for more than
+reminders.auto_dismiss_after_days are dismissed.
+
+This also runs after schedule generate and import, and before reminders are
+listed. The startup banner only reads reminders and never syncs.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			result, err := syncReminders()
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.Print(cmd, result)
+			}
+
+			cmd.Printf("✓ Reminders synced: %d created, %d updated, %d dismissed\n",
+				result.Created, result.Updated, result.Dismissed)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// PrintReminderBanner writes reminders due today or earlier, at or above
+// reminders.startup_priority_filter, to stderr before a command runs. It does
+// nothing when reminders.show_on_startup is off, in JSON mode, or for the remind
+// commands themselves. It only reads reminders, which are brought up to date by
+// remind sync, schedule generate and import. Failures are ignored so reminders
+// never block a command.
+func PrintReminderBanner(cmd *cobra.Command) {
+	cfg := config.Get().Reminders
+	if !cfg.ShowOnStartup || output.GetFormat(cmd) == output.FormatJSON || isRemindCmd(cmd) {
+		return
+	}
+
+	minPriority := cfg.StartupPriorityFilter
+	if !services.IsValidReminderPriority(minPriority) {
+		minPriority = models.ReminderPriorityNormal
+	}
+
+	reminders, err := services.NewReminderService(db.Get()).Due(minPriority)
+	if err != nil || len(reminders) == 0 {
+		return
+	}
+
+	writeReminderBanner(cmd.ErrOrStderr(), reminders, time.Now(), config.Get().Output.Unicode)
+}
+
+// writeReminderBanner writes the startup reminder summary, showing at most five
+func writeReminderBanner(w io.Writer, reminders []*models.Reminder, now time.Time, unicode bool) {
+	const maxShown = 5
+
+	bell := "!"
+	if unicode {
+		bell = "🔔"
+	}
+
+	noun := "reminders"
+	if len(reminders) == 1 {
+		noun = "reminder"
+	}
+	fmt.Fprintf(w, "%s %d %s due:\n", bell, len(reminders), noun)
+	for i, r := range reminders {
+		if i >= maxShown {
+			fmt.Fprintf(w, "  ... and %d more (fintrack remind list --due)\n", len(reminders)-maxShown)
+			break
+		}
+		fmt.Fprintf(w, "  #%d [%s] %s (%s %s)\n", r.ID, r.Priority, r.Title,
+			services.ReminderStatus(r, now), r.RemindDate.Format("2006-01-02"))
+	}
+	fmt.Fprintln(w)
+}
+
+// isRemindCmd reports whether cmd is the remind command or one of its subcommands
+func isRemindCmd(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Name() == "remind" {
+			return true
+		}
+	}
+	return false
+}
+
+// printReminders prints reminders as JSON or a table
+func printReminders(cmd *cobra.Command, reminders []*models.Reminder, empty string) error {
+	if output.GetFormat(cmd) == output.FormatJSON {
+		return output.Print(cmd, reminders)
+	}
+
+	if len(reminders) == 0 {
+		fmt.Println(empty)
+		return nil
+	}
+
+	now := time.Now()
+	table := output.NewTable("ID", "DUE", "PRIORITY", "TYPE", "TITLE", "STATUS")
+	for _, r := range reminders {
+		table.AddRow(
+			fmt.Sprintf("%d", r.ID),
+			formatReminderWhen(r),
+			r.Priority,
+			r.Type,
+			r.Title,
+			services.ReminderStatus(r, now),
+		)
+	}
+	table.Print()
+
+	return nil
+}
+
+// formatReminderWhen formats a reminder's date, with its time of day if set
+func formatReminderWhen(r *models.Reminder) string {
+	when := r.RemindDate.Format("2006-01-02")
+	if r.RemindTime != nil {
+		when += " " + r.RemindTime.Format("15:04")
+	}
+	return when
+}
+
+// parseReminderIDs parses reminder ID arguments
+func parseReminderIDs(args []string) ([]uint, error) {
+	ids := make([]uint, 0, len(args))
+	for _, arg := range args {
+		id, err := strconv.ParseUint(strings.TrimPrefix(arg, "#"), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reminder ID: %s", arg)
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids, nil
+}
+
+// syncReminders brings bill reminders up to date with the recurring schedules
+func syncReminders() (*services.ReminderSyncResult, error) {
+	return services.NewBillReminderService(db.Get(), config.Get().Reminders.AutoDismissAfterDays).Sync()
+}
+
+// reminderToday returns today's date at midnight UTC, the form reminder dates are stored in
+func reminderToday() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRemindCmd(t *testing.T) {
+	cmd := NewRemindCmd()
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "remind", cmd.Use)
+	assert.Contains(t, cmd.Aliases, "r")
+	assert.Equal(t, "Manage reminders", cmd.Short)
+	assert.True(t, cmd.HasSubCommands())
+}
+
+func TestRemindCmd_Subcommands(t *testing.T) {
+	cmd := NewRemindCmd()
+
+	subcommands := []string{"add", "list", "dismiss", "snooze", "overdue", "sync"}
+	for _, sub := range subcommands {
+		found, _, err := cmd.Find([]string{sub})
+		assert.NoError(t, err)
+		assert.Equal(t, sub, found.Name(), "Expected subcommand '%s' not found", sub)
+	}
+}
+
+func TestRemindAddCmd_Flags(t *testing.T) {
+	cmd := NewRemindCmd()
+	addCmd, _, _ := cmd.Find([]string{"add"})
+
+	for _, flag := range []string{"date", "time", "message", "priority"} {
+		assert.NotNil(t, addCmd.Flags().Lookup(flag), "Expected flag '%s' not found", flag)
+	}
+	assert.Equal(t, models.ReminderPriorityNormal, addCmd.Flags().Lookup("priority").DefValue)
+}
+
+func TestParseReminderIDs(t *testing.T) {
+	ids, err := parseReminderIDs([]string{"4", "#7"})
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{4, 7}, ids)
+
+	_, err = parseReminderIDs([]string{"4", "rent"})
+	assert.EqualError(t, err, "invalid reminder ID: rent")
+}
+
+func TestIsRemindCmd(t *testing.T) {
+	cmd := NewRemindCmd()
+	list, _, _ := cmd.Find([]string{"list"})
+	assert.True(t, isRemindCmd(cmd))
+	assert.True(t, isRemindCmd(list))
+	assert.False(t, isRemindCmd(NewScheduleCmd()))
+}
+
+func TestWriteReminderBanner(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	reminders := []*models.Reminder{
+		{ID: 3, Title: "Budget 'Dining' exceeded", Priority: models.ReminderPriorityUrgent, RemindDate: now},
+		{ID: 1, Title: "Pay rent", Priority: models.ReminderPriorityHigh, RemindDate: now.AddDate(0, 0, -2)},
+	}
+
+	buf := new(bytes.Buffer)
+	writeReminderBanner(buf, reminders, now, false)
+
+	out := buf.String()
+	assert.Contains(t, out, "! 2 reminders due:")
+	assert.Contains(t, out, "#3 [urgent] Budget 'Dining' exceeded (due 2026-10-16)")
+	assert.Contains(t, out, "#1 [high] Pay rent (overdue 2026-10-14)")
+}
+
+func TestWriteReminderBanner_Truncates(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	var reminders []*models.Reminder
+	for i := 1; i <= 7; i++ {
+		reminders = append(reminders, &models.Reminder{ID: uint(i), Title: "Reminder", Priority: models.ReminderPriorityNormal, RemindDate: now})
+	}
+
+	buf := new(bytes.Buffer)
+	writeReminderBanner(buf, reminders, now, true)
+
+	assert.Contains(t, buf.String(), "🔔 7 reminders due:")
+	assert.Contains(t, buf.String(), "... and 2 more")
+	assert.NotContains(t, buf.String(), "#6")
+}
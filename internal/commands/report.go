@@ -0,0 +1,476 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/config"
+	"github.com/fintrack/fintrack/internal/db"
+	"github.com/fintrack/fintrack/internal/output"
+	"github.com/fintrack/fintrack/internal/services"
+	"github.com/spf13/cobra"
+)
+
+// NewReportCmd creates the report command
+func NewReportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "report",
+		Aliases: []string{"rp"},
+		Short:   "Generate financial reports",
+		Long: `Generate financial reports from recorded transactions.
+
+Category reports list subcategories separately ("Food > Groceries") unless
+reports.subcategory_rollup is set or --rollup is given, in which case they are
+folded into their top-level category. Lines are sorted by reports.category_sort
+(name, amount or count) unless --sort is given.
+
+Every report can be printed as a table, as JSON with --json, or as CSV with --csv.`,
+	}
+
+	cmd.PersistentFlags().Bool("csv", false, "Output in CSV format")
+
+	cmd.AddCommand(newReportIncomeStatementCmd())
+	cmd.AddCommand(newReportSpendingCmd())
+	cmd.AddCommand(newReportNetWorthCmd())
+
+	return cmd
+}
+
+func newReportIncomeStatementCmd() *cobra.Command {
+	var (
+		from    string
+		to      string
+		account string
+		sortBy  string
+		rollup  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:     "income-statement",
+		Aliases: []string{"income", "is"},
+		Short:   "Income and expenses by category",
+		Long: `Total income and expenses by category over a date range, with net income
+and the savings rate.
+
+Transactions are grouped by their category's type, so a refund booked against
+an expense category reduces that category's spending. Transfers are left out.
+
+Examples:
+  fintrack report income-statement
+  fintrack report income-statement --from 2026-01-01 --to 2026-06-30
+  fintrack report income-statement --rollup --sort name
+  fintrack report income-statement --from 2026-01-01 --csv > income.csv`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := reportOptions(cmd, account, sortBy, rollup)
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			now := time.Now()
+			opts.From = services.MonthStart(now)
+			opts.To = now
+			if from != "" {
+				t, err := time.Parse("2006-01-02", from)
+				if err != nil {
+					return output.PrintError(cmd, fmt.Errorf("invalid from date format (use YYYY-MM-DD): %v", err))
+				}
+				opts.From = t
+			}
+			if to != "" {
+				t, err := time.Parse("2006-01-02", to)
+				if err != nil {
+					return output.PrintError(cmd, fmt.Errorf("invalid to date format (use YYYY-MM-DD): %v", err))
+				}
+				opts.To = t
+			}
+
+			statement, err := services.NewReportService(db.Get()).IncomeStatement(opts)
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			switch output.GetFormat(cmd) {
+			case output.FormatJSON:
+				return output.Print(cmd, statement)
+			case output.FormatCSV:
+				var rows [][]string
+				for _, section := range []*services.ReportSection{statement.Income, statement.Expenses} {
+					for _, line := range section.Lines {
+						rows = append(rows, append([]string{section.Type}, categoryLineCSV(line)...))
+					}
+				}
+				return output.PrintCSV(os.Stdout, []string{"section", "category", "amount", "count", "share"}, rows)
+			}
+
+			printIncomeStatement(statement)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "Start date (YYYY-MM-DD, default: first of this month)")
+	cmd.Flags().StringVar(&to, "to", "", "End date (YYYY-MM-DD, default: today)")
+	addReportFlags(cmd, &account, &sortBy, &rollup)
+
+	return cmd
+}
+
+func newReportSpendingCmd() *cobra.Command {
+	var (
+		period  string
+		date    string
+		account string
+		sortBy  string
+		rollup  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "spending",
+		Short: "Spending by category for a period",
+		Long: `Total expenses by category over a week, month, quarter or year. The period
+is the one containing --date (default today); weeks start on Monday.
+
+Examples:
+  fintrack report spending
+  fintrack report spending --period month --date 2026-09-01
+  fintrack report spending --period year --rollup
+  fintrack report spending --sort count --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := reportOptions(cmd, account, sortBy, rollup)
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			if !cmd.Flags().Changed("period") && config.Get().Reports.DefaultPeriod != "" {
+				period = config.Get().Reports.DefaultPeriod
+			}
+			period = strings.ToLower(period)
+
+			day := time.Now()
+			if date != "" {
+				t, err := time.Parse("2006-01-02", date)
+				if err != nil {
+					return output.PrintError(cmd, fmt.Errorf("invalid date format (use YYYY-MM-DD): %v", err))
+				}
+				day = t
+			}
+
+			report, err := services.NewReportService(db.Get()).Spending(period, day, opts)
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			switch output.GetFormat(cmd) {
+			case output.FormatJSON:
+				return output.Print(cmd, report)
+			case output.FormatCSV:
+				var rows [][]string
+				for _, line := range report.Expenses.Lines {
+					rows = append(rows, categoryLineCSV(line))
+				}
+				return output.PrintCSV(os.Stdout, []string{"category", "amount", "count", "share"}, rows)
+			}
+
+			printSpendingReport(report)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&period, "period", "p", services.ReportPeriodMonth, "Period (week, month, quarter, year; default: reports.default_period)")
+	cmd.Flags().StringVar(&date, "date", "", "Any date in the period (YYYY-MM-DD, default: today)")
+	addReportFlags(cmd, &account, &sortBy, &rollup)
+
+	return cmd
+}
+
+func newReportNetWorthCmd() *cobra.Command {
+	var (
+		from        string
+		to          string
+		months      int
+		interval    string
+		fromInitial bool
+	)
+
+	cmd := &cobra.Command{
+		Use:     "networth",
+		Aliases: []string{"net-worth", "nw"},
+		Short:   "Net worth over time",
+		Long: `Show assets, liabilities and net worth at the end of each month (or day) of
+a date range. Closed accounts are included.
+
+Balances aren't stored over time, so each account's balance is reconstructed
+by undoing later transactions from its current balance. With --from-initial,
+transactions are replayed forward from the initial balance instead; the two
+only differ when a balance was adjusted without a transaction.
+
+Credit and loan accounts are liabilities, shown as the amount owed. An account
+counts from the day it was created or its first transaction, whichever is
+earlier.
+
+Examples:
+  fintrack report networth
+  fintrack report networth --months 24
+  fintrack report networth --from 2026-10-01 --interval day
+  fintrack report networth --from-initial --csv > networth.csv`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if months <= 0 {
+				return output.PrintError(cmd, fmt.Errorf("--months must be positive"))
+			}
+
+			now := time.Now()
+			opts := services.NetWorthOptions{
+				From:        services.MonthStart(now).AddDate(0, 1-months, 0),
+				To:          now,
+				Interval:    strings.ToLower(interval),
+				FromInitial: fromInitial,
+			}
+			if from != "" {
+				t, err := time.Parse("2006-01-02", from)
+				if err != nil {
+					return output.PrintError(cmd, fmt.Errorf("invalid from date format (use YYYY-MM-DD): %v", err))
+				}
+				opts.From = t
+			}
+			if to != "" {
+				t, err := time.Parse("2006-01-02", to)
+				if err != nil {
+					return output.PrintError(cmd, fmt.Errorf("invalid to date format (use YYYY-MM-DD): %v", err))
+				}
+				opts.To = t
+			}
+
+			report, err := services.NewReportService(db.Get()).NetWorth(opts)
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			switch output.GetFormat(cmd) {
+			case output.FormatJSON:
+				return output.Print(cmd, report)
+			case output.FormatCSV:
+				var rows [][]string
+				for _, point := range report.Points {
+					rows = append(rows, []string{
+						point.Date.Format("2006-01-02"),
+						fmt.Sprintf("%.2f", float64(point.AssetsCents)/100),
+						fmt.Sprintf("%.2f", float64(point.LiabilitiesCents)/100),
+						fmt.Sprintf("%.2f", float64(point.NetWorthCents)/100),
+						fmt.Sprintf("%.2f", float64(point.ChangeCents)/100),
+					})
+				}
+				return output.PrintCSV(os.Stdout, []string{"date", "assets", "liabilities", "net_worth", "change"}, rows)
+			}
+
+			printNetWorth(report)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "Start date (YYYY-MM-DD, default: start of the --months range)")
+	cmd.Flags().StringVar(&to, "to", "", "End date (YYYY-MM-DD, default: today)")
+	cmd.Flags().IntVar(&months, "months", 12, "Number of months up to today to cover when --from isn't given")
+	cmd.Flags().StringVar(&interval, "interval", services.NetWorthIntervalMonth, "Interval between points (day, month)")
+	cmd.Flags().BoolVar(&fromInitial, "from-initial", false, "Replay transactions forward from initial balances")
+
+	return cmd
+}
+
+// reportChart returns the chart renderer for reports and projections, or nil
+// when reports.show_charts is off
+func reportChart() *output.Chart {
+	cfg := config.Get()
+	if !cfg.Reports.ShowCharts {
+		return nil
+	}
+	return output.NewChart(cfg.Reports.ChartWidth, cfg.Output.Unicode)
+}
+
+// chartCurrency labels chart axes with a cents value as currency
+func chartCurrency(cents float64) string {
+	return output.FormatCurrencyCents(int64(cents), "USD")
+}
+
+// addReportFlags adds the flags shared by category reports
+func addReportFlags(cmd *cobra.Command, account, sortBy *string, rollup *bool) {
+	cmd.Flags().StringVar(account, "account", "", "Account ID or name (default: all accounts)")
+	cmd.Flags().StringVar(sortBy, "sort", services.ReportSortAmount, "Sort categories by name, amount or count (default: reports.category_sort)")
+	cmd.Flags().BoolVar(rollup, "rollup", false, "Fold subcategories into their top-level category (default: reports.subcategory_rollup)")
+}
+
+// reportOptions builds report options from the shared flags, falling back to the
+// reports config for flags that weren't given
+func reportOptions(cmd *cobra.Command, account, sortBy string, rollup bool) (services.ReportOptions, error) {
+	cfg := config.Get().Reports
+	if !cmd.Flags().Changed("sort") && cfg.CategorySort != "" {
+		sortBy = cfg.CategorySort
+	}
+	if !cmd.Flags().Changed("rollup") {
+		rollup = cfg.SubcategoryRollup
+	}
+
+	opts := services.ReportOptions{
+		Rollup: rollup,
+		Sort:   strings.ToLower(sortBy),
+	}
+	if !services.IsValidReportSort(opts.Sort) {
+		return opts, fmt.Errorf("invalid sort: %s (valid: name, amount, count)", sortBy)
+	}
+	if account != "" {
+		accountID, err := resolveAccountID(account)
+		if err != nil {
+			return opts, err
+		}
+		opts.AccountID = &accountID
+	}
+	return opts, nil
+}
+
+// categoryLineCSV formats a report line as CSV fields, with plain decimal
+// amounts so spreadsheets read them as numbers
+func categoryLineCSV(line *services.CategoryLine) []string {
+	return []string{
+		line.Name,
+		fmt.Sprintf("%.2f", float64(line.AmountCents)/100),
+		fmt.Sprintf("%d", line.Count),
+		fmt.Sprintf("%.4f", line.Share),
+	}
+}
+
+func printIncomeStatement(s *services.IncomeStatement) {
+	fmt.Printf("INCOME STATEMENT (%s to %s)\n", s.From.Format("2006-01-02"), s.To.Format("2006-01-02"))
+
+	fmt.Println("\nIncome:")
+	printCategoryLines(s.Income, "No income recorded.")
+	fmt.Printf("Total Income:    %s\n", output.FormatCurrencyCents(s.Income.TotalCents, "USD"))
+
+	fmt.Println("\nExpenses:")
+	printCategoryLines(s.Expenses, "No expenses recorded.")
+	fmt.Printf("Total Expenses:  %s\n", output.FormatCurrencyCents(s.Expenses.TotalCents, "USD"))
+
+	fmt.Printf("\nNet Income:      %s\n", formatAmountCents(s.NetCents))
+	if s.Income.TotalCents > 0 {
+		fmt.Printf("Savings Rate:    %s\n", output.FormatPercentage(s.SavingsRate))
+	}
+
+	if chart := reportChart(); chart != nil && len(s.Months) > 0 {
+		// Each bar is as long as the larger of income and expenses
+		bars := make([]output.StackedBar, 0, len(s.Months))
+		for _, month := range s.Months {
+			spent := month.ExpensesCents
+			if month.IncomeCents < spent {
+				spent = month.IncomeCents
+			}
+			bars = append(bars, output.StackedBar{
+				Label:    month.Month.Format("Jan 2006"),
+				Segments: []float64{float64(spent), float64(month.NetCents), float64(-month.NetCents)},
+				Text:     formatAmountCents(month.NetCents),
+			})
+		}
+		fmt.Println()
+		fmt.Print(chart.StackedBars(bars, []string{"Spent", "Saved", "Overspent"}))
+	}
+}
+
+func printSpendingReport(r *services.SpendingReport) {
+	fmt.Printf("SPENDING BY CATEGORY (%s, %s to %s)\n\n", r.Period, r.From.Format("2006-01-02"), r.To.Format("2006-01-02"))
+
+	printCategoryLines(r.Expenses, "No expenses recorded.")
+	fmt.Printf("\nTotal Spending:  %s (%d transactions)\n", output.FormatCurrencyCents(r.Expenses.TotalCents, "USD"), r.Expenses.Count)
+	fmt.Printf("Daily Average:   %s\n", output.FormatCurrencyCents(r.DailyAverageCents, "USD"))
+
+	if chart := reportChart(); chart != nil && len(r.Expenses.Lines) > 0 {
+		bars := make([]output.Bar, 0, len(r.Expenses.Lines))
+		for _, line := range r.Expenses.Lines {
+			bars = append(bars, output.Bar{
+				Label: line.Name,
+				Value: float64(line.AmountCents),
+				Text:  output.FormatCurrencyCents(line.AmountCents, "USD"),
+			})
+		}
+		fmt.Println()
+		fmt.Print(chart.Bars(bars))
+	}
+}
+
+func printNetWorth(r *services.NetWorthReport) {
+	fmt.Printf("NET WORTH (%s to %s)\n\n", r.From.Format("2006-01-02"), r.To.Format("2006-01-02"))
+
+	table := output.NewTable("DATE", "ASSETS", "LIABILITIES", "NET WORTH", "CHANGE")
+	for _, point := range r.Points {
+		table.AddRow(
+			point.Date.Format("2006-01-02"),
+			output.FormatCurrencyCents(point.AssetsCents, "USD"),
+			output.FormatCurrencyCents(point.LiabilitiesCents, "USD"),
+			output.FormatCurrencyCents(point.NetWorthCents, "USD"),
+			formatAmountCents(point.ChangeCents),
+		)
+	}
+	table.Print()
+
+	if len(r.Points) > 0 {
+		var change int64
+		for _, point := range r.Points {
+			change += point.ChangeCents
+		}
+		fmt.Printf("\nNet Worth:  %s (%s over the period)\n",
+			output.FormatCurrencyCents(r.Points[len(r.Points)-1].NetWorthCents, "USD"), formatAmountCents(change))
+	}
+
+	closed := 0
+	for _, account := range r.Accounts {
+		if !account.IsActive {
+			closed++
+		}
+	}
+	if closed > 0 {
+		fmt.Printf("Includes %d closed account(s).\n", closed)
+	}
+
+	if chart := reportChart(); chart != nil && len(r.Points) > 0 {
+		values := make([]float64, 0, len(r.Points))
+		for _, point := range r.Points {
+			values = append(values, float64(point.NetWorthCents))
+		}
+		fmt.Println()
+		fmt.Print(chart.Line(values, 0, chartCurrency))
+
+		fmt.Println("\nAccounts:")
+		table := output.NewTable("ACCOUNT", "BALANCE", "TREND")
+		for _, account := range r.Accounts {
+			balances := make([]float64, 0, len(account.BalancesCents))
+			for _, cents := range account.BalancesCents {
+				balances = append(balances, float64(cents))
+			}
+			table.AddRow(
+				account.Name,
+				output.FormatCurrencyCents(account.BalancesCents[len(account.BalancesCents)-1], "USD"),
+				chart.Sparkline(balances),
+			)
+		}
+		table.Print()
+	}
+}
+
+// printCategoryLines prints a report section as a table
+func printCategoryLines(section *services.ReportSection, empty string) {
+	if len(section.Lines) == 0 {
+		fmt.Println(empty)
+		return
+	}
+
+	table := output.NewTable("CATEGORY", "AMOUNT", "COUNT", "SHARE")
+	for _, line := range section.Lines {
+		table.AddRow(
+			line.Name,
+			output.FormatCurrencyCents(line.AmountCents, "USD"),
+			fmt.Sprintf("%d", line.Count),
+			output.FormatPercentage(line.Share),
+		)
+	}
+	table.Print()
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/fintrack/fintrack/internal/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReportCmd(t *testing.T) {
+	cmd := NewReportCmd()
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "report", cmd.Use)
+	assert.Contains(t, cmd.Aliases, "rp")
+	assert.Equal(t, "Generate financial reports", cmd.Short)
+	assert.NotNil(t, cmd.PersistentFlags().Lookup("csv"))
+}
+
+func TestReportCmd_Subcommands(t *testing.T) {
+	cmd := NewReportCmd()
+
+	subcommands := []string{"income-statement", "spending", "networth"}
+	for _, sub := range subcommands {
+		found, _, err := cmd.Find([]string{sub})
+		assert.NoError(t, err)
+		assert.Equal(t, sub, found.Name(), "Expected subcommand '%s' not found", sub)
+	}
+}
+
+func TestReportIncomeStatementCmd_Flags(t *testing.T) {
+	cmd := newReportIncomeStatementCmd()
+	assert.Contains(t, cmd.Aliases, "is")
+
+	for _, flag := range []string{"from", "to", "account", "sort", "rollup"} {
+		assert.NotNil(t, cmd.Flags().Lookup(flag), "Expected flag '%s' not found", flag)
+	}
+	assert.Equal(t, services.ReportSortAmount, cmd.Flags().Lookup("sort").DefValue)
+}
+
+func TestReportSpendingCmd_Flags(t *testing.T) {
+	cmd := newReportSpendingCmd()
+
+	for _, flag := range []string{"period", "date", "account", "sort", "rollup"} {
+		assert.NotNil(t, cmd.Flags().Lookup(flag), "Expected flag '%s' not found", flag)
+	}
+	assert.Equal(t, services.ReportPeriodMonth, cmd.Flags().Lookup("period").DefValue)
+}
+
+func TestReportNetWorthCmd_Flags(t *testing.T) {
+	cmd := newReportNetWorthCmd()
+	assert.Contains(t, cmd.Aliases, "nw")
+
+	for _, flag := range []string{"from", "to", "months", "interval", "from-initial"} {
+		assert.NotNil(t, cmd.Flags().Lookup(flag), "Expected flag '%s' not found", flag)
+	}
+	assert.Equal(t, "12", cmd.Flags().Lookup("months").DefValue)
+	assert.Equal(t, services.NetWorthIntervalMonth, cmd.Flags().Lookup("interval").DefValue)
+}
+
+func TestCategoryLineCSV(t *testing.T) {
+	line := &services.CategoryLine{Name: "Food > Groceries", AmountCents: 123456, Count: 7, Share: 0.25}
+	assert.Equal(t, []string{"Food > Groceries", "1234.56", "7", "0.2500"}, categoryLineCSV(line))
+}
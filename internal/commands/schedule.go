@@ -0,0 +1,919 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/config"
+	"github.com/fintrack/fintrack/internal/db"
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/fintrack/fintrack/internal/output"
+	"github.com/fintrack/fintrack/internal/services"
+	"github.com/spf13/cobra"
+)
+
+// NewScheduleCmd creates the schedule command
+func NewScheduleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "schedule",
+		Aliases: []string{"s"},
+		Short:   "Manage recurring transactions",
+		Long: `Manage recurring income and expenses such as rent, salary and subscriptions.
+
+Examples:
+  fintrack schedule add "Rent" --amount -1500 --account Checking --category Rent --day 1
+  fintrack schedule add "Salary" --amount 3200 --account Checking --frequency biweekly --weekday fri
+  fintrack schedule list
+  fintrack schedule generate --dry-run
+  fintrack schedule pause 3
+  fintrack schedule resume 3`,
+	}
+
+	cmd.AddCommand(newScheduleAddCmd())
+	cmd.AddCommand(newScheduleListCmd())
+	cmd.AddCommand(newScheduleShowCmd())
+	cmd.AddCommand(newScheduleUpdateCmd())
+	cmd.AddCommand(newSchedulePauseCmd())
+	cmd.AddCommand(newScheduleResumeCmd())
+	cmd.AddCommand(newScheduleDeleteCmd())
+	cmd.AddCommand(newScheduleGenerateCmd())
+	cmd.AddCommand(newScheduleDetectCmd())
+
+	return cmd
+}
+
+func newScheduleAddCmd() *cobra.Command {
+	var (
+		amount      float64
+		account     string
+		category    string
+		frequency   string
+		interval    int
+		day         int
+		weekday     string
+		start       string
+		end         string
+		description string
+		auto        bool
+		remindDays  int
+	)
+
+	cmd := &cobra.Command{
+		Use:     "add NAME",
+		Aliases: []string{"create", "new"},
+		Short:   "Add a recurring item",
+		Long: `Add a recurring income or expense.
+
+Negative amounts are expenses, positive amounts are income. Accounts and
+categories can be given by ID or name.
+
+Frequency must be one of: daily, weekly, biweekly, monthly, quarterly, annual
+--day sets the day of the month (1-31) for monthly, quarterly and annual items;
+days past the end of a short month fall on its last day. --weekday sets the day
+of the week (0-6 or sun-sat) for weekly and biweekly items.
+
+Examples:
+  fintrack schedule add "Rent" --amount -1500 --account Checking --category Rent --day 1
+  fintrack s add "Netflix" -a -15.99 --account 2 -c Subscriptions -f monthly
+  fintrack s add "Salary" -a 3200 --account Checking -f biweekly --weekday fri --auto
+  fintrack s add "Gym" -a -40 --account Checking -f monthly --interval 3 --end 2027-06-30`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			accountID, err := resolveScheduleAccount(account)
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			startDate := time.Now()
+			if start != "" {
+				if startDate, err = time.Parse("2006-01-02", start); err != nil {
+					return output.PrintError(cmd, fmt.Errorf("invalid start date format (use YYYY-MM-DD): %v", err))
+				}
+			}
+			startDate = time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, time.UTC)
+
+			if !cmd.Flags().Changed("remind-days") {
+				remindDays = defaultReminderDays()
+			}
+
+			item := &models.RecurringItem{
+				Name:               args[0],
+				AccountID:          accountID,
+				AmountCents:        models.DollarsToCents(amount),
+				Description:        description,
+				Frequency:          frequency,
+				FrequencyInterval:  interval,
+				StartDate:          startDate,
+				AutoGenerate:       auto,
+				ReminderDaysBefore: remindDays,
+				IsActive:           true,
+			}
+
+			if cmd.Flags().Changed("day") {
+				item.DayOfMonth = &day
+			}
+			if weekday != "" {
+				wd, err := parseWeekday(weekday)
+				if err != nil {
+					return output.PrintError(cmd, err)
+				}
+				item.DayOfWeek = &wd
+			}
+			if end != "" {
+				t, err := time.Parse("2006-01-02", end)
+				if err != nil {
+					return output.PrintError(cmd, fmt.Errorf("invalid end date format (use YYYY-MM-DD): %v", err))
+				}
+				item.EndDate = &t
+			}
+			if category != "" {
+				cat, err := resolveCategory(category, scheduleCategoryType(item.AmountCents))
+				if err != nil {
+					return output.PrintError(cmd, err)
+				}
+				item.CategoryID = &cat.ID
+				item.Category = cat
+			}
+
+			if err := services.ValidateRecurringItem(item); err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			next, ok := services.NextRecurringDate(item)
+			if !ok {
+				return output.PrintError(cmd, fmt.Errorf("schedule has no occurrences before its end date"))
+			}
+			item.NextDate = next
+
+			repo := repositories.NewRecurringRepository(db.Get())
+			if err := repo.Create(item); err != nil {
+				return output.PrintError(cmd, fmt.Errorf("failed to create recurring item: %w", err))
+			}
+
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.Print(cmd, item)
+			}
+
+			fmt.Printf("✓ Created recurring item #%d\n", item.ID)
+			fmt.Printf("Name: %s\n", item.Name)
+			fmt.Printf("Amount: %s\n", formatAmountCents(item.AmountCents))
+			fmt.Printf("Schedule: %s\n", describeSchedule(item))
+			fmt.Printf("Next: %s\n", item.NextDate.Format("2006-01-02"))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Float64VarP(&amount, "amount", "a", 0, "Amount in dollars, negative for expenses (required)")
+	cmd.Flags().StringVar(&account, "account", "", "Account ID or name (default: defaults.account from config)")
+	cmd.Flags().StringVarP(&category, "category", "c", "", "Category ID or name")
+	cmd.Flags().StringVarP(&frequency, "frequency", "f", models.FrequencyMonthly, "Frequency (daily, weekly, biweekly, monthly, quarterly, annual)")
+	cmd.Flags().IntVar(&interval, "interval", 1, "Repeat every N periods")
+	cmd.Flags().IntVar(&day, "day", 0, "Day of month (1-31)")
+	cmd.Flags().StringVar(&weekday, "weekday", "", "Day of week (0-6 or sun-sat)")
+	cmd.Flags().StringVar(&start, "start", "", "Start date (YYYY-MM-DD, default: today)")
+	cmd.Flags().StringVar(&end, "end", "", "End date (YYYY-MM-DD)")
+	cmd.Flags().StringVarP(&description, "description", "d", "", "Description")
+	cmd.Flags().BoolVar(&auto, "auto", false, "Generate transactions without confirmation")
+	cmd.Flags().IntVar(&remindDays, "remind-days", 3, "Days before due date to remind (default: recurring.reminder_days_before)")
+
+	mustMarkRequired(cmd, "amount")
+
+	return cmd
+}
+
+func newScheduleListCmd() *cobra.Command {
+	var (
+		all     bool
+		account string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List recurring items",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repo := repositories.NewRecurringRepository(db.Get())
+
+			var items []*models.RecurringItem
+			var err error
+			if account != "" {
+				accountID, aerr := resolveAccountID(account)
+				if aerr != nil {
+					return output.PrintError(cmd, aerr)
+				}
+				items, err = repo.ListByAccount(accountID, !all)
+			} else {
+				items, err = repo.List(!all)
+			}
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.Print(cmd, items)
+			}
+
+			if len(items) == 0 {
+				fmt.Println("No recurring items found.")
+				return nil
+			}
+
+			table := output.NewTable("ID", "NAME", "AMOUNT", "SCHEDULE", "NEXT", "ACCOUNT", "CATEGORY", "STATUS")
+			for _, item := range items {
+				table.AddRow(
+					fmt.Sprintf("%d", item.ID),
+					item.Name,
+					formatAmountCents(item.AmountCents),
+					describeSchedule(item),
+					item.NextDate.Format("2006-01-02"),
+					scheduleAccountName(item),
+					scheduleCategoryName(item),
+					scheduleStatus(item),
+				)
+			}
+			table.Print()
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Include paused items")
+	cmd.Flags().StringVar(&account, "account", "", "Only items for this account ID or name")
+
+	return cmd
+}
+
+func newScheduleShowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "show ID",
+		Aliases: []string{"get"},
+		Short:   "Show recurring item details",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			item, err := resolveRecurringItem(args[0])
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.Print(cmd, item)
+			}
+
+			fmt.Printf("Recurring Item #%d\n", item.ID)
+			fmt.Printf("Name: %s\n", item.Name)
+			fmt.Printf("Amount: %s\n", formatAmountCents(item.AmountCents))
+			fmt.Printf("Account: %s\n", scheduleAccountName(item))
+			if item.CategoryID != nil {
+				fmt.Printf("Category: %s\n", scheduleCategoryName(item))
+			}
+			if item.Description != "" {
+				fmt.Printf("Description: %s\n", item.Description)
+			}
+			fmt.Printf("Schedule: %s\n", describeSchedule(item))
+			fmt.Printf("Start: %s\n", item.StartDate.Format("2006-01-02"))
+			if item.EndDate != nil {
+				fmt.Printf("End: %s\n", item.EndDate.Format("2006-01-02"))
+			}
+			fmt.Printf("Next: %s\n", item.NextDate.Format("2006-01-02"))
+			if item.LastGeneratedDate != nil {
+				fmt.Printf("Last Generated: %s\n", item.LastGeneratedDate.Format("2006-01-02"))
+			}
+			fmt.Printf("Auto Generate: %v\n", item.AutoGenerate)
+			fmt.Printf("Remind: %d days before\n", item.ReminderDaysBefore)
+			fmt.Printf("Status: %s\n", scheduleStatus(item))
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func newScheduleUpdateCmd() *cobra.Command {
+	var (
+		name        string
+		amount      float64
+		account     string
+		category    string
+		frequency   string
+		interval    int
+		day         int
+		weekday     string
+		start       string
+		end         string
+		next        string
+		description string
+		auto        bool
+		remindDays  int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update ID",
+		Short: "Update a recurring item",
+		Long: `Update an existing recurring item's properties.
+
+Use --day 0, --weekday none or --end none to clear those settings.
+
+Examples:
+  fintrack schedule update 1 --amount -1550
+  fintrack schedule update Rent --day 5
+  fintrack s update 3 --frequency weekly --weekday mon --day 0
+  fintrack s update Gym --end none --auto`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			item, err := resolveRecurringItem(args[0])
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			flags := cmd.Flags()
+			updated := false
+			if flags.Changed("name") {
+				item.Name = name
+				updated = true
+			}
+			if flags.Changed("amount") {
+				item.AmountCents = models.DollarsToCents(amount)
+				updated = true
+			}
+			if flags.Changed("account") {
+				accountID, err := resolveAccountID(account)
+				if err != nil {
+					return output.PrintError(cmd, err)
+				}
+				item.AccountID = accountID
+				item.Account = nil
+				updated = true
+			}
+			if flags.Changed("category") {
+				cat, err := resolveCategory(category, scheduleCategoryType(item.AmountCents))
+				if err != nil {
+					return output.PrintError(cmd, err)
+				}
+				item.CategoryID = &cat.ID
+				item.Category = cat
+				updated = true
+			}
+			if flags.Changed("frequency") {
+				item.Frequency = frequency
+				updated = true
+			}
+			if flags.Changed("interval") {
+				item.FrequencyInterval = interval
+				updated = true
+			}
+			if flags.Changed("day") {
+				item.DayOfMonth = nil
+				if day != 0 {
+					item.DayOfMonth = &day
+				}
+				updated = true
+			}
+			if flags.Changed("weekday") {
+				item.DayOfWeek = nil
+				if weekday != "none" {
+					wd, err := parseWeekday(weekday)
+					if err != nil {
+						return output.PrintError(cmd, err)
+					}
+					item.DayOfWeek = &wd
+				}
+				updated = true
+			}
+			if flags.Changed("start") {
+				t, err := time.Parse("2006-01-02", start)
+				if err != nil {
+					return output.PrintError(cmd, fmt.Errorf("invalid start date format (use YYYY-MM-DD): %v", err))
+				}
+				item.StartDate = t
+				updated = true
+			}
+			if flags.Changed("end") {
+				item.EndDate = nil
+				if end != "none" {
+					t, err := time.Parse("2006-01-02", end)
+					if err != nil {
+						return output.PrintError(cmd, fmt.Errorf("invalid end date format (use YYYY-MM-DD): %v", err))
+					}
+					item.EndDate = &t
+				}
+				updated = true
+			}
+			if flags.Changed("next") {
+				t, err := time.Parse("2006-01-02", next)
+				if err != nil {
+					return output.PrintError(cmd, fmt.Errorf("invalid next date format (use YYYY-MM-DD): %v", err))
+				}
+				item.NextDate = t
+				updated = true
+			}
+			if flags.Changed("description") {
+				item.Description = description
+				updated = true
+			}
+			if flags.Changed("auto") {
+				item.AutoGenerate = auto
+				updated = true
+			}
+			if flags.Changed("remind-days") {
+				item.ReminderDaysBefore = remindDays
+				updated = true
+			}
+
+			if !updated {
+				return output.PrintError(cmd, fmt.Errorf("no updates specified"))
+			}
+
+			if err := services.ValidateRecurringItem(item); err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			// A changed schedule moves the next due date unless it was set explicitly
+			scheduleChanged := false
+			for _, f := range []string{"frequency", "interval", "day", "weekday", "start", "end"} {
+				scheduleChanged = scheduleChanged || flags.Changed(f)
+			}
+			if scheduleChanged && !flags.Changed("next") {
+				if next, ok := services.NextRecurringDate(item); ok {
+					item.NextDate = next
+				}
+			}
+
+			repo := repositories.NewRecurringRepository(db.Get())
+			if err := repo.Update(item); err != nil {
+				return output.PrintError(cmd, fmt.Errorf("failed to update recurring item: %w", err))
+			}
+
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.Print(cmd, item)
+			}
+
+			fmt.Printf("Recurring item #%d updated successfully\n", item.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "New name")
+	cmd.Flags().Float64VarP(&amount, "amount", "a", 0, "New amount in dollars, negative for expenses")
+	cmd.Flags().StringVar(&account, "account", "", "New account ID or name")
+	cmd.Flags().StringVarP(&category, "category", "c", "", "New category ID or name")
+	cmd.Flags().StringVarP(&frequency, "frequency", "f", "", "New frequency")
+	cmd.Flags().IntVar(&interval, "interval", 1, "Repeat every N periods")
+	cmd.Flags().IntVar(&day, "day", 0, "Day of month (1-31, 0 to clear)")
+	cmd.Flags().StringVar(&weekday, "weekday", "", "Day of week (0-6 or sun-sat, none to clear)")
+	cmd.Flags().StringVar(&start, "start", "", "New start date (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&end, "end", "", "New end date (YYYY-MM-DD, none to clear)")
+	cmd.Flags().StringVar(&next, "next", "", "New next due date (YYYY-MM-DD)")
+	cmd.Flags().StringVarP(&description, "description", "d", "", "New description")
+	cmd.Flags().BoolVar(&auto, "auto", false, "Generate transactions without confirmation")
+	cmd.Flags().IntVar(&remindDays, "remind-days", 3, "Days before due date to remind")
+
+	return cmd
+}
+
+func newSchedulePauseCmd() *cobra.Command {
+	return newScheduleSetActiveCmd("pause", "Pause a recurring item", false)
+}
+
+func newScheduleResumeCmd() *cobra.Command {
+	return newScheduleSetActiveCmd("resume", "Resume a paused recurring item", true)
+}
+
+// newScheduleSetActiveCmd builds the pause and resume commands, which differ only in
+// the active state they set
+func newScheduleSetActiveCmd(use, short string, active bool) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   use + " ID",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			item, err := resolveRecurringItem(args[0])
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			repo := repositories.NewRecurringRepository(db.Get())
+			if err := repo.SetActive(item.ID, active); err != nil {
+				return output.PrintError(cmd, fmt.Errorf("failed to %s recurring item: %w", use, err))
+			}
+			item.IsActive = active
+
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.Print(cmd, item)
+			}
+
+			state := "paused"
+			if active {
+				state = "resumed"
+			}
+			return output.PrintSuccess(cmd, fmt.Sprintf("Recurring item #%d (%s) %s", item.ID, item.Name, state))
+		},
+	}
+
+	return cmd
+}
+
+func newScheduleDeleteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "delete ID",
+		Aliases: []string{"rm", "remove"},
+		Short:   "Delete a recurring item",
+		Long: `Delete a recurring item. Transactions already generated from it are kept.
+
+Examples:
+  fintrack schedule delete 3
+  fintrack s rm Netflix`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			item, err := resolveRecurringItem(args[0])
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			repo := repositories.NewRecurringRepository(db.Get())
+			if err := repo.Delete(item.ID); err != nil {
+				return output.PrintError(cmd, fmt.Errorf("failed to delete recurring item: %w", err))
+			}
+
+			return output.PrintSuccess(cmd, fmt.Sprintf("Recurring item #%d deleted successfully", item.ID))
+		},
+	}
+
+	return cmd
+}
+
+func newScheduleGenerateCmd() *cobra.Command {
+	var (
+		days    int
+		dryRun  bool
+		confirm bool
+		yes     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Create transactions for due recurring items",
+		Long: `Create transactions for recurring items due within the next few days.
+
+Items marked --auto (or every item when recurring.auto_generate is true) are
+created directly. Other items need --confirm, which asks about each one; add
+--yes to accept them all without asking. Each generated transaction is linked
+to its recurring item, and the item's next due date is advanced in the same
+database transaction.
+
+Examples:
+  fintrack schedule generate --dry-run
+  fintrack schedule generate --confirm
+  fintrack schedule generate --days 14 --confirm --yes`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := config.Get().Recurring
+			if !cmd.Flags().Changed("days") {
+				days = cfg.GenerateDaysAhead
+			}
+			if days < 0 {
+				return output.PrintError(cmd, fmt.Errorf("--days cannot be negative"))
+			}
+
+			opts := services.GenerateOptions{
+				DaysAhead:    days,
+				AutoGenerate: cfg.AutoGenerate,
+				DryRun:       dryRun,
+			}
+			if confirm {
+				reader := bufio.NewReader(cmd.InOrStdin())
+				opts.Confirm = func(occ *services.ScheduledOccurrence) bool {
+					if yes {
+						return true
+					}
+					fmt.Fprintf(cmd.OutOrStdout(), "Create %s %s on %s? [y/N] ", occ.Item.Name,
+						formatAmountCents(occ.Item.AmountCents), occ.Date.Format("2006-01-02"))
+					answer, _ := reader.ReadString('\n')
+					answer = strings.ToLower(strings.TrimSpace(answer))
+					return answer == "y" || answer == "yes"
+				}
+			}
+
+			result, err := services.NewScheduleGenerator(db.Get()).Generate(opts)
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+			if !dryRun {
+				// Generated payments move each item's next date, so its bill reminder moves too
+				if _, err := syncReminders(); err != nil {
+					return output.PrintError(cmd, err)
+				}
+			}
+
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.Print(cmd, result)
+			}
+
+			if len(result.Generated) == 0 && len(result.Pending) == 0 {
+				fmt.Printf("Nothing due through %s.\n", result.Through.Format("2006-01-02"))
+				return nil
+			}
+
+			status := "created"
+			if dryRun {
+				status = "would create"
+			}
+			table := output.NewTable("DATE", "NAME", "AMOUNT", "ACCOUNT", "STATUS")
+			for _, occ := range result.Generated {
+				table.AddRow(occ.Date.Format("2006-01-02"), occ.Item.Name,
+					formatAmountCents(occ.Item.AmountCents), scheduleAccountName(occ.Item), status)
+			}
+			for _, occ := range result.Pending {
+				table.AddRow(occ.Date.Format("2006-01-02"), occ.Item.Name,
+					formatAmountCents(occ.Item.AmountCents), scheduleAccountName(occ.Item), "needs confirmation")
+			}
+			table.Print()
+
+			if dryRun {
+				fmt.Println("\nThis was a dry run. No transactions were created.")
+			} else {
+				fmt.Printf("\n✓ Created %d transaction(s)\n", len(result.Generated))
+			}
+			if len(result.Pending) > 0 && !confirm {
+				fmt.Println("Run with --confirm to create the remaining items.")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&days, "days", 3, "Generate items due within N days (default: recurring.generate_days_ahead)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview without creating transactions")
+	cmd.Flags().BoolVar(&confirm, "confirm", false, "Ask before creating items that are not auto-generated")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "With --confirm, accept every item without asking")
+
+	return cmd
+}
+
+func newScheduleDetectCmd() *cobra.Command {
+	var (
+		months        int
+		since         string
+		minCount      int
+		tolerance     float64
+		minConfidence float64
+		accept        string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "detect",
+		Short: "Find recurring payments in transaction history",
+		Long: `Analyse past transactions for payments that repeat at regular intervals.
+
+Transactions are grouped by account and payee (ignoring reference numbers and
+card-processor prefixes), payments within --tolerance of the typical amount are
+kept, and groups paid weekly, biweekly, monthly, quarterly or annually are
+proposed with a confidence score. Payees that already have a recurring item are
+skipped.
+
+Accept candidates by their number in the list, or all of them, to create
+recurring items. Matched transactions are linked to the new items.
+
+Examples:
+  fintrack schedule detect
+  fintrack schedule detect --months 6 --min-confidence 0.8
+  fintrack schedule detect --accept 1,3,4
+  fintrack schedule detect --accept all`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from := time.Now().AddDate(0, -months, 0)
+			if since != "" {
+				t, err := time.Parse("2006-01-02", since)
+				if err != nil {
+					return output.PrintError(cmd, fmt.Errorf("invalid since date format (use YYYY-MM-DD): %v", err))
+				}
+				from = t
+			}
+			if tolerance <= 0 || tolerance >= 1 {
+				return output.PrintError(cmd, fmt.Errorf("--tolerance must be between 0 and 1"))
+			}
+
+			detector := services.NewRecurringDetector(db.Get())
+			candidates, err := detector.Detect(services.DetectOptions{
+				Since:           from,
+				MinOccurrences:  minCount,
+				AmountTolerance: tolerance,
+				MinConfidence:   minConfidence,
+			})
+			if err != nil {
+				return output.PrintError(cmd, err)
+			}
+
+			if accept != "" {
+				selected, err := selectCandidates(candidates, accept)
+				if err != nil {
+					return output.PrintError(cmd, err)
+				}
+				items, err := detector.Accept(selected, defaultReminderDays())
+				if err != nil {
+					return output.PrintError(cmd, err)
+				}
+
+				if output.GetFormat(cmd) == output.FormatJSON {
+					return output.Print(cmd, items)
+				}
+				for _, item := range items {
+					fmt.Printf("✓ Created recurring item #%d: %s %s %s, next %s\n", item.ID, item.Name,
+						formatAmountCents(item.AmountCents), describeSchedule(item), item.NextDate.Format("2006-01-02"))
+				}
+				return nil
+			}
+
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.Print(cmd, candidates)
+			}
+
+			if len(candidates) == 0 {
+				fmt.Println("No recurring payments found.")
+				return nil
+			}
+
+			table := output.NewTable("#", "PAYEE", "AMOUNT", "FREQUENCY", "SEEN", "LAST", "NEXT", "ACCOUNT", "CONFIDENCE")
+			for i, c := range candidates {
+				account := c.AccountName
+				if account == "" {
+					account = fmt.Sprintf("#%d", c.AccountID)
+				}
+				table.AddRow(
+					fmt.Sprintf("%d", i+1),
+					c.Name,
+					formatAmountCents(c.AmountCents),
+					c.Frequency,
+					fmt.Sprintf("%d", c.Occurrences),
+					c.LastDate.Format("2006-01-02"),
+					c.NextDate.Format("2006-01-02"),
+					account,
+					output.FormatPercentage(c.Confidence),
+				)
+			}
+			table.Print()
+
+			fmt.Println("\nAccept with: fintrack schedule detect --accept 1,2,... (or --accept all)")
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&months, "months", 12, "Analyse the last N months of history")
+	cmd.Flags().StringVar(&since, "since", "", "Analyse history from this date (YYYY-MM-DD, overrides --months)")
+	cmd.Flags().IntVar(&minCount, "min-occurrences", 3, "Fewest payments needed to propose a candidate")
+	cmd.Flags().Float64Var(&tolerance, "tolerance", 0.10, "Allowed amount variation as a fraction (0.0-1.0)")
+	cmd.Flags().Float64Var(&minConfidence, "min-confidence", 0.5, "Hide candidates below this confidence (0.0-1.0)")
+	cmd.Flags().StringVar(&accept, "accept", "", "Create recurring items for these candidate numbers (e.g. 1,3) or all")
+
+	return cmd
+}
+
+// selectCandidates picks candidates by their 1-based list numbers, or all of them
+func selectCandidates(candidates []*services.RecurringCandidate, accept string) ([]*services.RecurringCandidate, error) {
+	if strings.EqualFold(accept, "all") {
+		if len(candidates) == 0 {
+			return nil, fmt.Errorf("no candidates to accept")
+		}
+		return candidates, nil
+	}
+
+	var selected []*services.RecurringCandidate
+	seen := make(map[int]bool)
+	for _, part := range strings.Split(accept, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n < 1 || n > len(candidates) {
+			return nil, fmt.Errorf("invalid candidate number: %s (choose 1-%d)", strings.TrimSpace(part), len(candidates))
+		}
+		if !seen[n] {
+			seen[n] = true
+			selected = append(selected, candidates[n-1])
+		}
+	}
+	return selected, nil
+}
+
+// resolveRecurringItem looks up a recurring item by ID or by name
+func resolveRecurringItem(idOrName string) (*models.RecurringItem, error) {
+	repo := repositories.NewRecurringRepository(db.Get())
+
+	if id, err := strconv.ParseUint(idOrName, 10, 32); err == nil {
+		return repo.GetByID(uint(id))
+	}
+
+	item, err := repo.GetByName(idOrName)
+	if err != nil {
+		return nil, fmt.Errorf("recurring item not found: %s", idOrName)
+	}
+	return item, nil
+}
+
+// resolveScheduleAccount resolves the --account flag, falling back to the
+// configured default account
+func resolveScheduleAccount(account string) (uint, error) {
+	if account == "" {
+		account = config.Get().Defaults.Account
+	}
+	if account == "" {
+		return 0, fmt.Errorf("--account is required (or set defaults.account in config)")
+	}
+	return resolveAccountID(account)
+}
+
+// defaultReminderDays returns the configured reminder lead time, falling back to 3 days
+func defaultReminderDays() int {
+	if d := config.Get().Recurring.ReminderDaysBefore; d > 0 {
+		return d
+	}
+	return 3
+}
+
+// scheduleCategoryType returns the category type matching the sign of an amount
+func scheduleCategoryType(amountCents int64) string {
+	if amountCents > 0 {
+		return models.CategoryTypeIncome
+	}
+	return models.CategoryTypeExpense
+}
+
+// weekdayNames maps accepted day-of-week names to time.Weekday numbers (0=Sunday)
+var weekdayNames = map[string]int{
+	"sun": 0, "sunday": 0,
+	"mon": 1, "monday": 1,
+	"tue": 2, "tues": 2, "tuesday": 2,
+	"wed": 3, "wednesday": 3,
+	"thu": 4, "thur": 4, "thurs": 4, "thursday": 4,
+	"fri": 5, "friday": 5,
+	"sat": 6, "saturday": 6,
+}
+
+// parseWeekday parses a day of week given as 0-6 (0=Sunday) or as a day name
+func parseWeekday(s string) (int, error) {
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 0 || n > 6 {
+			return 0, fmt.Errorf("weekday must be between 0 (Sunday) and 6 (Saturday)")
+		}
+		return n, nil
+	}
+	if n, ok := weekdayNames[strings.ToLower(s)]; ok {
+		return n, nil
+	}
+	return 0, fmt.Errorf("invalid weekday: %s (use 0-6 or sun-sat)", s)
+}
+
+// describeSchedule renders a recurring item's frequency in words, e.g.
+// "monthly on day 1" or "every 2 weeks on Fri"
+func describeSchedule(item *models.RecurringItem) string {
+	units := map[string]string{
+		models.FrequencyDaily:     "days",
+		models.FrequencyWeekly:    "weeks",
+		models.FrequencyMonthly:   "months",
+		models.FrequencyQuarterly: "quarters",
+		models.FrequencyAnnual:    "years",
+	}
+
+	desc := item.Frequency
+	switch {
+	case item.Frequency == models.FrequencyBiweekly && item.FrequencyInterval > 1:
+		desc = fmt.Sprintf("every %d weeks", 2*item.FrequencyInterval)
+	case item.FrequencyInterval > 1:
+		desc = fmt.Sprintf("every %d %s", item.FrequencyInterval, units[item.Frequency])
+	}
+	if item.DayOfMonth != nil {
+		desc += fmt.Sprintf(" on day %d", *item.DayOfMonth)
+	}
+	if item.DayOfWeek != nil {
+		desc += " on " + time.Weekday(*item.DayOfWeek).String()[:3]
+	}
+	return desc
+}
+
+func scheduleAccountName(item *models.RecurringItem) string {
+	if item.Account != nil {
+		return item.Account.Name
+	}
+	return fmt.Sprintf("#%d", item.AccountID)
+}
+
+func scheduleCategoryName(item *models.RecurringItem) string {
+	if item.Category != nil {
+		return item.Category.Name
+	}
+	if item.CategoryID == nil {
+		return ""
+	}
+	return fmt.Sprintf("#%d", *item.CategoryID)
+}
+
+func scheduleStatus(item *models.RecurringItem) string {
+	if !item.IsActive {
+		return "paused"
+	}
+	if item.EndDate != nil && item.NextDate.After(*item.EndDate) {
+		return "ended"
+	}
+	return "active"
+}
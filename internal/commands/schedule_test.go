@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewScheduleCmd(t *testing.T) {
+	cmd := NewScheduleCmd()
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "schedule", cmd.Use)
+	assert.Contains(t, cmd.Aliases, "s")
+	assert.Equal(t, "Manage recurring transactions", cmd.Short)
+	assert.True(t, cmd.HasSubCommands())
+}
+
+func TestScheduleCmd_Subcommands(t *testing.T) {
+	cmd := NewScheduleCmd()
+
+	subcommands := []string{"add", "list", "show", "update", "pause", "resume", "delete", "generate", "detect"}
+	for _, sub := range subcommands {
+		found, _, err := cmd.Find([]string{sub})
+		assert.NoError(t, err)
+		assert.Equal(t, sub, found.Name(), "Expected subcommand '%s' not found", sub)
+	}
+}
+
+func TestScheduleAddCmd_Flags(t *testing.T) {
+	cmd := NewScheduleCmd()
+	addCmd, _, _ := cmd.Find([]string{"add"})
+
+	flags := []string{"amount", "account", "category", "frequency", "interval", "day", "weekday", "start", "end", "description", "auto", "remind-days"}
+	for _, flag := range flags {
+		assert.NotNil(t, addCmd.Flags().Lookup(flag), "Expected flag '%s' not found", flag)
+	}
+}
+
+func TestParseWeekday(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{"0", 0, false},
+		{"6", 6, false},
+		{"fri", 5, false},
+		{"Monday", 1, false},
+		{"7", 0, true},
+		{"-1", 0, true},
+		{"someday", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseWeekday(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDescribeSchedule(t *testing.T) {
+	day := 1
+	friday := 5
+
+	assert.Equal(t, "monthly on day 1", describeSchedule(&models.RecurringItem{Frequency: models.FrequencyMonthly, FrequencyInterval: 1, DayOfMonth: &day}))
+	assert.Equal(t, "every 3 months", describeSchedule(&models.RecurringItem{Frequency: models.FrequencyMonthly, FrequencyInterval: 3}))
+	assert.Equal(t, "biweekly on Fri", describeSchedule(&models.RecurringItem{Frequency: models.FrequencyBiweekly, FrequencyInterval: 1, DayOfWeek: &friday}))
+	assert.Equal(t, "every 4 weeks", describeSchedule(&models.RecurringItem{Frequency: models.FrequencyBiweekly, FrequencyInterval: 2}))
+}
+
+func TestScheduleCategoryType(t *testing.T) {
+	assert.Equal(t, models.CategoryTypeExpense, scheduleCategoryType(-1500))
+	assert.Equal(t, models.CategoryTypeIncome, scheduleCategoryType(320000))
+}
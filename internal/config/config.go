@@ -4,17 +4,30 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 
 	"github.com/spf13/viper"
 )
 
 // Config holds all application configuration
 type Config struct {
-	Database  DatabaseConfig  `mapstructure:"database"`
-	Defaults  DefaultsConfig  `mapstructure:"defaults"`
-	Alerts    AlertsConfig    `mapstructure:"alerts"`
-	Recurring RecurringConfig `mapstructure:"recurring"`
-	Output    OutputConfig    `mapstructure:"output"`
+	Database     DatabaseConfig             `mapstructure:"database"`
+	Defaults     DefaultsConfig             `mapstructure:"defaults"`
+	Alerts       AlertsConfig               `mapstructure:"alerts"`
+	Recurring    RecurringConfig            `mapstructure:"recurring"`
+	Output       OutputConfig               `mapstructure:"output"`
+	Reminders    RemindersConfig            `mapstructure:"reminders"`
+	Projection   ProjectionConfig           `mapstructure:"projection"`
+	Reports      ReportsConfig              `mapstructure:"reports"`
+	Calendar     CalendarConfig             `mapstructure:"calendar"`
+	Import       ImportConfig               `mapstructure:"import"`
+	Categories   CategoriesConfig           `mapstructure:"categories"`
+	Advanced     AdvancedConfig             `mapstructure:"advanced"`
+	Security     SecurityConfig             `mapstructure:"security"`
+	Experimental ExperimentalConfig         `mapstructure:"experimental"`
+	CSVFormats   map[string]CSVFormatConfig `mapstructure:"csv_formats"` // By format name
+	Profile      string                     `mapstructure:"profile"`     // Profile in effect, if any
+	Profiles     map[string]ProfileConfig   `mapstructure:"profiles"`    // By profile name
 }
 
 // DatabaseConfig holds database connection settings
@@ -25,6 +38,8 @@ type DatabaseConfig struct {
 	Database              string `mapstructure:"database"`
 	User                  string `mapstructure:"user"`
 	Password              string `mapstructure:"password"`
+	PasswordFile          string `mapstructure:"password_file"`    // Path to a file holding the password
+	PasswordCommand       string `mapstructure:"password_command"` // Shell command printing the password
 	SSLMode               string `mapstructure:"sslmode"`
 	MaxConnections        int    `mapstructure:"max_connections"`
 	MaxIdleConnections    int    `mapstructure:"max_idle_connections"`
@@ -41,8 +56,17 @@ type DefaultsConfig struct {
 
 // AlertsConfig holds alert settings
 type AlertsConfig struct {
-	Enabled   bool    `mapstructure:"enabled"`
-	Threshold float64 `mapstructure:"threshold"`
+	Enabled    bool             `mapstructure:"enabled"`
+	Threshold  float64          `mapstructure:"threshold"`
+	Methods    []string         `mapstructure:"methods"` // terminal, desktop, email
+	LowBalance LowBalanceConfig `mapstructure:"low_balance"`
+}
+
+// LowBalanceConfig holds low balance warning settings. Thresholds are in dollars.
+type LowBalanceConfig struct {
+	Enabled    bool               `mapstructure:"enabled"`
+	Thresholds map[string]float64 `mapstructure:"thresholds"` // By account type
+	Accounts   map[string]float64 `mapstructure:"accounts"`   // By account name, overriding the type threshold
 }
 
 // RecurringConfig holds recurring transaction settings
@@ -54,15 +78,192 @@ type RecurringConfig struct {
 
 // OutputConfig holds output formatting settings
 type OutputConfig struct {
-	DefaultFormat string `mapstructure:"default_format"`
-	Color         bool   `mapstructure:"color"`
-	Unicode       bool   `mapstructure:"unicode"`
+	DefaultFormat string        `mapstructure:"default_format"` // table, json, csv
+	Color         bool          `mapstructure:"color"`
+	Unicode       bool          `mapstructure:"unicode"`
+	Table         TableConfig   `mapstructure:"table"`
+	Numbers       NumbersConfig `mapstructure:"numbers"`
+}
+
+// TableConfig holds table styling settings
+type TableConfig struct {
+	BorderStyle string `mapstructure:"border_style"` // ascii, light, bold, double, rounded
+	Grid        bool   `mapstructure:"grid"`
+	Compact     bool   `mapstructure:"compact"`
+}
+
+// NumbersConfig holds number formatting settings
+type NumbersConfig struct {
+	DecimalPlaces       int    `mapstructure:"decimal_places"`
+	ThousandsSeparator  string `mapstructure:"thousands_separator"`
+	CurrencyPosition    string `mapstructure:"currency_position"`    // left, right
+	NegativeParentheses bool   `mapstructure:"negative_parentheses"` // ($100.00) rather than -$100.00
+}
+
+// RemindersConfig holds reminder settings
+type RemindersConfig struct {
+	DefaultTime           string `mapstructure:"default_time"` // HH:MM
+	ShowOnStartup         bool   `mapstructure:"show_on_startup"`
+	StartupPriorityFilter string `mapstructure:"startup_priority_filter"` // low, normal, high, urgent
+	AutoDismissAfterDays  int    `mapstructure:"auto_dismiss_after_days"`
+}
+
+// ProjectionConfig holds cash flow projection settings
+type ProjectionConfig struct {
+	DefaultDays          int                `mapstructure:"default_days"`
+	ConfidenceThreshold  float64            `mapstructure:"confidence_threshold"` // 0.0-1.0
+	Scenario             string             `mapstructure:"scenario"`             // conservative, moderate, optimistic
+	HistoricalWindowDays int                `mapstructure:"historical_window_days"`
+	Scenarios            map[string]float64 `mapstructure:"scenarios"` // Spending percentile per scenario
+}
+
+// ReportsConfig holds report settings
+type ReportsConfig struct {
+	DefaultPeriod     string `mapstructure:"default_period"` // week, month, quarter, year
+	ShowCharts        bool   `mapstructure:"show_charts"`
+	ChartWidth        int    `mapstructure:"chart_width"`
+	CategorySort      string `mapstructure:"category_sort"` // name, amount, count
+	SubcategoryRollup bool   `mapstructure:"subcategory_rollup"`
+}
+
+// CalendarConfig holds calendar view settings
+type CalendarConfig struct {
+	WeekStart     int             `mapstructure:"week_start"` // 0 = Sunday, 1 = Monday
+	ShowAmounts   bool            `mapstructure:"show_amounts"`
+	UpcomingCount int             `mapstructure:"upcoming_count"`
+	Symbols       CalendarSymbols `mapstructure:"symbols"`
+}
+
+// CalendarSymbols holds the symbol shown for each kind of calendar event
+type CalendarSymbols struct {
+	Income      string `mapstructure:"income"`
+	Expense     string `mapstructure:"expense"`
+	Transfer    string `mapstructure:"transfer"`
+	Bill        string `mapstructure:"bill"`
+	Reminder    string `mapstructure:"reminder"`
+	BudgetAlert string `mapstructure:"budget_alert"`
+}
+
+// ProfileConfig holds the settings a named profile overrides. Only the keys
+// given in the profile take effect; the rest come from the top level.
+type ProfileConfig struct {
+	Database DatabaseConfig `mapstructure:"database"`
+	Defaults DefaultsConfig `mapstructure:"defaults"`
+}
+
+// ImportConfig holds import settings
+type ImportConfig struct {
+	DuplicateWindowDays int    `mapstructure:"duplicate_window_days"`
+	AutoCategorize      bool   `mapstructure:"auto_categorize"`
+	DefaultFormat       string `mapstructure:"default_format"` // A csv_formats name
+	MaxFileSizeMB       int    `mapstructure:"max_file_size_mb"`
+	SkipBeforeDate      string `mapstructure:"skip_before_date"` // YYYY-MM-DD, optional
+}
+
+// CategoriesConfig holds category settings
+type CategoriesConfig struct {
+	AllowCreate   bool `mapstructure:"allow_create"`
+	FuzzyMatch    bool `mapstructure:"fuzzy_match"`
+	ShowHierarchy bool `mapstructure:"show_hierarchy"`
+}
+
+// AdvancedConfig holds logging and database maintenance settings
+type AdvancedConfig struct {
+	Debug                bool   `mapstructure:"debug"`
+	LogFile              string `mapstructure:"log_file"`
+	LogLevel             string `mapstructure:"log_level"` // debug, info, warn, error
+	QueryLogging         bool   `mapstructure:"query_logging"`
+	SlowQueryThresholdMS int    `mapstructure:"slow_query_threshold_ms"`
+	AutoMigrate          bool   `mapstructure:"auto_migrate"`
+	AutoRefreshViews     bool   `mapstructure:"auto_refresh_views"`
+}
+
+// SecurityConfig holds security settings
+type SecurityConfig struct {
+	EncryptionEnabled  bool `mapstructure:"encryption_enabled"`
+	ConfirmDeletes     bool `mapstructure:"confirm_deletes"`
+	MaskAccountNumbers bool `mapstructure:"mask_account_numbers"`
+}
+
+// ExperimentalConfig holds settings for features still in development
+type ExperimentalConfig struct {
+	TUIEnabled       bool   `mapstructure:"tui_enabled"`
+	PlaidEnabled     bool   `mapstructure:"plaid_enabled"`
+	PlaidClientID    string `mapstructure:"plaid_client_id"`
+	PlaidSecret      string `mapstructure:"plaid_secret"`
+	PlaidEnvironment string `mapstructure:"plaid_environment"` // sandbox, development, production
+	AICategorization bool   `mapstructure:"ai_categorization"`
+	AIModel          string `mapstructure:"ai_model"` // local, openai, anthropic
+	AIAPIKey         string `mapstructure:"ai_api_key"`
+}
+
+// CSVFormatConfig describes the layout of a bank's CSV export
+type CSVFormatConfig struct {
+	Columns    map[string]string `mapstructure:"columns"` // Field (date, amount, payee, ...) to header name
+	DateFormat string            `mapstructure:"date_format"`
+	AmountSign string            `mapstructure:"amount_sign"` // normal, or inverse when debits are positive
+	SkipRows   int               `mapstructure:"skip_rows"`
+}
+
+// builtinCSVFormats are the bank export layouts known without configuration
+var builtinCSVFormats = map[string]CSVFormatConfig{
+	"generic": {
+		Columns:    map[string]string{"date": "date", "amount": "amount", "payee": "payee", "category": "category", "description": "description"},
+		DateFormat: "2006-01-02",
+		AmountSign: "normal",
+	},
+	"chase": {
+		Columns:    map[string]string{"date": "Posting Date", "amount": "Amount", "payee": "Description", "category": "Type"},
+		DateFormat: "01/02/2006",
+		AmountSign: "inverse",
+		SkipRows:   1,
+	},
+	"bofa": {
+		Columns:    map[string]string{"date": "Date", "amount": "Amount", "payee": "Description"},
+		DateFormat: "01/02/2006",
+		AmountSign: "normal",
+		SkipRows:   1,
+	},
+	"amex": {
+		Columns:    map[string]string{"date": "Date", "amount": "Amount", "payee": "Description"},
+		DateFormat: "01/02/06",
+		AmountSign: "normal",
+		SkipRows:   1,
+	},
+	"mint": {
+		Columns:    map[string]string{"date": "Date", "amount": "Amount", "payee": "Description", "category": "Category", "account": "Account Name", "type": "Transaction Type"},
+		DateFormat: "01/02/2006",
+		AmountSign: "normal",
+		SkipRows:   1,
+	},
+	"ynab": {
+		Columns:    map[string]string{"date": "Date", "amount": "Outflow", "amount_inflow": "Inflow", "payee": "Payee", "category": "Category", "memo": "Memo"},
+		DateFormat: "01/02/2006",
+		AmountSign: "normal",
+		SkipRows:   1,
+	},
+}
+
+// connectionDefaults are the defaults of the database settings that choose the
+// database and how to log in to it. A profile setting any of them replaces
+// them all rather than mixing with the top level.
+var connectionDefaults = map[string]interface{}{
+	"url":              "",
+	"host":             "localhost",
+	"port":             5432,
+	"database":         "fintrack",
+	"user":             "fintrack_user",
+	"password":         "",
+	"password_file":    "",
+	"password_command": "",
+	"sslmode":          "disable",
 }
 
 var cfg *Config
 
-// Init initializes the configuration
-func Init(configFile string) error {
+// Init initializes the configuration. A non-empty profile selects
+// profiles.<profile> in place of FINTRACK_PROFILE or the config file's profile.
+func Init(configFile, profile string) error {
 	// Set defaults
 	setDefaults()
 
@@ -94,12 +295,27 @@ func Init(configFile string) error {
 		}
 	}
 
-	// Unmarshal config
-	cfg = &Config{}
-	if err := viper.Unmarshal(cfg); err != nil {
-		return fmt.Errorf("failed to unmarshal config: %w", err)
+	if err := applyProfile(profile); err != nil {
+		if loadErr := load(); loadErr != nil {
+			return loadErr
+		}
+		return err
 	}
+	return load()
+}
 
+// load unmarshals the settings into the current configuration, expanding
+// environment variables in string values and recording the active profile
+func load() error {
+	c := &Config{}
+	if err := viper.Unmarshal(c); err != nil {
+		return fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	if err := expandStrings(reflect.ValueOf(c).Elem(), ""); err != nil {
+		return fmt.Errorf("failed to expand config: %w", err)
+	}
+	c.Profile = activeProfile
+	cfg = c
 	return nil
 }
 
@@ -115,11 +331,11 @@ func Get() *Config {
 // setDefaults sets default configuration values
 func setDefaults() {
 	// Database defaults
-	viper.SetDefault("database.host", "localhost")
-	viper.SetDefault("database.port", 5432)
-	viper.SetDefault("database.database", "fintrack")
-	viper.SetDefault("database.user", "fintrack_user")
-	viper.SetDefault("database.sslmode", "disable")
+	for key, value := range connectionDefaults {
+		if value != "" {
+			viper.SetDefault("database."+key, value)
+		}
+	}
 	viper.SetDefault("database.max_connections", 10)
 	viper.SetDefault("database.max_idle_connections", 2)
 	viper.SetDefault("database.connection_max_lifetime", "1h")
@@ -132,6 +348,12 @@ func setDefaults() {
 	// Alert defaults
 	viper.SetDefault("alerts.enabled", true)
 	viper.SetDefault("alerts.threshold", 0.80)
+	viper.SetDefault("alerts.methods", []string{"terminal"})
+	viper.SetDefault("alerts.low_balance.enabled", true)
+	viper.SetDefault("alerts.low_balance.thresholds", map[string]float64{
+		"checking": 500,
+		"savings":  1000,
+	})
 
 	// Recurring defaults
 	viper.SetDefault("recurring.auto_generate", false)
@@ -142,26 +364,109 @@ func setDefaults() {
 	viper.SetDefault("output.default_format", "table")
 	viper.SetDefault("output.color", true)
 	viper.SetDefault("output.unicode", true)
+	viper.SetDefault("output.table.border_style", "rounded")
+	viper.SetDefault("output.table.grid", true)
+	viper.SetDefault("output.table.compact", false)
+	viper.SetDefault("output.numbers.decimal_places", 2)
+	viper.SetDefault("output.numbers.thousands_separator", ",")
+	viper.SetDefault("output.numbers.currency_position", "left")
+	viper.SetDefault("output.numbers.negative_parentheses", false)
+
+	// Reminder defaults
+	viper.SetDefault("reminders.default_time", "09:00")
+	viper.SetDefault("reminders.show_on_startup", true)
+	viper.SetDefault("reminders.startup_priority_filter", "normal")
+	viper.SetDefault("reminders.auto_dismiss_after_days", 30)
+
+	// Projection defaults
+	viper.SetDefault("projection.default_days", 90)
+	viper.SetDefault("projection.confidence_threshold", 0.70)
+	viper.SetDefault("projection.scenario", "moderate")
+	viper.SetDefault("projection.historical_window_days", 90)
+	viper.SetDefault("projection.scenarios", map[string]float64{
+		"conservative": 0.75,
+		"moderate":     0.50,
+		"optimistic":   0.25,
+	})
+
+	// Report defaults
+	viper.SetDefault("reports.default_period", "month")
+	viper.SetDefault("reports.show_charts", true)
+	viper.SetDefault("reports.chart_width", 60)
+	viper.SetDefault("reports.category_sort", "amount")
+	viper.SetDefault("reports.subcategory_rollup", false)
+
+	// Calendar defaults
+	viper.SetDefault("calendar.week_start", 0)
+	viper.SetDefault("calendar.show_amounts", true)
+	viper.SetDefault("calendar.upcoming_count", 10)
+	viper.SetDefault("calendar.symbols.income", "💰")
+	viper.SetDefault("calendar.symbols.expense", "💳")
+	viper.SetDefault("calendar.symbols.transfer", "🔄")
+	viper.SetDefault("calendar.symbols.bill", "📄")
+	viper.SetDefault("calendar.symbols.reminder", "📅")
+	viper.SetDefault("calendar.symbols.budget_alert", "⚠️")
+
+	// Import defaults
+	viper.SetDefault("import.duplicate_window_days", 30)
+	viper.SetDefault("import.auto_categorize", false)
+	viper.SetDefault("import.default_format", "generic")
+	viper.SetDefault("import.max_file_size_mb", 50)
+
+	// Category defaults
+	viper.SetDefault("categories.allow_create", true)
+	viper.SetDefault("categories.fuzzy_match", true)
+	viper.SetDefault("categories.show_hierarchy", true)
+
+	// Advanced defaults
+	viper.SetDefault("advanced.debug", false)
+	viper.SetDefault("advanced.log_file", "~/.config/fintrack/fintrack.log")
+	viper.SetDefault("advanced.log_level", "info")
+	viper.SetDefault("advanced.query_logging", false)
+	viper.SetDefault("advanced.slow_query_threshold_ms", 100)
+	viper.SetDefault("advanced.auto_migrate", true)
+	viper.SetDefault("advanced.auto_refresh_views", false)
+
+	// Security defaults
+	viper.SetDefault("security.encryption_enabled", false)
+	viper.SetDefault("security.confirm_deletes", true)
+	viper.SetDefault("security.mask_account_numbers", true)
+
+	// Experimental defaults
+	viper.SetDefault("experimental.tui_enabled", false)
+	viper.SetDefault("experimental.plaid_enabled", false)
+	viper.SetDefault("experimental.plaid_environment", "sandbox")
+	viper.SetDefault("experimental.ai_categorization", false)
+	viper.SetDefault("experimental.ai_model", "local")
+
+	// Built-in CSV formats; formats in the config file are added alongside
+	for name, format := range builtinCSVFormats {
+		key := "csv_formats." + name
+		viper.SetDefault(key+".columns", format.Columns)
+		viper.SetDefault(key+".date_format", format.DateFormat)
+		viper.SetDefault(key+".amount_sign", format.AmountSign)
+		viper.SetDefault(key+".skip_rows", format.SkipRows)
+	}
 }
 
-// GetDatabaseURL returns the database connection URL
-func (c *Config) GetDatabaseURL() string {
+// GetDatabaseURL returns the database connection URL, with the password from
+// DatabasePassword
+func (c *Config) GetDatabaseURL() (string, error) {
+	password, err := c.DatabasePassword()
+	if err != nil {
+		return "", err
+	}
 	if c.Database.URL != "" {
-		return c.Database.URL
+		return withPassword(c.Database.URL, password), nil
 	}
 
 	// Build URL from components
-	password := c.Database.Password
-	if password == "" {
-		password = os.Getenv("FINTRACK_DB_PASSWORD")
-	}
-
 	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		c.Database.Host,
 		c.Database.Port,
 		c.Database.User,
-		password,
+		dsnValue(password),
 		c.Database.Database,
 		c.Database.SSLMode,
-	)
+	), nil
 }
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ExpandEnv replaces ${VAR} in value with the environment variable VAR, and
+// ${VAR:-default} with default when VAR is unset or empty. An unset variable
+// without a default expands to nothing, as in the shell. $${ gives a literal ${.
+func ExpandEnv(value string) (string, error) {
+	var b strings.Builder
+	for {
+		i := strings.Index(value, "${")
+		if i < 0 {
+			b.WriteString(value)
+			return b.String(), nil
+		}
+		if i > 0 && value[i-1] == '$' {
+			b.WriteString(value[:i])
+			b.WriteString("{")
+			value = value[i+2:]
+			continue
+		}
+
+		end := strings.IndexByte(value[i:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("missing } after ${")
+		}
+		name, fallback, hasFallback := strings.Cut(value[i+2:i+end], ":-")
+		if !isEnvName(name) {
+			return "", fmt.Errorf("invalid environment variable name %q", name)
+		}
+
+		expanded := os.Getenv(name)
+		if expanded == "" && hasFallback {
+			expanded = fallback
+		}
+		b.WriteString(value[:i])
+		b.WriteString(expanded)
+		value = value[i+end+1:]
+	}
+}
+
+// isEnvName reports whether name is usable as an environment variable name
+func isEnvName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r == '_', r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// expandStrings expands environment variables in every string below v, which
+// is found at key in the configuration
+func expandStrings(v reflect.Value, key string) error {
+	switch v.Kind() {
+	case reflect.String:
+		expanded, err := ExpandEnv(v.String())
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		v.SetString(expanded)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			name := strings.Split(v.Type().Field(i).Tag.Get("mapstructure"), ",")[0]
+			if err := expandStrings(v.Field(i), joinKey(key, name)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if err := expandStrings(v.Index(i), fmt.Sprintf("%s[%d]", key, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		// Map values can't be set in place, so each is copied, expanded and stored
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		for _, k := range keys {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(v.MapIndex(k))
+			if err := expandStrings(elem, joinKey(key, k.String())); err != nil {
+				return err
+			}
+			v.SetMapIndex(k, elem)
+		}
+	}
+	return nil
+}
+
+// joinKey appends name to a dotted config key
+func joinKey(key, name string) string {
+	if key == "" {
+		return name
+	}
+	return key + "." + name
+}
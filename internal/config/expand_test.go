@@ -0,0 +1,62 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("FINTRACK_TEST_HOST", "db.internal")
+	t.Setenv("FINTRACK_TEST_EMPTY", "")
+
+	cases := map[string]string{
+		"${FINTRACK_TEST_HOST}":                       "db.internal",
+		"postgres://${FINTRACK_TEST_HOST}:5432/":      "postgres://db.internal:5432/",
+		"${FINTRACK_TEST_UNSET}":                      "",
+		"${FINTRACK_TEST_UNSET:-localhost}":           "localhost",
+		"${FINTRACK_TEST_EMPTY:-localhost}":           "localhost",
+		"${FINTRACK_TEST_HOST:-localhost}":            "db.internal",
+		"${FINTRACK_TEST_UNSET:-}":                    "",
+		"$${FINTRACK_TEST_HOST}":                      "${FINTRACK_TEST_HOST}",
+		"pa$$word $HOME":                              "pa$$word $HOME",
+		"${FINTRACK_TEST_HOST}/${FINTRACK_TEST_HOST}": "db.internal/db.internal",
+	}
+	for value, want := range cases {
+		got, err := ExpandEnv(value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, want, got, value)
+	}
+}
+
+func TestExpandEnv_Malformed(t *testing.T) {
+	_, err := ExpandEnv("${FINTRACK_TEST_HOST")
+	assert.EqualError(t, err, "missing } after ${")
+
+	_, err = ExpandEnv("${1PASSWORD}")
+	assert.EqualError(t, err, `invalid environment variable name "1PASSWORD"`)
+
+	_, err = ExpandEnv("${}")
+	assert.Error(t, err)
+}
+
+func TestExpandStrings(t *testing.T) {
+	t.Setenv("FINTRACK_TEST_BANK", "Trans Date")
+	c := &Config{
+		Database: DatabaseConfig{Host: "${FINTRACK_TEST_DB:-localhost}", Port: 5432},
+		Alerts:   AlertsConfig{Methods: []string{"${FINTRACK_TEST_METHOD:-terminal}"}},
+		CSVFormats: map[string]CSVFormatConfig{
+			"credit_union": {Columns: map[string]string{"date": "${FINTRACK_TEST_BANK}"}},
+		},
+	}
+
+	assert.NoError(t, expandStrings(reflect.ValueOf(c).Elem(), ""))
+	assert.Equal(t, "localhost", c.Database.Host)
+	assert.Equal(t, []string{"terminal"}, c.Alerts.Methods)
+	assert.Equal(t, "Trans Date", c.CSVFormats["credit_union"].Columns["date"])
+
+	c.CSVFormats["credit_union"].Columns["amount"] = "${Amount"
+	err := expandStrings(reflect.ValueOf(c).Elem(), "")
+	assert.EqualError(t, err, "csv_formats.credit_union.columns.amount: missing } after ${")
+}
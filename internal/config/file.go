@@ -0,0 +1,285 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultPath returns the standard config file location,
+// ~/.config/fintrack/config.yaml
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "fintrack", "config.yaml"), nil
+}
+
+// FilePath returns the config file that was read, or the standard location
+// when none was found
+func FilePath() (string, error) {
+	if path := viper.ConfigFileUsed(); path != "" {
+		return path, nil
+	}
+	return DefaultPath()
+}
+
+// keyType returns the Go type of a dotted config key such as "database.port",
+// following mapstructure tags. Any key below a map field is valid and has the
+// map's value type.
+func keyType(key string) (reflect.Type, bool) {
+	t := reflect.TypeOf(Config{})
+	for _, part := range strings.Split(key, ".") {
+		switch t.Kind() {
+		case reflect.Struct:
+			field, ok := structField(t, part)
+			if !ok {
+				return nil, false
+			}
+			t = field.Type
+		case reflect.Map:
+			t = t.Elem()
+		default:
+			return nil, false
+		}
+	}
+	return t, true
+}
+
+// structField finds the field of struct type t with the given mapstructure name
+func structField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if strings.Split(field.Tag.Get("mapstructure"), ",")[0] == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// IsKnownKey reports whether key names a config setting or section
+func IsKnownKey(key string) bool {
+	_, ok := keyType(strings.ToLower(key))
+	return ok
+}
+
+// UnknownKeys lists the keys in the YAML config file at path that don't match
+// any setting, in file order
+func UnknownKeys(path string) ([]string, error) {
+	doc, err := readDocument(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var unknown []string
+	var walk func(node *yaml.Node, prefix string)
+	walk = func(node *yaml.Node, prefix string) {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := prefix + node.Content[i].Value
+			t, ok := keyType(key)
+			if !ok {
+				unknown = append(unknown, key)
+				continue
+			}
+			if value := node.Content[i+1]; value.Kind == yaml.MappingNode && (t.Kind() == reflect.Struct || t.Kind() == reflect.Map) {
+				walk(value, key+".")
+			}
+		}
+	}
+	if root := documentRoot(doc); root != nil {
+		walk(root, "")
+	}
+	return unknown, nil
+}
+
+// SetValue sets key to value in the YAML config file at path. See SetValues.
+func SetValue(path, key, value string) error {
+	return SetValues(path, map[string]string{key: value})
+}
+
+// SetValues sets each key to its value in the YAML config file at path,
+// creating the file and any missing sections. Everything else in the file,
+// including comments, is kept. Values are checked against each key's type, and
+// nothing is written if any of them is invalid.
+func SetValues(path string, values map[string]string) error {
+	doc, err := readDocument(path)
+	if err != nil {
+		return err
+	}
+	root := documentRoot(doc)
+	if root == nil {
+		root = &yaml.Node{Kind: yaml.MappingNode}
+		doc.Kind = yaml.DocumentNode
+		doc.Content = []*yaml.Node{root}
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := values[key]
+		key = strings.ToLower(key)
+		scalar, err := scalarNode(key, value)
+		if err != nil {
+			return err
+		}
+		if err := setNode(root, strings.Split(key, "."), scalar); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	data, err := encodeDocument(doc)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	// The file may hold the database password
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// scalarNode builds the YAML value for key, checking value against its type
+func scalarNode(key, value string) (*yaml.Node, error) {
+	t, ok := keyType(key)
+	if !ok {
+		return nil, fmt.Errorf("unknown config key: %s", key)
+	}
+
+	node := &yaml.Node{Kind: yaml.ScalarNode, Value: value}
+	switch t.Kind() {
+	case reflect.String:
+		node.Tag = "!!str"
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be true or false", key)
+		}
+		node.Tag, node.Value = "!!bool", strconv.FormatBool(b)
+	case reflect.Int, reflect.Int64:
+		if _, err := strconv.Atoi(value); err != nil {
+			return nil, fmt.Errorf("%s must be a whole number", key)
+		}
+		node.Tag = "!!int"
+	case reflect.Float64:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return nil, fmt.Errorf("%s must be a number", key)
+		}
+		// Whole numbers are written plainly rather than tagged as floats
+		node.Tag = "!!float"
+		if _, err := strconv.Atoi(value); err == nil {
+			node.Tag = "!!int"
+		}
+	case reflect.Slice:
+		// Lists are given comma-separated, as in FINTRACK_ environment variables
+		node = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Style: yaml.FlowStyle}
+		for _, item := range splitList(value) {
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: item})
+		}
+	default:
+		return nil, fmt.Errorf("%s is a section; set one of its keys instead", key)
+	}
+	return node, nil
+}
+
+// splitList splits a comma-separated list, dropping blanks around and between items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// setNode sets the value at path below a mapping node, adding mappings for
+// missing sections. An existing value keeps its comments, and a string keeps
+// its quoting style.
+func setNode(mapping *yaml.Node, path []string, value *yaml.Node) error {
+	if mapping.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s is not a section in the config file", path[0])
+	}
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value != path[0] {
+			continue
+		}
+		existing := mapping.Content[i+1]
+		if len(path) > 1 {
+			return setNode(existing, path[1:], value)
+		}
+		if existing.Kind == yaml.MappingNode {
+			return fmt.Errorf("existing value is a section")
+		}
+		// A list keeps its block or flow style
+		if value.Tag != "!!str" && !(existing.Kind == yaml.SequenceNode && value.Kind == yaml.SequenceNode) {
+			existing.Style = value.Style
+		}
+		existing.Kind, existing.Value, existing.Tag, existing.Content = value.Kind, value.Value, value.Tag, value.Content
+		return nil
+	}
+
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: path[0]}
+	if len(path) == 1 {
+		mapping.Content = append(mapping.Content, key, value)
+		return nil
+	}
+	section := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	mapping.Content = append(mapping.Content, key, section)
+	return setNode(section, path[1:], value)
+}
+
+// readDocument parses the YAML file at path, returning an empty document when
+// it doesn't exist
+func readDocument(path string) (*yaml.Node, error) {
+	doc := &yaml.Node{}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return doc, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	return doc, nil
+}
+
+// documentRoot returns the top-level mapping of a parsed document, or nil if
+// the document is empty
+func documentRoot(doc *yaml.Node) *yaml.Node {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil
+	}
+	return doc.Content[0]
+}
+
+// encodeDocument formats a document with two-space indentation
+func encodeDocument(doc *yaml.Node) ([]byte, error) {
+	var b strings.Builder
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to format config file: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to format config file: %w", err)
+	}
+	return []byte(b.String()), nil
+}
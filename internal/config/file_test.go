@@ -0,0 +1,143 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const commentedConfig = `# FinTrack configuration
+database:
+  # Where PostgreSQL runs
+  host: localhost # change for remote databases
+  port: 5432
+reports:
+  default_period: "month"
+`
+
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestIsKnownKey(t *testing.T) {
+	assert.True(t, IsKnownKey("database.port"))
+	assert.True(t, IsKnownKey("Reports.Show_Charts"))
+	assert.True(t, IsKnownKey("calendar.symbols"))
+	assert.True(t, IsKnownKey("alerts.low_balance.thresholds.brokerage"))
+	assert.True(t, IsKnownKey("csv_formats.credit_union.columns.date"))
+	assert.True(t, IsKnownKey("output.numbers.currency_position"))
+
+	assert.False(t, IsKnownKey("database.prot"))
+	assert.False(t, IsKnownKey("database.port.number"))
+	assert.False(t, IsKnownKey("alerts.low_balance.thresholds.checking.extra"))
+	assert.False(t, IsKnownKey("csv_formats.chase.skip"))
+}
+
+func TestSetValue_KeepsComments(t *testing.T) {
+	path := writeConfig(t, commentedConfig)
+
+	assert.NoError(t, SetValue(path, "database.host", "db.example.com"))
+	assert.NoError(t, SetValue(path, "reports.default_period", "quarter"))
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, `# FinTrack configuration
+database:
+  # Where PostgreSQL runs
+  host: db.example.com # change for remote databases
+  port: 5432
+reports:
+  default_period: "quarter"
+`, string(data))
+}
+
+func TestSetValues_AddsSections(t *testing.T) {
+	path := writeConfig(t, commentedConfig)
+
+	assert.NoError(t, SetValues(path, map[string]string{
+		"database.port":                          "5433",
+		"reports.show_charts":                    "FALSE",
+		"alerts.low_balance.thresholds.checking": "250",
+	}))
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "  port: 5433\n")
+	assert.Contains(t, string(data), "  show_charts: false\n")
+	assert.Contains(t, string(data), "alerts:\n  low_balance:\n    thresholds:\n      checking: 250\n")
+}
+
+func TestSetValue_CreatesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fintrack", "config.yaml")
+
+	assert.NoError(t, SetValue(path, "calendar.week_start", "1"))
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "calendar:\n  week_start: 1\n", string(data))
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestSetValue_Lists(t *testing.T) {
+	path := writeConfig(t, "alerts:\n  methods:\n    - terminal\n")
+
+	assert.NoError(t, SetValue(path, "alerts.methods", "terminal, desktop"))
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "alerts:\n  methods:\n    - terminal\n    - desktop\n", string(data))
+
+	path = writeConfig(t, commentedConfig)
+	assert.NoError(t, SetValue(path, "alerts.methods", "email"))
+	data, err = os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "alerts:\n  methods: [email]\n")
+}
+
+func TestSetValue_RejectsBadValues(t *testing.T) {
+	path := writeConfig(t, commentedConfig)
+
+	assert.EqualError(t, SetValue(path, "database.prot", "1"), "unknown config key: database.prot")
+	assert.EqualError(t, SetValue(path, "database.port", "high"), "database.port must be a whole number")
+	assert.EqualError(t, SetValue(path, "reports.show_charts", "maybe"), "reports.show_charts must be true or false")
+	assert.EqualError(t, SetValue(path, "database", "x"), "database is a section; set one of its keys instead")
+
+	// A good value alongside a bad one isn't written either
+	assert.Error(t, SetValues(path, map[string]string{"database.host": "db", "database.port": "high"}))
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, commentedConfig, string(data))
+}
+
+func TestUnknownKeys(t *testing.T) {
+	path := writeConfig(t, `database:
+  host: localhost
+  prot: 5432
+reports:
+  show_chart: true
+alerts:
+  low_balance:
+    thresholds:
+      brokerage: 100
+colour: true
+csv_formats:
+  mybank:
+    columns:
+      date: "Date"
+    skiprows: 1
+`)
+
+	unknown, err := UnknownKeys(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"database.prot", "reports.show_chart", "colour", "csv_formats.mybank.skiprows"}, unknown)
+
+	unknown, err = UnknownKeys(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.NoError(t, err)
+	assert.Empty(t, unknown)
+}
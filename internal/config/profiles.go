@@ -0,0 +1,118 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// ErrUnknownProfile is returned by Init when the selected profile isn't in the
+// config file. The rest of the configuration is still loaded, so configuration
+// commands can be used to fix it.
+var ErrUnknownProfile = errors.New("unknown profile")
+
+// activeProfile is the profile selected by Init, or empty for none
+var activeProfile string
+
+// applyProfile merges profiles.<name> over the settings read from the config
+// file; environment variables and overrides still take precedence. Without a
+// name, FINTRACK_PROFILE or the profile key in the config file chooses.
+//
+// A profile naming its own database replaces the whole connection, so a
+// top-level url or password never leaks into another ledger.
+func applyProfile(name string) error {
+	if name == "" {
+		name = viper.GetString("profile")
+	}
+	activeProfile = strings.ToLower(name)
+	if activeProfile == "" {
+		return nil
+	}
+
+	settings, ok := viper.Get("profiles." + activeProfile).(map[string]interface{})
+	if !ok {
+		names := sortedKeys(viper.GetStringMap("profiles"))
+		if len(names) == 0 {
+			return fmt.Errorf("%w %q; no profiles are defined", ErrUnknownProfile, name)
+		}
+		return fmt.Errorf("%w %q (profiles: %s)", ErrUnknownProfile, name, strings.Join(names, ", "))
+	}
+	return viper.MergeConfigMap(withConnection(settings))
+}
+
+// withConnection returns profile settings in which a database section that
+// sets any connection setting sets all of them, using defaults for the rest.
+// The settings from the config file are copied rather than changed.
+func withConnection(settings map[string]interface{}) map[string]interface{} {
+	database, ok := settings["database"].(map[string]interface{})
+	if !ok {
+		return settings
+	}
+	connects := false
+	for key := range connectionDefaults {
+		if _, ok := database[key]; ok {
+			connects = true
+		}
+	}
+	if !connects {
+		return settings
+	}
+
+	merged := make(map[string]interface{}, len(settings))
+	for key, value := range settings {
+		merged[key] = value
+	}
+	replaced := make(map[string]interface{}, len(database)+len(connectionDefaults))
+	for key, value := range connectionDefaults {
+		replaced[key] = value
+	}
+	for key, value := range database {
+		replaced[key] = value
+	}
+	merged["database"] = replaced
+	return merged
+}
+
+// ActiveProfile returns the name of the profile in effect, or "" if none is
+func ActiveProfile() string {
+	return activeProfile
+}
+
+// ProfileNames returns the names of the profiles in the config file, in order
+func ProfileNames() []string {
+	return sortedKeys(Get().Profiles)
+}
+
+// ProfileKey returns where a setting belongs while a profile is active: keys
+// in sections a profile overrides, such as database.host, move below
+// profiles.<name>. Other keys, and any key without a profile, are unchanged.
+func ProfileKey(key string) string {
+	section, _, _ := strings.Cut(key, ".")
+	if activeProfile == "" {
+		return key
+	}
+	if _, ok := structField(reflect.TypeOf(ProfileConfig{}), section); !ok {
+		return key
+	}
+	return "profiles." + activeProfile + "." + key
+}
+
+// ProfileDatabase describes where a profile keeps its data, with any password
+// redacted, or "" if the profile uses the top-level database settings
+func ProfileDatabase(p ProfileConfig) string {
+	db := p.Database
+	if db.URL != "" {
+		return RedactURL(db.URL)
+	}
+	if db.Host == "" && db.Database == "" {
+		return ""
+	}
+	host := db.Host
+	if db.Port != 0 {
+		host = fmt.Sprintf("%s:%d", host, db.Port)
+	}
+	return strings.TrimPrefix(host+"/"+db.Database, "/")
+}
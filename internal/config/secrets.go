@@ -0,0 +1,99 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// DatabasePassword returns the database password from the first source set:
+// database.password, database.password_file, database.password_command, then
+// the FINTRACK_DB_PASSWORD environment variable. Only the first line of a file
+// or command's output is used, as with pass(1).
+func (c *Config) DatabasePassword() (string, error) {
+	db := c.Database
+	switch {
+	case db.Password != "":
+		return db.Password, nil
+	case db.PasswordFile != "":
+		data, err := os.ReadFile(ExpandHome(db.PasswordFile))
+		if err != nil {
+			return "", fmt.Errorf("failed to read database.password_file: %w", err)
+		}
+		return firstLine(data), nil
+	case db.PasswordCommand != "":
+		return runPasswordCommand(db.PasswordCommand)
+	}
+	return os.Getenv("FINTRACK_DB_PASSWORD"), nil
+}
+
+// runPasswordCommand runs command with the shell and returns the first line it
+// prints. The terminal stays attached so a keyring or GPG agent can prompt.
+func runPasswordCommand(command string) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+	var stdout bytes.Buffer
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, &stdout, os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("database.password_command failed: %w", err)
+	}
+	password := firstLine(stdout.Bytes())
+	if password == "" {
+		return "", fmt.Errorf("database.password_command printed no password")
+	}
+	return password, nil
+}
+
+// withPassword adds password to a postgres:// URL that doesn't already carry
+// one. Connection strings in key=value form are returned unchanged.
+func withPassword(dbURL, password string) string {
+	u, err := url.Parse(dbURL)
+	if err != nil || password == "" || (u.Scheme != "postgres" && u.Scheme != "postgresql") {
+		return dbURL
+	}
+	if u.User == nil {
+		return dbURL // No user to authenticate as
+	}
+	if _, ok := u.User.Password(); ok {
+		return dbURL
+	}
+	u.User = url.UserPassword(u.User.Username(), password)
+	return u.String()
+}
+
+// dsnValue quotes a value for a key=value connection string when it contains
+// spaces, quotes or backslashes
+func dsnValue(value string) string {
+	if !strings.ContainsAny(value, ` '\`) {
+		return value
+	}
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+// ExpandHome replaces a leading ~ in path with the user's home directory
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
+
+// firstLine returns the first line of data without its line ending
+func firstLine(data []byte) string {
+	line, _, _ := strings.Cut(string(data), "\n")
+	return strings.TrimSuffix(line, "\r")
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatabasePassword_Sources(t *testing.T) {
+	t.Setenv("FINTRACK_DB_PASSWORD", "from-env")
+	path := filepath.Join(t.TempDir(), "db-password")
+	assert.NoError(t, os.WriteFile(path, []byte("from-file\nsecond line\n"), 0600))
+
+	password, err := (&Config{Database: DatabaseConfig{Password: "inline", PasswordFile: path}}).DatabasePassword()
+	assert.NoError(t, err)
+	assert.Equal(t, "inline", password)
+
+	password, err = (&Config{Database: DatabaseConfig{PasswordFile: path}}).DatabasePassword()
+	assert.NoError(t, err)
+	assert.Equal(t, "from-file", password)
+
+	password, err = (&Config{}).DatabasePassword()
+	assert.NoError(t, err)
+	assert.Equal(t, "from-env", password)
+
+	_, err = (&Config{Database: DatabaseConfig{PasswordFile: path + ".missing"}}).DatabasePassword()
+	assert.ErrorContains(t, err, "failed to read database.password_file")
+}
+
+func TestDatabasePassword_Command(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+
+	password, err := (&Config{Database: DatabaseConfig{PasswordCommand: "printf 'from-command\\r\\nmetadata\\n'"}}).DatabasePassword()
+	assert.NoError(t, err)
+	assert.Equal(t, "from-command", password)
+
+	_, err = (&Config{Database: DatabaseConfig{PasswordCommand: "exit 3"}}).DatabasePassword()
+	assert.ErrorContains(t, err, "database.password_command failed")
+
+	_, err = (&Config{Database: DatabaseConfig{PasswordCommand: "true"}}).DatabasePassword()
+	assert.EqualError(t, err, "database.password_command printed no password")
+}
+
+func TestGetDatabaseURL_PasswordSources(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db-password")
+	assert.NoError(t, os.WriteFile(path, []byte("it's a secret\n"), 0600))
+
+	c := &Config{Database: DatabaseConfig{Host: "db", Port: 5432, User: "fin", Database: "fintrack", SSLMode: "disable", PasswordFile: path}}
+	dsn, err := c.GetDatabaseURL()
+	assert.NoError(t, err)
+	assert.Equal(t, `host=db port=5432 user=fin password='it\'s a secret' dbname=fintrack sslmode=disable`, dsn)
+
+	c.Database.URL = "postgresql://fin@db:5432/fintrack?sslmode=disable"
+	dsn, err = c.GetDatabaseURL()
+	assert.NoError(t, err)
+	assert.Equal(t, "postgresql://fin:it%27s%20a%20secret@db:5432/fintrack?sslmode=disable", dsn)
+}
+
+func TestWithPassword(t *testing.T) {
+	assert.Equal(t, "postgresql://fin:pw@db/fintrack", withPassword("postgresql://fin@db/fintrack", "pw"))
+	assert.Equal(t, "postgresql://fin:kept@db/fintrack", withPassword("postgresql://fin:kept@db/fintrack", "pw"))
+	assert.Equal(t, "postgresql://db/fintrack", withPassword("postgresql://db/fintrack", "pw"))
+	assert.Equal(t, "postgresql://fin@db/fintrack", withPassword("postgresql://fin@db/fintrack", ""))
+	assert.Equal(t, "host=db user=fin", withPassword("host=db user=fin", "pw"))
+}
+
+func TestExpandHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	assert.Equal(t, filepath.Join(home, ".pgpass"), ExpandHome("~/.pgpass"))
+	assert.Equal(t, "/etc/fintrack/password", ExpandHome("/etc/fintrack/password"))
+	assert.Equal(t, "~user/password", ExpandHome("~user/password"))
+}
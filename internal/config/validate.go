@@ -0,0 +1,264 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// redacted replaces secrets in displayed configuration
+const redacted = "********"
+
+// Allowed values of enumerated settings
+var (
+	validSSLModes     = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+	validFormats      = []string{"table", "json", "csv"}
+	validPriorities   = []string{"low", "normal", "high", "urgent"}
+	validPeriods      = []string{"week", "month", "quarter", "year"}
+	validCategorySort = []string{"name", "amount", "count"}
+	validAlertMethods = []string{"terminal", "desktop", "email"}
+	validBorderStyles = []string{"ascii", "light", "bold", "double", "rounded"}
+	validCurrencySide = []string{"left", "right"}
+	validLogLevels    = []string{"debug", "info", "warn", "error"}
+	validPlaidEnvs    = []string{"sandbox", "development", "production"}
+	validAIModels     = []string{"local", "openai", "anthropic"}
+	validAmountSigns  = []string{"normal", "inverse"}
+	validCSVColumns   = []string{"date", "amount", "amount_inflow", "payee", "category", "description", "memo", "account", "type"}
+)
+
+// secretKeys are settings redacted wherever configuration is displayed, besides
+// the database password and URL of the top level and each profile
+var secretKeys = []string{"experimental.plaid_secret", "experimental.ai_api_key"}
+
+// dsnPassword matches the password in a key=value connection string
+var dsnPassword = regexp.MustCompile(`(password=)('(?:[^'\\]|\\.)*'|\S+)`)
+
+// Validate checks settings for values out of range or not among the allowed
+// choices, returning one error per problem
+func (c *Config) Validate() []error {
+	var errs []error
+	check := func(ok bool, key, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+		}
+	}
+
+	if c.Profile != "" {
+		_, ok := c.Profiles[c.Profile]
+		check(ok, "profile", "unknown profile %q (profiles: %s)", c.Profile, strings.Join(sortedKeys(c.Profiles), ", "))
+	}
+
+	db := c.Database
+	check(db.Port >= 1 && db.Port <= 65535, "database.port", "must be between 1 and 65535 (got %d)", db.Port)
+	check(oneOf(db.SSLMode, validSSLModes), "database.sslmode", "must be one of %s (got %q)", strings.Join(validSSLModes, ", "), db.SSLMode)
+	check(db.MaxConnections >= 0, "database.max_connections", "must not be negative")
+	check(db.MaxIdleConnections >= 0, "database.max_idle_connections", "must not be negative")
+	sources := 0
+	for _, source := range []string{db.Password, db.PasswordFile, db.PasswordCommand} {
+		if source != "" {
+			sources++
+		}
+	}
+	check(sources <= 1, "database.password", "set only one of password, password_file and password_command")
+	if db.PasswordFile != "" {
+		_, err := os.Stat(ExpandHome(db.PasswordFile))
+		check(err == nil, "database.password_file", "cannot read %s", db.PasswordFile)
+	}
+	if db.ConnectionMaxLifetime != "" {
+		_, err := time.ParseDuration(db.ConnectionMaxLifetime)
+		check(err == nil, "database.connection_max_lifetime", "must be a duration such as 30m or 1h (got %q)", db.ConnectionMaxLifetime)
+	}
+
+	check(len(c.Defaults.Currency) == 3, "defaults.currency", "must be a three-letter currency code (got %q)", c.Defaults.Currency)
+	if c.Defaults.Timezone != "" {
+		_, err := time.LoadLocation(c.Defaults.Timezone)
+		check(err == nil, "defaults.timezone", "unknown time zone %q", c.Defaults.Timezone)
+	}
+
+	check(c.Alerts.Threshold > 0 && c.Alerts.Threshold <= 1, "alerts.threshold", "must be between 0 and 1 (got %g)", c.Alerts.Threshold)
+	for _, method := range c.Alerts.Methods {
+		check(oneOf(method, validAlertMethods), "alerts.methods", "must each be one of %s (got %q)", strings.Join(validAlertMethods, ", "), method)
+	}
+	for _, name := range sortedKeys(c.Alerts.LowBalance.Thresholds) {
+		check(c.Alerts.LowBalance.Thresholds[name] >= 0, "alerts.low_balance.thresholds."+name, "must not be negative")
+	}
+	for _, name := range sortedKeys(c.Alerts.LowBalance.Accounts) {
+		check(c.Alerts.LowBalance.Accounts[name] >= 0, "alerts.low_balance.accounts."+name, "must not be negative")
+	}
+
+	check(c.Recurring.GenerateDaysAhead >= 0, "recurring.generate_days_ahead", "must not be negative")
+	check(c.Recurring.ReminderDaysBefore >= 0, "recurring.reminder_days_before", "must not be negative")
+
+	o := c.Output
+	check(oneOf(o.DefaultFormat, validFormats), "output.default_format", "must be one of %s (got %q)", strings.Join(validFormats, ", "), o.DefaultFormat)
+	check(oneOf(o.Table.BorderStyle, validBorderStyles), "output.table.border_style", "must be one of %s (got %q)", strings.Join(validBorderStyles, ", "), o.Table.BorderStyle)
+	check(o.Numbers.DecimalPlaces >= 0 && o.Numbers.DecimalPlaces <= 4, "output.numbers.decimal_places", "must be between 0 and 4 (got %d)", o.Numbers.DecimalPlaces)
+	check(oneOf(o.Numbers.CurrencyPosition, validCurrencySide), "output.numbers.currency_position", "must be one of %s (got %q)", strings.Join(validCurrencySide, ", "), o.Numbers.CurrencyPosition)
+
+	_, err := time.Parse("15:04", c.Reminders.DefaultTime)
+	check(err == nil, "reminders.default_time", "must be a time as HH:MM (got %q)", c.Reminders.DefaultTime)
+	check(oneOf(c.Reminders.StartupPriorityFilter, validPriorities), "reminders.startup_priority_filter", "must be one of %s (got %q)", strings.Join(validPriorities, ", "), c.Reminders.StartupPriorityFilter)
+	check(c.Reminders.AutoDismissAfterDays >= 0, "reminders.auto_dismiss_after_days", "must not be negative")
+
+	p := c.Projection
+	check(p.DefaultDays > 0, "projection.default_days", "must be positive")
+	check(p.ConfidenceThreshold >= 0 && p.ConfidenceThreshold <= 1, "projection.confidence_threshold", "must be between 0 and 1 (got %g)", p.ConfidenceThreshold)
+	check(p.HistoricalWindowDays > 0, "projection.historical_window_days", "must be positive")
+	for _, name := range sortedKeys(p.Scenarios) {
+		check(p.Scenarios[name] >= 0 && p.Scenarios[name] <= 1, "projection.scenarios."+name, "must be a percentile between 0 and 1 (got %g)", p.Scenarios[name])
+	}
+	if _, ok := p.Scenarios[p.Scenario]; !ok {
+		check(false, "projection.scenario", "must be one of %s (got %q)", strings.Join(sortedKeys(p.Scenarios), ", "), p.Scenario)
+	}
+
+	r := c.Reports
+	check(oneOf(r.DefaultPeriod, validPeriods), "reports.default_period", "must be one of %s (got %q)", strings.Join(validPeriods, ", "), r.DefaultPeriod)
+	check(r.ChartWidth > 0, "reports.chart_width", "must be positive")
+	check(oneOf(r.CategorySort, validCategorySort), "reports.category_sort", "must be one of %s (got %q)", strings.Join(validCategorySort, ", "), r.CategorySort)
+
+	check(c.Calendar.WeekStart == 0 || c.Calendar.WeekStart == 1, "calendar.week_start", "must be 0 (Sunday) or 1 (Monday)")
+	check(c.Calendar.UpcomingCount > 0, "calendar.upcoming_count", "must be positive")
+
+	im := c.Import
+	check(im.DuplicateWindowDays >= 0, "import.duplicate_window_days", "must not be negative")
+	check(im.MaxFileSizeMB > 0, "import.max_file_size_mb", "must be positive")
+	if _, ok := c.CSVFormats[strings.ToLower(im.DefaultFormat)]; !ok {
+		check(false, "import.default_format", "must be one of the csv_formats: %s (got %q)", strings.Join(sortedKeys(c.CSVFormats), ", "), im.DefaultFormat)
+	}
+	if im.SkipBeforeDate != "" {
+		_, err := time.Parse("2006-01-02", im.SkipBeforeDate)
+		check(err == nil, "import.skip_before_date", "must be a date as YYYY-MM-DD (got %q)", im.SkipBeforeDate)
+	}
+
+	a := c.Advanced
+	check(oneOf(a.LogLevel, validLogLevels), "advanced.log_level", "must be one of %s (got %q)", strings.Join(validLogLevels, ", "), a.LogLevel)
+	check(a.SlowQueryThresholdMS >= 0, "advanced.slow_query_threshold_ms", "must not be negative")
+
+	x := c.Experimental
+	if x.PlaidEnvironment != "" {
+		check(oneOf(x.PlaidEnvironment, validPlaidEnvs), "experimental.plaid_environment", "must be one of %s (got %q)", strings.Join(validPlaidEnvs, ", "), x.PlaidEnvironment)
+	}
+	if x.PlaidEnabled {
+		check(x.PlaidClientID != "" && x.PlaidSecret != "", "experimental.plaid_enabled", "needs plaid_client_id and plaid_secret")
+	}
+	if x.AIModel != "" {
+		check(oneOf(x.AIModel, validAIModels), "experimental.ai_model", "must be one of %s (got %q)", strings.Join(validAIModels, ", "), x.AIModel)
+	}
+
+	for _, name := range sortedKeys(c.CSVFormats) {
+		f, key := c.CSVFormats[name], "csv_formats."+name
+		check(f.Columns["date"] != "" && f.Columns["amount"] != "", key+".columns", "must map at least date and amount")
+		for _, column := range sortedKeys(f.Columns) {
+			check(oneOf(column, validCSVColumns), key+".columns", "unknown column %q; expected one of %s", column, strings.Join(validCSVColumns, ", "))
+		}
+		check(f.DateFormat != "", key+".date_format", "must be set")
+		if f.AmountSign != "" {
+			check(oneOf(f.AmountSign, validAmountSigns), key+".amount_sign", "must be one of %s (got %q)", strings.Join(validAmountSigns, ", "), f.AmountSign)
+		}
+		check(f.SkipRows >= 0, key+".skip_rows", "must not be negative")
+	}
+
+	return errs
+}
+
+// Settings returns every setting, from defaults, the config file and the
+// environment, as nested maps keyed by section, with secrets redacted
+func Settings() map[string]interface{} {
+	settings := viper.AllSettings()
+	for _, key := range secretKeys {
+		parts := strings.SplitN(key, ".", 2)
+		if section, ok := settings[parts[0]].(map[string]interface{}); ok {
+			if secret, _ := section[parts[1]].(string); secret != "" {
+				section[parts[1]] = redacted
+			}
+		}
+	}
+	redactDatabase(settings["database"])
+	if profiles, ok := settings["profiles"].(map[string]interface{}); ok {
+		for _, profile := range profiles {
+			if profile, ok := profile.(map[string]interface{}); ok {
+				redactDatabase(profile["database"])
+			}
+		}
+	}
+	return settings
+}
+
+// redactDatabase hides the password and any password in the URL of a database
+// section of the settings
+func redactDatabase(section interface{}) {
+	database, ok := section.(map[string]interface{})
+	if !ok {
+		return
+	}
+	if password, _ := database["password"].(string); password != "" {
+		database["password"] = redacted
+	}
+	if dbURL, _ := database["url"].(string); dbURL != "" {
+		database["url"] = RedactURL(dbURL)
+	}
+}
+
+// RedactURL hides the password in a database URL or key=value connection string
+func RedactURL(dbURL string) string {
+	if u, err := url.Parse(dbURL); err == nil && u.User != nil {
+		return u.Redacted()
+	}
+	return dsnPassword.ReplaceAllString(dbURL, "${1}"+redacted)
+}
+
+// Value returns the setting or section at a dotted key as Settings shows it,
+// or false if nothing is set there
+func Value(key string) (interface{}, bool) {
+	var value interface{} = Settings()
+	for _, part := range strings.Split(strings.ToLower(key), ".") {
+		section, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = section[part]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// Override sets a value for the rest of this run, taking precedence over the
+// config file and environment, without saving it. A string given for a list
+// setting is split on commas.
+func Override(key string, value interface{}) error {
+	if text, ok := value.(string); ok {
+		if t, known := keyType(strings.ToLower(key)); known && t.Kind() == reflect.Slice {
+			value = splitList(text)
+		}
+	}
+	viper.Set(key, value)
+	return load()
+}
+
+// oneOf reports whether value is among choices, ignoring case
+func oneOf(value string, choices []string) bool {
+	for _, choice := range choices {
+		if strings.EqualFold(value, choice) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
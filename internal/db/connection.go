@@ -55,7 +55,10 @@ func Init() error {
 func initDB() error {
 	cfg := config.Get()
 
-	dsn := cfg.GetDatabaseURL()
+	dsn, err := cfg.GetDatabaseURL()
+	if err != nil {
+		return err
+	}
 	if dsn == "" {
 		return fmt.Errorf(`database not configured
 
@@ -81,11 +84,16 @@ For more help, see: https://github.com/jcaldwell-labs/fintrack#configuration`)
 		},
 	}
 
+	// Name the profile in connection errors, so it's clear which ledger failed
+	target := "database"
+	if profile := config.ActiveProfile(); profile != "" {
+		target = fmt.Sprintf("database of profile %q", profile)
+	}
+
 	// Connect to database
-	var err error
 	connDB, err := gorm.Open(postgres.Open(dsn), gormConfig)
 	if err != nil {
-		return fmt.Errorf("failed to connect to database: %w", err)
+		return fmt.Errorf("failed to connect to %s: %w", target, err)
 	}
 
 	// Get underlying SQL database for connection pool configuration
@@ -108,7 +116,7 @@ For more help, see: https://github.com/jcaldwell-labs/fintrack#configuration`)
 
 	// Test connection
 	if err := sqlDB.Ping(); err != nil {
-		return fmt.Errorf("failed to ping database: %w", err)
+		return fmt.Errorf("failed to ping %s: %w", target, err)
 	}
 
 	// Only assign to global after successful connection
@@ -154,6 +162,7 @@ func AutoMigrate() error {
 		&models.Category{},
 		&models.Transaction{},
 		&models.Budget{},
+		&models.EnvelopeAllocation{},
 		&models.RecurringItem{},
 		&models.Reminder{},
 		&models.CashFlowProjection{},
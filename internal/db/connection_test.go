@@ -87,6 +87,7 @@ func TestAutoMigrate_WhenInitialized(t *testing.T) {
 	assert.True(t, testDB.Migrator().HasTable(&models.Category{}))
 	assert.True(t, testDB.Migrator().HasTable(&models.Transaction{}))
 	assert.True(t, testDB.Migrator().HasTable(&models.Budget{}))
+	assert.True(t, testDB.Migrator().HasTable(&models.EnvelopeAllocation{}))
 	assert.True(t, testDB.Migrator().HasTable(&models.RecurringItem{}))
 	assert.True(t, testDB.Migrator().HasTable(&models.Reminder{}))
 	assert.True(t, testDB.Migrator().HasTable(&models.CashFlowProjection{}))
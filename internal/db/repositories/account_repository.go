@@ -84,11 +84,21 @@ func (r *AccountRepository) HardDelete(id uint) error {
 	return r.db.Delete(&models.Account{}, id).Error
 }
 
-// UpdateBalance updates the account balance (in cents)
+// UpdateBalance updates the account balance (in cents) and runs the registered
+// balance hooks
 func (r *AccountRepository) UpdateBalance(id uint, newBalanceCents int64) error {
-	return r.db.Model(&models.Account{}).
-		Where("id = ?", id).
-		Update("current_balance", newBalanceCents).Error
+	return r.db.Transaction(func(dbTx *gorm.DB) error {
+		var account models.Account
+		if err := dbTx.Select("current_balance").First(&account, id).Error; err != nil {
+			return err
+		}
+		if err := dbTx.Model(&models.Account{}).
+			Where("id = ?", id).
+			Update("current_balance", newBalanceCents).Error; err != nil {
+			return err
+		}
+		return runBalanceHooks(dbTx, map[uint]int64{id: newBalanceCents - account.CurrentBalanceCents})
+	})
 }
 
 // GetBalance retrieves the current balance (in cents) for an account
@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/models"
+	"gorm.io/gorm"
+)
+
+// BudgetRepository handles budget data operations
+type BudgetRepository struct {
+	db *gorm.DB
+}
+
+// NewBudgetRepository creates a new budget repository
+func NewBudgetRepository(db *gorm.DB) *BudgetRepository {
+	return &BudgetRepository{db: db}
+}
+
+// Create creates a new budget
+func (r *BudgetRepository) Create(budget *models.Budget) error {
+	return r.db.Create(budget).Error
+}
+
+// GetByID retrieves a budget by ID with its category
+func (r *BudgetRepository) GetByID(id uint) (*models.Budget, error) {
+	var budget models.Budget
+	err := r.db.Preload("Category").First(&budget, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("budget not found")
+		}
+		return nil, err
+	}
+	return &budget, nil
+}
+
+// GetByName retrieves the most recent active budget with the given name
+func (r *BudgetRepository) GetByName(name string) (*models.Budget, error) {
+	var budget models.Budget
+	err := r.db.Preload("Category").
+		Where("name = ? AND is_active = ?", name, true).
+		Order("period_start desc").
+		First(&budget).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("budget not found")
+		}
+		return nil, err
+	}
+	return &budget, nil
+}
+
+// List retrieves all budgets with optional active filter
+func (r *BudgetRepository) List(activeOnly bool) ([]*models.Budget, error) {
+	var budgets []*models.Budget
+	query := r.db.Preload("Category")
+
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	err := query.Order("period_start desc, name").Find(&budgets).Error
+	return budgets, err
+}
+
+// ListByCategory retrieves active budgets for a specific category
+func (r *BudgetRepository) ListByCategory(categoryID uint) ([]*models.Budget, error) {
+	var budgets []*models.Budget
+	err := r.db.Preload("Category").
+		Where("category_id = ? AND is_active = ?", categoryID, true).
+		Order("period_start desc").
+		Find(&budgets).Error
+	return budgets, err
+}
+
+// ListEndedBefore retrieves active budgets whose period ended before the given date
+func (r *BudgetRepository) ListEndedBefore(date time.Time) ([]*models.Budget, error) {
+	var budgets []*models.Budget
+	err := r.db.Where("is_active = ? AND period_end < ?", true, startOfDay(date)).
+		Order("period_start, id").
+		Find(&budgets).Error
+	return budgets, err
+}
+
+// FindPeriod retrieves the budget with the same name, category and period type
+// starting on the given day, or nil if none exists
+func (r *BudgetRepository) FindPeriod(budget *models.Budget, periodStart time.Time) (*models.Budget, error) {
+	day := startOfDay(periodStart)
+	query := r.db.Where("name = ? AND period_type = ? AND period_start >= ? AND period_start < ?",
+		budget.Name, budget.PeriodType, day, day.AddDate(0, 0, 1))
+	if budget.CategoryID != nil {
+		query = query.Where("category_id = ?", *budget.CategoryID)
+	} else {
+		query = query.Where("category_id IS NULL")
+	}
+
+	var found models.Budget
+	err := query.First(&found).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &found, nil
+}
+
+// Update updates a budget
+func (r *BudgetRepository) Update(budget *models.Budget) error {
+	return r.db.Save(budget).Error
+}
+
+// Delete permanently deletes a budget
+func (r *BudgetRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.Budget{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("budget not found")
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// BudgetRepositoryTestSuite is the test suite for budget repository
+type BudgetRepositoryTestSuite struct {
+	suite.Suite
+	db       *gorm.DB
+	repo     *BudgetRepository
+	category *models.Category
+}
+
+// SetupSuite runs once before all tests
+func (suite *BudgetRepositoryTestSuite) SetupSuite() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(suite.T(), err)
+
+	suite.db = db
+	suite.repo = NewBudgetRepository(db)
+}
+
+// SetupTest runs before each test
+func (suite *BudgetRepositoryTestSuite) SetupTest() {
+	_ = suite.db.Migrator().DropTable(&models.Budget{}, &models.Transaction{}, &models.Category{})
+	_ = suite.db.AutoMigrate(&models.Category{}, &models.Transaction{}, &models.Budget{})
+
+	suite.category = &models.Category{Name: "Groceries", Type: models.CategoryTypeExpense}
+	assert.NoError(suite.T(), suite.db.Create(suite.category).Error)
+}
+
+func (suite *BudgetRepositoryTestSuite) newBudget(name string, start time.Time) *models.Budget {
+	return &models.Budget{
+		Name:             name,
+		CategoryID:       &suite.category.ID,
+		PeriodType:       models.BudgetPeriodMonthly,
+		PeriodStart:      start,
+		PeriodEnd:        start.AddDate(0, 1, -1),
+		LimitAmountCents: 60000,
+		AlertThreshold:   0.80,
+		IsActive:         true,
+	}
+}
+
+func (suite *BudgetRepositoryTestSuite) TestCreateAndGetByID() {
+	budget := suite.newBudget("Groceries", time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(suite.T(), suite.repo.Create(budget))
+	assert.NotZero(suite.T(), budget.ID)
+
+	retrieved, err := suite.repo.GetByID(budget.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Groceries", retrieved.Name)
+	assert.Equal(suite.T(), int64(60000), retrieved.LimitAmountCents)
+	assert.NotNil(suite.T(), retrieved.Category)
+	assert.Equal(suite.T(), "Groceries", retrieved.Category.Name)
+}
+
+func (suite *BudgetRepositoryTestSuite) TestGetByID_NotFound() {
+	_, err := suite.repo.GetByID(9999)
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "not found")
+}
+
+func (suite *BudgetRepositoryTestSuite) TestGetByName_ReturnsLatestPeriod() {
+	_ = suite.repo.Create(suite.newBudget("Groceries", time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)))
+	latest := suite.newBudget("Groceries", time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC))
+	_ = suite.repo.Create(latest)
+
+	retrieved, err := suite.repo.GetByName("Groceries")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), latest.ID, retrieved.ID)
+}
+
+func (suite *BudgetRepositoryTestSuite) TestList_ActiveOnly() {
+	active := suite.newBudget("Active", time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC))
+	inactive := suite.newBudget("Inactive", time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC))
+	_ = suite.repo.Create(active)
+	_ = suite.repo.Create(inactive)
+	suite.db.Model(inactive).Update("is_active", false)
+
+	budgets, err := suite.repo.List(true)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), budgets, 1)
+	assert.Equal(suite.T(), "Active", budgets[0].Name)
+
+	budgets, err = suite.repo.List(false)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), budgets, 2)
+}
+
+func (suite *BudgetRepositoryTestSuite) TestUpdate() {
+	budget := suite.newBudget("Groceries", time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC))
+	_ = suite.repo.Create(budget)
+
+	budget.LimitAmountCents = 75000
+	assert.NoError(suite.T(), suite.repo.Update(budget))
+
+	retrieved, _ := suite.repo.GetByID(budget.ID)
+	assert.Equal(suite.T(), int64(75000), retrieved.LimitAmountCents)
+}
+
+func (suite *BudgetRepositoryTestSuite) TestDelete() {
+	budget := suite.newBudget("Groceries", time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC))
+	_ = suite.repo.Create(budget)
+
+	assert.NoError(suite.T(), suite.repo.Delete(budget.ID))
+	_, err := suite.repo.GetByID(budget.ID)
+	assert.Error(suite.T(), err)
+
+	assert.Error(suite.T(), suite.repo.Delete(budget.ID))
+}
+
+func (suite *BudgetRepositoryTestSuite) TestGetSpendingInPeriod() {
+	txRepo := NewTransactionRepository(suite.db)
+	other := &models.Category{Name: "Fuel", Type: models.CategoryTypeExpense}
+	_ = suite.db.Create(other)
+
+	txs := []*models.Transaction{
+		{AccountID: 1, Date: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), AmountCents: -2500, CategoryID: &suite.category.ID, Type: models.TransactionTypeExpense},
+		{AccountID: 1, Date: time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC), AmountCents: -1000, CategoryID: &suite.category.ID, Type: models.TransactionTypeExpense},
+		{AccountID: 1, Date: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), AmountCents: -9999, CategoryID: &suite.category.ID, Type: models.TransactionTypeExpense},
+		{AccountID: 1, Date: time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC), AmountCents: -4000, CategoryID: &other.ID, Type: models.TransactionTypeExpense},
+		{AccountID: 1, Date: time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC), AmountCents: 300000, Type: models.TransactionTypeIncome},
+	}
+	for _, tx := range txs {
+		assert.NoError(suite.T(), suite.db.Create(tx).Error)
+	}
+
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC)
+
+	spent, err := txRepo.GetSpendingInPeriod([]uint{suite.category.ID}, from, to)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(3500), spent)
+
+	spent, err = txRepo.GetSpendingInPeriod(nil, from, to)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(7500), spent)
+
+	byCategory, err := txRepo.GetSpendingByCategory([]uint{suite.category.ID, other.ID}, from, to)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(3500), byCategory[suite.category.ID])
+	assert.Equal(suite.T(), int64(4000), byCategory[other.ID])
+}
+
+func TestBudgetRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(BudgetRepositoryTestSuite))
+}
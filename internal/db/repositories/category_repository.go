@@ -87,6 +87,38 @@ func (r *CategoryRepository) ListSubcategories(parentID uint) ([]*models.Categor
 	return categories, err
 }
 
+// categoryTreeQuery selects a category and all of its descendants. It is plain
+// recursive SQL supported by both PostgreSQL and SQLite. UNION (rather than
+// UNION ALL) stops the recursion if parent links ever form a cycle.
+const categoryTreeQuery = `WITH RECURSIVE category_tree(id) AS (
+	SELECT id FROM categories WHERE id = ?
+	UNION
+	SELECT c.id FROM categories c JOIN category_tree t ON c.parent_id = t.id
+)
+SELECT id FROM category_tree`
+
+// GetDescendantIDs retrieves the IDs of a category and all of its descendants
+func (r *CategoryRepository) GetDescendantIDs(categoryID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.Raw(categoryTreeQuery, categoryID).Scan(&ids).Error
+	return ids, err
+}
+
+// ListDescendants retrieves a category and all of its descendants
+func (r *CategoryRepository) ListDescendants(categoryID uint) ([]*models.Category, error) {
+	ids, err := r.GetDescendantIDs(categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	var categories []*models.Category
+	if len(ids) == 0 {
+		return categories, nil
+	}
+	err = r.db.Where("id IN ?", ids).Order("name").Find(&categories).Error
+	return categories, err
+}
+
 // Update updates a category
 func (r *CategoryRepository) Update(category *models.Category) error {
 	return r.db.Save(category).Error
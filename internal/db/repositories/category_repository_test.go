@@ -345,6 +345,48 @@ func (suite *CategoryRepositoryTestSuite) TestGetSystemCategories() {
 	}
 }
 
+// TestGetDescendantIDs tests resolving a category tree recursively
+func (suite *CategoryRepositoryTestSuite) TestGetDescendantIDs() {
+	// Given - Food & Dining > Restaurants > Fast Food, plus an unrelated category
+	food := &models.Category{Name: "Food & Dining", Type: models.CategoryTypeExpense}
+	_ = suite.repo.Create(food)
+	restaurants := &models.Category{Name: "Restaurants", Type: models.CategoryTypeExpense, ParentID: &food.ID}
+	_ = suite.repo.Create(restaurants)
+	fastFood := &models.Category{Name: "Fast Food", Type: models.CategoryTypeExpense, ParentID: &restaurants.ID}
+	_ = suite.repo.Create(fastFood)
+	groceries := &models.Category{Name: "Groceries", Type: models.CategoryTypeExpense, ParentID: &food.ID}
+	_ = suite.repo.Create(groceries)
+	_ = suite.repo.Create(&models.Category{Name: "Transportation", Type: models.CategoryTypeExpense})
+
+	// When
+	ids, err := suite.repo.GetDescendantIDs(food.ID)
+
+	// Then
+	assert.NoError(suite.T(), err)
+	assert.ElementsMatch(suite.T(), []uint{food.ID, restaurants.ID, fastFood.ID, groceries.ID}, ids)
+
+	ids, err = suite.repo.GetDescendantIDs(restaurants.ID)
+	assert.NoError(suite.T(), err)
+	assert.ElementsMatch(suite.T(), []uint{restaurants.ID, fastFood.ID}, ids)
+
+	descendants, err := suite.repo.ListDescendants(restaurants.ID)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), descendants, 2)
+}
+
+// TestGetDescendantIDs_Cycle tests that a parent cycle does not recurse forever
+func (suite *CategoryRepositoryTestSuite) TestGetDescendantIDs_Cycle() {
+	a := &models.Category{Name: "A", Type: models.CategoryTypeExpense}
+	_ = suite.repo.Create(a)
+	b := &models.Category{Name: "B", Type: models.CategoryTypeExpense, ParentID: &a.ID}
+	_ = suite.repo.Create(b)
+	suite.db.Model(a).Update("parent_id", b.ID)
+
+	ids, err := suite.repo.GetDescendantIDs(a.ID)
+	assert.NoError(suite.T(), err)
+	assert.ElementsMatch(suite.T(), []uint{a.ID, b.ID}, ids)
+}
+
 // Run the test suite
 func TestCategoryRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(CategoryRepositoryTestSuite))
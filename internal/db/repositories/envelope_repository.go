@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/fintrack/fintrack/internal/models"
+	"gorm.io/gorm"
+)
+
+// EnvelopeRepository handles envelope allocation data operations
+type EnvelopeRepository struct {
+	db *gorm.DB
+}
+
+// NewEnvelopeRepository creates a new envelope repository
+func NewEnvelopeRepository(db *gorm.DB) *EnvelopeRepository {
+	return &EnvelopeRepository{db: db}
+}
+
+// Create creates a new envelope allocation
+func (r *EnvelopeRepository) Create(allocation *models.EnvelopeAllocation) error {
+	return r.db.Create(allocation).Error
+}
+
+// CreateMove records a move between two envelopes as a pair of allocations
+// in a single database transaction
+func (r *EnvelopeRepository) CreateMove(fromCategoryID, toCategoryID uint, month time.Time, amountCents int64, note string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		out := &models.EnvelopeAllocation{
+			Month:       month,
+			CategoryID:  fromCategoryID,
+			AmountCents: -amountCents,
+			Kind:        models.AllocationKindMove,
+			Note:        note,
+		}
+		if err := tx.Create(out).Error; err != nil {
+			return err
+		}
+		in := &models.EnvelopeAllocation{
+			Month:       month,
+			CategoryID:  toCategoryID,
+			AmountCents: amountCents,
+			Kind:        models.AllocationKindMove,
+			Note:        note,
+		}
+		return tx.Create(in).Error
+	})
+}
+
+// ListByMonth retrieves all allocations for a month
+func (r *EnvelopeRepository) ListByMonth(month time.Time) ([]*models.EnvelopeAllocation, error) {
+	var allocations []*models.EnvelopeAllocation
+	day := startOfDay(month)
+	err := r.db.Preload("Category").
+		Where("month >= ? AND month < ?", day, day.AddDate(0, 0, 1)).
+		Order("created_at, id").
+		Find(&allocations).Error
+	return allocations, err
+}
+
+// SumByCategory totals allocations per category for months in [from, to].
+// A nil from includes every month up to and including to.
+func (r *EnvelopeRepository) SumByCategory(from *time.Time, to time.Time) (map[uint]int64, error) {
+	var rows []struct {
+		CategoryID uint
+		Total      int64
+	}
+	query := r.db.Model(&models.EnvelopeAllocation{}).
+		Where("month < ?", startOfDay(to).AddDate(0, 0, 1))
+	if from != nil {
+		query = query.Where("month >= ?", startOfDay(*from))
+	}
+	err := query.Select("category_id, COALESCE(SUM(amount), 0) AS total").
+		Group("category_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		totals[row.CategoryID] = row.Total
+	}
+	return totals, nil
+}
@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/fintrack/fintrack/internal/models"
+	"gorm.io/gorm"
+)
+
+// TransactionHook is called after transactions are created or updated. It runs
+// inside the same database transaction as the change, so it sees the new rows
+// and balances, and returning an error rolls the whole change back.
+type TransactionHook func(tx *gorm.DB, transactions []*models.Transaction) error
+
+var (
+	hooksMu          sync.RWMutex
+	transactionHooks = make(map[string]TransactionHook)
+	createHooks      = make(map[string]TransactionHook)
+)
+
+// RegisterTransactionHook registers a hook under name, replacing any hook
+// previously registered with the same name. Hooks run in name order.
+func RegisterTransactionHook(name string, hook TransactionHook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	transactionHooks[name] = hook
+}
+
+// UnregisterTransactionHook removes the hook registered under name
+func UnregisterTransactionHook(name string) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	delete(transactionHooks, name)
+}
+
+// RegisterTransactionCreateHook registers a hook that runs only when
+// transactions are created, not when they are updated. Create hooks run in
+// name order after the transaction hooks.
+func RegisterTransactionCreateHook(name string, hook TransactionHook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	createHooks[name] = hook
+}
+
+// UnregisterTransactionCreateHook removes the create hook registered under name
+func UnregisterTransactionCreateHook(name string) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	delete(createHooks, name)
+}
+
+// runTransactionHooks runs every registered hook against the changed transactions
+func runTransactionHooks(tx *gorm.DB, transactions []*models.Transaction) error {
+	return runHooks(transactionHooks, tx, transactions)
+}
+
+// runCreateHooks runs the transaction hooks and then the create hooks against
+// newly created transactions
+func runCreateHooks(tx *gorm.DB, transactions []*models.Transaction) error {
+	if err := runHooks(transactionHooks, tx, transactions); err != nil {
+		return err
+	}
+	return runHooks(createHooks, tx, transactions)
+}
+
+// runHooks runs the hooks registered in registry in name order
+func runHooks(registry map[string]TransactionHook, tx *gorm.DB, transactions []*models.Transaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+
+	hooksMu.RLock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	hooks := make(map[string]TransactionHook, len(registry))
+	for name, hook := range registry {
+		hooks[name] = hook
+	}
+	hooksMu.RUnlock()
+
+	sort.Strings(names)
+	for _, name := range names {
+		if err := hooks[name](tx, transactions); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// BalanceHook is called after account balances change, with the amount (in cents)
+// each changed account's balance moved by. Like TransactionHook it runs inside the
+// database transaction that changed the balances.
+type BalanceHook func(tx *gorm.DB, changes map[uint]int64) error
+
+var balanceHooks = make(map[string]BalanceHook)
+
+// RegisterBalanceHook registers a balance hook under name, replacing any hook
+// previously registered with the same name. Hooks run in name order.
+func RegisterBalanceHook(name string, hook BalanceHook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	balanceHooks[name] = hook
+}
+
+// UnregisterBalanceHook removes the balance hook registered under name
+func UnregisterBalanceHook(name string) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	delete(balanceHooks, name)
+}
+
+// runBalanceHooks runs every registered balance hook against the balance changes,
+// ignoring accounts whose balance did not move
+func runBalanceHooks(tx *gorm.DB, changes map[uint]int64) error {
+	moved := make(map[uint]int64, len(changes))
+	for accountID, delta := range changes {
+		if delta != 0 {
+			moved[accountID] = delta
+		}
+	}
+	if len(moved) == 0 {
+		return nil
+	}
+
+	hooksMu.RLock()
+	names := make([]string, 0, len(balanceHooks))
+	hooks := make(map[string]BalanceHook, len(balanceHooks))
+	for name, hook := range balanceHooks {
+		names = append(names, name)
+		hooks[name] = hook
+	}
+	hooksMu.RUnlock()
+
+	sort.Strings(names)
+	for _, name := range names {
+		if err := hooks[name](tx, moved); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package repositories
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupHookTestDB(t *testing.T) (*gorm.DB, *models.Account) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&models.Account{}, &models.Transaction{}))
+
+	account := &models.Account{Name: "Checking", Type: models.AccountTypeChecking, Currency: "USD", InitialBalanceCents: 10000, IsActive: true}
+	assert.NoError(t, NewAccountRepository(db).Create(account))
+	return db, account
+}
+
+func TestTransactionHooks_RunOnCreateBatchAndUpdate(t *testing.T) {
+	db, account := setupHookTestDB(t)
+	repo := NewTransactionRepository(db)
+
+	var seen [][]*models.Transaction
+	RegisterTransactionHook("test_recorder", func(tx *gorm.DB, txs []*models.Transaction) error {
+		seen = append(seen, txs)
+		return nil
+	})
+	defer UnregisterTransactionHook("test_recorder")
+
+	tx := &models.Transaction{AccountID: account.ID, Date: time.Now(), AmountCents: -500, Type: models.TransactionTypeExpense, Tags: models.StringArray{}}
+	assert.NoError(t, repo.Create(tx))
+
+	batch := []*models.Transaction{
+		{AccountID: account.ID, Date: time.Now(), AmountCents: -100, Type: models.TransactionTypeExpense},
+		{AccountID: account.ID, Date: time.Now(), AmountCents: -200, Type: models.TransactionTypeExpense},
+	}
+	assert.NoError(t, repo.CreateBatch(batch, 10))
+
+	tx.AmountCents = -700
+	assert.NoError(t, repo.Update(tx))
+
+	assert.Len(t, seen, 3)
+	assert.Len(t, seen[0], 1)
+	assert.Len(t, seen[1], 2)
+	assert.Equal(t, int64(-700), seen[2][0].AmountCents)
+}
+
+func TestTransactionCreateHooks_SkipUpdate(t *testing.T) {
+	db, account := setupHookTestDB(t)
+	repo := NewTransactionRepository(db)
+
+	var seen [][]*models.Transaction
+	RegisterTransactionCreateHook("test_recorder", func(tx *gorm.DB, txs []*models.Transaction) error {
+		seen = append(seen, txs)
+		return nil
+	})
+	defer UnregisterTransactionCreateHook("test_recorder")
+
+	tx := &models.Transaction{AccountID: account.ID, Date: time.Now(), AmountCents: -500, Type: models.TransactionTypeExpense, Tags: models.StringArray{}}
+	assert.NoError(t, repo.Create(tx))
+
+	batch := []*models.Transaction{
+		{AccountID: account.ID, Date: time.Now(), AmountCents: -100, Type: models.TransactionTypeExpense},
+		{AccountID: account.ID, Date: time.Now(), AmountCents: -200, Type: models.TransactionTypeExpense},
+	}
+	assert.NoError(t, repo.CreateBatch(batch, 10))
+
+	tx.AmountCents = -700
+	assert.NoError(t, repo.Update(tx))
+
+	assert.Len(t, seen, 2)
+	assert.Len(t, seen[0], 1)
+	assert.Len(t, seen[1], 2)
+}
+
+func TestTransactionHooks_ErrorRollsBack(t *testing.T) {
+	db, account := setupHookTestDB(t)
+	repo := NewTransactionRepository(db)
+
+	RegisterTransactionHook("test_failing", func(tx *gorm.DB, txs []*models.Transaction) error {
+		return errors.New("boom")
+	})
+	defer UnregisterTransactionHook("test_failing")
+
+	tx := &models.Transaction{AccountID: account.ID, Date: time.Now(), AmountCents: -500, Type: models.TransactionTypeExpense, Tags: models.StringArray{}}
+	err := repo.Create(tx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "test_failing")
+
+	var count int64
+	db.Model(&models.Transaction{}).Count(&count)
+	assert.Equal(t, int64(0), count)
+
+	reloaded, err := NewAccountRepository(db).GetByID(account.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10000), reloaded.CurrentBalanceCents)
+}
+
+func TestBalanceHooks_ReportBalanceChanges(t *testing.T) {
+	db, account := setupHookTestDB(t)
+	repo := NewTransactionRepository(db)
+
+	savings := &models.Account{Name: "Savings", Type: models.AccountTypeSavings, Currency: "USD", IsActive: true}
+	assert.NoError(t, NewAccountRepository(db).Create(savings))
+
+	var seen []map[uint]int64
+	RegisterBalanceHook("test_recorder", func(tx *gorm.DB, changes map[uint]int64) error {
+		seen = append(seen, changes)
+		return nil
+	})
+	defer UnregisterBalanceHook("test_recorder")
+
+	tx := &models.Transaction{AccountID: account.ID, Date: time.Now(), AmountCents: -500, Type: models.TransactionTypeExpense, Tags: models.StringArray{}}
+	assert.NoError(t, repo.Create(tx))
+
+	batch := []*models.Transaction{
+		{AccountID: account.ID, Date: time.Now(), AmountCents: -100, Type: models.TransactionTypeExpense},
+		{AccountID: account.ID, Date: time.Now(), AmountCents: -200, Type: models.TransactionTypeExpense},
+	}
+	assert.NoError(t, repo.CreateBatch(batch, 10))
+
+	// Description-only updates don't move a balance
+	tx.Description = "Coffee"
+	assert.NoError(t, repo.Update(tx))
+
+	tx.AccountID = savings.ID
+	tx.AmountCents = -700
+	assert.NoError(t, repo.Update(tx))
+
+	assert.NoError(t, repo.Delete(tx.ID))
+	assert.NoError(t, NewAccountRepository(db).UpdateBalance(account.ID, 20000))
+
+	assert.Equal(t, []map[uint]int64{
+		{account.ID: -500},
+		{account.ID: -300},
+		{account.ID: 500, savings.ID: -700},
+		{savings.ID: 700},
+		{account.ID: 20000 - 9700},
+	}, seen)
+}
+
+func TestBalanceHooks_ErrorRollsBack(t *testing.T) {
+	db, account := setupHookTestDB(t)
+
+	RegisterBalanceHook("test_failing", func(tx *gorm.DB, changes map[uint]int64) error {
+		return errors.New("boom")
+	})
+	defer UnregisterBalanceHook("test_failing")
+
+	tx := &models.Transaction{AccountID: account.ID, Date: time.Now(), AmountCents: -500, Type: models.TransactionTypeExpense, Tags: models.StringArray{}}
+	err := NewTransactionRepository(db).Create(tx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "test_failing")
+
+	reloaded, err := NewAccountRepository(db).GetByID(account.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10000), reloaded.CurrentBalanceCents)
+}
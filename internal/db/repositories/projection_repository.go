@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"github.com/fintrack/fintrack/internal/models"
+	"gorm.io/gorm"
+)
+
+// ProjectionRepository handles cash flow projection data operations
+type ProjectionRepository struct {
+	db *gorm.DB
+}
+
+// NewProjectionRepository creates a new projection repository
+func NewProjectionRepository(db *gorm.DB) *ProjectionRepository {
+	return &ProjectionRepository{db: db}
+}
+
+// Replace stores a projection, replacing any earlier rows of the same type for
+// the same account. A nil account ID is the projection across all accounts.
+func (r *ProjectionRepository) Replace(accountID *uint, projectionType string, rows []*models.CashFlowProjection) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := projectionScope(tx, accountID, projectionType).Delete(&models.CashFlowProjection{}).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(rows, 100).Error
+	})
+}
+
+// List retrieves the stored projection of a type for an account (nil for all
+// accounts), ordered by date
+func (r *ProjectionRepository) List(accountID *uint, projectionType string) ([]*models.CashFlowProjection, error) {
+	var rows []*models.CashFlowProjection
+	err := projectionScope(r.db, accountID, projectionType).
+		Order("projection_date asc").
+		Find(&rows).Error
+	return rows, err
+}
+
+// projectionScope restricts a query to one account's projection of a type
+func projectionScope(db *gorm.DB, accountID *uint, projectionType string) *gorm.DB {
+	query := db.Where("projection_type = ?", projectionType)
+	if accountID == nil {
+		return query.Where("account_id IS NULL")
+	}
+	return query.Where("account_id = ?", *accountID)
+}
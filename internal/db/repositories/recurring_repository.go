@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/models"
+	"gorm.io/gorm"
+)
+
+// RecurringRepository handles recurring item data operations
+type RecurringRepository struct {
+	db *gorm.DB
+}
+
+// NewRecurringRepository creates a new recurring item repository
+func NewRecurringRepository(db *gorm.DB) *RecurringRepository {
+	return &RecurringRepository{db: db}
+}
+
+// Create creates a new recurring item
+func (r *RecurringRepository) Create(item *models.RecurringItem) error {
+	return r.db.Create(item).Error
+}
+
+// GetByID retrieves a recurring item by ID with its account and category
+func (r *RecurringRepository) GetByID(id uint) (*models.RecurringItem, error) {
+	var item models.RecurringItem
+	err := r.db.Preload("Account").Preload("Category").First(&item, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("recurring item not found")
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+// GetByName retrieves a recurring item by name, preferring active items
+func (r *RecurringRepository) GetByName(name string) (*models.RecurringItem, error) {
+	var item models.RecurringItem
+	err := r.db.Preload("Account").Preload("Category").
+		Where("name = ?", name).
+		Order("is_active desc, id").
+		First(&item).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("recurring item not found")
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+// List retrieves all recurring items ordered by next date, optionally only active ones
+func (r *RecurringRepository) List(activeOnly bool) ([]*models.RecurringItem, error) {
+	var items []*models.RecurringItem
+	query := r.db.Preload("Account").Preload("Category")
+
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	err := query.Order("next_date, name").Find(&items).Error
+	return items, err
+}
+
+// ListByAccount retrieves recurring items for a specific account
+func (r *RecurringRepository) ListByAccount(accountID uint, activeOnly bool) ([]*models.RecurringItem, error) {
+	var items []*models.RecurringItem
+	query := r.db.Preload("Account").Preload("Category").Where("account_id = ?", accountID)
+
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	err := query.Order("next_date, name").Find(&items).Error
+	return items, err
+}
+
+// ListDue retrieves active recurring items whose next date is on or before the given day
+func (r *RecurringRepository) ListDue(through time.Time) ([]*models.RecurringItem, error) {
+	var items []*models.RecurringItem
+	err := r.db.Preload("Account").Preload("Category").
+		Where("is_active = ? AND next_date < ?", true, startOfDay(through).AddDate(0, 0, 1)).
+		Order("next_date, name").
+		Find(&items).Error
+	return items, err
+}
+
+// Update updates a recurring item
+func (r *RecurringRepository) Update(item *models.RecurringItem) error {
+	return r.db.Omit("Account", "Category").Save(item).Error
+}
+
+// SetActive pauses or resumes a recurring item
+func (r *RecurringRepository) SetActive(id uint, active bool) error {
+	result := r.db.Model(&models.RecurringItem{}).Where("id = ?", id).Update("is_active", active)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("recurring item not found")
+	}
+	return nil
+}
+
+// Delete deletes a recurring item
+func (r *RecurringRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.RecurringItem{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("recurring item not found")
+	}
+	return nil
+}
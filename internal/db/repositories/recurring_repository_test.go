@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// RecurringRepositoryTestSuite is the test suite for recurring item repository
+type RecurringRepositoryTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	repo    *RecurringRepository
+	account *models.Account
+}
+
+// SetupSuite runs once before all tests
+func (suite *RecurringRepositoryTestSuite) SetupSuite() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(suite.T(), err)
+
+	suite.db = db
+	suite.repo = NewRecurringRepository(db)
+}
+
+// SetupTest runs before each test
+func (suite *RecurringRepositoryTestSuite) SetupTest() {
+	_ = suite.db.Migrator().DropTable(&models.RecurringItem{}, &models.Category{}, &models.Account{})
+	_ = suite.db.AutoMigrate(&models.Account{}, &models.Category{}, &models.RecurringItem{})
+
+	suite.account = &models.Account{Name: "Checking", Type: models.AccountTypeChecking, Currency: "USD", IsActive: true}
+	assert.NoError(suite.T(), suite.db.Create(suite.account).Error)
+}
+
+func (suite *RecurringRepositoryTestSuite) newItem(name string, next time.Time) *models.RecurringItem {
+	return &models.RecurringItem{
+		AccountID:         suite.account.ID,
+		Name:              name,
+		AmountCents:       -150000,
+		Frequency:         models.FrequencyMonthly,
+		FrequencyInterval: 1,
+		StartDate:         next,
+		NextDate:          next,
+		IsActive:          true,
+	}
+}
+
+func (suite *RecurringRepositoryTestSuite) TestCreateAndGet() {
+	item := suite.newItem("Rent", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(suite.T(), suite.repo.Create(item))
+	assert.NotZero(suite.T(), item.ID)
+
+	byID, err := suite.repo.GetByID(item.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Rent", byID.Name)
+	assert.NotNil(suite.T(), byID.Account)
+	assert.Equal(suite.T(), "Checking", byID.Account.Name)
+
+	byName, err := suite.repo.GetByName("Rent")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), item.ID, byName.ID)
+
+	_, err = suite.repo.GetByID(999)
+	assert.EqualError(suite.T(), err, "recurring item not found")
+}
+
+func (suite *RecurringRepositoryTestSuite) TestListAndPause() {
+	rent := suite.newItem("Rent", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC))
+	gym := suite.newItem("Gym", time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC))
+	assert.NoError(suite.T(), suite.repo.Create(rent))
+	assert.NoError(suite.T(), suite.repo.Create(gym))
+
+	items, err := suite.repo.List(true)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), items, 2)
+	assert.Equal(suite.T(), "Gym", items[0].Name, "ordered by next date")
+
+	assert.NoError(suite.T(), suite.repo.SetActive(gym.ID, false))
+
+	items, err = suite.repo.List(true)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), items, 1)
+
+	items, err = suite.repo.List(false)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), items, 2)
+
+	assert.Error(suite.T(), suite.repo.SetActive(999, true))
+}
+
+func (suite *RecurringRepositoryTestSuite) TestListDue() {
+	assert.NoError(suite.T(), suite.repo.Create(suite.newItem("Due", time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC))))
+	assert.NoError(suite.T(), suite.repo.Create(suite.newItem("Later", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC))))
+
+	items, err := suite.repo.ListDue(time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC))
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), items, 1)
+	assert.Equal(suite.T(), "Due", items[0].Name)
+}
+
+func (suite *RecurringRepositoryTestSuite) TestUpdateAndDelete() {
+	item := suite.newItem("Rent", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(suite.T(), suite.repo.Create(item))
+
+	loaded, err := suite.repo.GetByID(item.ID)
+	assert.NoError(suite.T(), err)
+	day := 5
+	loaded.DayOfMonth = &day
+	loaded.AmountCents = -155000
+	assert.NoError(suite.T(), suite.repo.Update(loaded))
+
+	reloaded, err := suite.repo.GetByID(item.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(-155000), reloaded.AmountCents)
+	assert.Equal(suite.T(), 5, *reloaded.DayOfMonth)
+
+	assert.NoError(suite.T(), suite.repo.Delete(item.ID))
+	assert.EqualError(suite.T(), suite.repo.Delete(item.ID), "recurring item not found")
+}
+
+func TestRecurringRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RecurringRepositoryTestSuite))
+}
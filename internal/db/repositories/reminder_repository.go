@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/models"
+	"gorm.io/gorm"
+)
+
+// ReminderRepository handles reminder data operations
+type ReminderRepository struct {
+	db *gorm.DB
+}
+
+// NewReminderRepository creates a new reminder repository
+func NewReminderRepository(db *gorm.DB) *ReminderRepository {
+	return &ReminderRepository{db: db}
+}
+
+// Create creates a new reminder
+func (r *ReminderRepository) Create(reminder *models.Reminder) error {
+	return r.db.Create(reminder).Error
+}
+
+// GetByID retrieves a reminder by ID
+func (r *ReminderRepository) GetByID(id uint) (*models.Reminder, error) {
+	var reminder models.Reminder
+	err := r.db.First(&reminder, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("reminder not found")
+		}
+		return nil, err
+	}
+	return &reminder, nil
+}
+
+// ListByRelated retrieves all reminders of a type for a related entity,
+// including dismissed ones
+func (r *ReminderRepository) ListByRelated(reminderType string, relatedID uint) ([]*models.Reminder, error) {
+	var reminders []*models.Reminder
+	err := r.db.Where("type = ? AND related_id = ?", reminderType, relatedID).
+		Order("remind_date, id").
+		Find(&reminders).Error
+	return reminders, err
+}
+
+// ReminderFilter holds filter options for listing reminders
+type ReminderFilter struct {
+	Type             string
+	From             *time.Time // Only reminders due on or after this day
+	Through          *time.Time // Only reminders due on or before this day
+	Before           *time.Time // Only reminders due before this day
+	IncludeDismissed bool
+}
+
+// List retrieves reminders matching the filter, ordered by remind date
+func (r *ReminderRepository) List(filter ReminderFilter) ([]*models.Reminder, error) {
+	var reminders []*models.Reminder
+	query := r.db
+
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.From != nil {
+		query = query.Where("remind_date >= ?", startOfDay(*filter.From))
+	}
+	if filter.Through != nil {
+		query = query.Where("remind_date < ?", startOfDay(*filter.Through).AddDate(0, 0, 1))
+	}
+	if filter.Before != nil {
+		query = query.Where("remind_date < ?", startOfDay(*filter.Before))
+	}
+	if !filter.IncludeDismissed {
+		query = query.Where("is_dismissed = ?", false)
+	}
+
+	err := query.Order("remind_date, id").Find(&reminders).Error
+	return reminders, err
+}
+
+// Dismiss marks a reminder as dismissed
+func (r *ReminderRepository) Dismiss(id uint) error {
+	now := time.Now()
+	result := r.db.Model(&models.Reminder{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"is_dismissed": true,
+			"dismissed_at": now,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("reminder not found")
+	}
+	return nil
+}
+
+// Snooze moves a reminder to a new day and restores it if it was dismissed
+func (r *ReminderRepository) Snooze(id uint, until time.Time) error {
+	result := r.db.Model(&models.Reminder{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"remind_date":  startOfDay(until),
+			"is_dismissed": false,
+			"dismissed_at": nil,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("reminder not found")
+	}
+	return nil
+}
+
+// Update updates a reminder
+func (r *ReminderRepository) Update(reminder *models.Reminder) error {
+	return r.db.Save(reminder).Error
+}
+
+// DismissBefore dismisses every undismissed reminder due before the given day
+// and returns how many were dismissed
+func (r *ReminderRepository) DismissBefore(day time.Time) (int64, error) {
+	result := r.db.Model(&models.Reminder{}).
+		Where("is_dismissed = ? AND remind_date < ?", false, startOfDay(day)).
+		Updates(map[string]interface{}{
+			"is_dismissed": true,
+			"dismissed_at": time.Now(),
+		})
+	return result.RowsAffected, result.Error
+}
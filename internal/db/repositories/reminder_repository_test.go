@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// ReminderRepositoryTestSuite is the test suite for reminder repository
+type ReminderRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo *ReminderRepository
+}
+
+// SetupSuite runs once before all tests
+func (suite *ReminderRepositoryTestSuite) SetupSuite() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(suite.T(), err)
+
+	suite.db = db
+	suite.repo = NewReminderRepository(db)
+}
+
+// SetupTest runs before each test
+func (suite *ReminderRepositoryTestSuite) SetupTest() {
+	_ = suite.db.Migrator().DropTable(&models.Reminder{})
+	_ = suite.db.AutoMigrate(&models.Reminder{})
+}
+
+func (suite *ReminderRepositoryTestSuite) create(title string, date time.Time, reminderType string) *models.Reminder {
+	reminder := &models.Reminder{
+		Type:       reminderType,
+		Title:      title,
+		RemindDate: date,
+		Priority:   models.ReminderPriorityNormal,
+	}
+	assert.NoError(suite.T(), suite.repo.Create(reminder))
+	return reminder
+}
+
+func (suite *ReminderRepositoryTestSuite) TestList() {
+	suite.create("Pay rent", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), models.ReminderTypeBill)
+	suite.create("Call bank", time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC), models.ReminderTypeCustom)
+	today := suite.create("Renew card", time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), models.ReminderTypeCustom)
+
+	all, err := suite.repo.List(ReminderFilter{})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), all, 3)
+	assert.Equal(suite.T(), "Call bank", all[0].Title)
+
+	day := time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC)
+	due, err := suite.repo.List(ReminderFilter{Through: &day})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), due, 2)
+
+	overdue, err := suite.repo.List(ReminderFilter{Before: &day})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), overdue, 1)
+
+	upcoming, err := suite.repo.List(ReminderFilter{From: &day})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), upcoming, 2)
+	assert.Equal(suite.T(), "Renew card", upcoming[0].Title)
+
+	bills, err := suite.repo.List(ReminderFilter{Type: models.ReminderTypeBill})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), bills, 1)
+
+	assert.NoError(suite.T(), suite.repo.Dismiss(today.ID))
+	due, err = suite.repo.List(ReminderFilter{Through: &day})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), due, 1)
+
+	all, err = suite.repo.List(ReminderFilter{IncludeDismissed: true})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), all, 3)
+}
+
+func (suite *ReminderRepositoryTestSuite) TestDismiss() {
+	reminder := suite.create("Pay rent", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), models.ReminderTypeBill)
+
+	assert.NoError(suite.T(), suite.repo.Dismiss(reminder.ID))
+
+	dismissed, err := suite.repo.GetByID(reminder.ID)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), dismissed.IsDismissed)
+	assert.NotNil(suite.T(), dismissed.DismissedAt)
+
+	assert.EqualError(suite.T(), suite.repo.Dismiss(9999), "reminder not found")
+}
+
+func (suite *ReminderRepositoryTestSuite) TestSnooze() {
+	reminder := suite.create("Pay rent", time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), models.ReminderTypeBill)
+	assert.NoError(suite.T(), suite.repo.Dismiss(reminder.ID))
+
+	assert.NoError(suite.T(), suite.repo.Snooze(reminder.ID, time.Date(2026, 10, 20, 18, 30, 0, 0, time.UTC)))
+
+	snoozed, err := suite.repo.GetByID(reminder.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "2026-10-20", snoozed.RemindDate.Format("2006-01-02"))
+	assert.False(suite.T(), snoozed.IsDismissed)
+	assert.Nil(suite.T(), snoozed.DismissedAt)
+
+	assert.EqualError(suite.T(), suite.repo.Snooze(9999, time.Now()), "reminder not found")
+}
+
+func (suite *ReminderRepositoryTestSuite) TestDismissBefore() {
+	suite.create("Old", time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), models.ReminderTypeBill)
+	suite.create("Recent", time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC), models.ReminderTypeCustom)
+	dismissed := suite.create("Already dismissed", time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC), models.ReminderTypeCustom)
+	assert.NoError(suite.T(), suite.repo.Dismiss(dismissed.ID))
+
+	n, err := suite.repo.DismissBefore(time.Date(2026, 9, 16, 0, 0, 0, 0, time.UTC))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), n)
+
+	open, err := suite.repo.List(ReminderFilter{})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), open, 1)
+	assert.Equal(suite.T(), "Recent", open[0].Title)
+}
+
+func TestReminderRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(ReminderRepositoryTestSuite))
+}
@@ -32,13 +32,20 @@ func NewTransactionRepository(db *gorm.DB) *TransactionRepository {
 	return &TransactionRepository{db: db}
 }
 
-// Create creates a new transaction and updates the account balance
+// Create creates a new transaction, updates the account balance and runs the
+// registered transaction and balance hooks
 func (r *TransactionRepository) Create(tx *models.Transaction) error {
 	return r.db.Transaction(func(dbTx *gorm.DB) error {
 		if err := dbTx.Create(tx).Error; err != nil {
 			return err
 		}
-		return r.updateAccountBalance(dbTx, tx.AccountID, tx.AmountCents)
+		if err := r.updateAccountBalance(dbTx, tx.AccountID, tx.AmountCents); err != nil {
+			return err
+		}
+		if err := runCreateHooks(dbTx, []*models.Transaction{tx}); err != nil {
+			return err
+		}
+		return runBalanceHooks(dbTx, map[uint]int64{tx.AccountID: tx.AmountCents})
 	})
 }
 
@@ -60,7 +67,10 @@ func (r *TransactionRepository) CreateBatch(txs []*models.Transaction, batchSize
 				return err
 			}
 		}
-		return nil
+		if err := runCreateHooks(dbTx, txs); err != nil {
+			return err
+		}
+		return runBalanceHooks(dbTx, accountTotals)
 	})
 }
 
@@ -140,20 +150,33 @@ func (r *TransactionRepository) Update(tx *models.Transaction) error {
 		if err := dbTx.Save(tx).Error; err != nil {
 			return err
 		}
+		changes := make(map[uint]int64)
 		if balanceDiff != 0 {
 			if original.AccountID != tx.AccountID {
 				if err := r.updateAccountBalance(dbTx, original.AccountID, -original.AmountCents); err != nil {
 					return err
 				}
-				return r.updateAccountBalance(dbTx, tx.AccountID, tx.AmountCents)
+				if err := r.updateAccountBalance(dbTx, tx.AccountID, tx.AmountCents); err != nil {
+					return err
+				}
+				changes[original.AccountID] = -original.AmountCents
+				changes[tx.AccountID] = tx.AmountCents
+			} else {
+				if err := r.updateAccountBalance(dbTx, tx.AccountID, balanceDiff); err != nil {
+					return err
+				}
+				changes[tx.AccountID] = balanceDiff
 			}
-			return r.updateAccountBalance(dbTx, tx.AccountID, balanceDiff)
 		}
-		return nil
+		if err := runTransactionHooks(dbTx, []*models.Transaction{tx}); err != nil {
+			return err
+		}
+		return runBalanceHooks(dbTx, changes)
 	})
 }
 
-// Delete deletes a transaction and adjusts the account balance
+// Delete deletes a transaction, adjusts the account balance and runs the
+// registered balance hooks
 func (r *TransactionRepository) Delete(id uint) error {
 	return r.db.Transaction(func(dbTx *gorm.DB) error {
 		var tx models.Transaction
@@ -166,7 +189,10 @@ func (r *TransactionRepository) Delete(id uint) error {
 		if err := dbTx.Delete(&models.Transaction{}, id).Error; err != nil {
 			return err
 		}
-		return r.updateAccountBalance(dbTx, tx.AccountID, -tx.AmountCents)
+		if err := r.updateAccountBalance(dbTx, tx.AccountID, -tx.AmountCents); err != nil {
+			return err
+		}
+		return runBalanceHooks(dbTx, map[uint]int64{tx.AccountID: -tx.AmountCents})
 	})
 }
 
@@ -195,6 +221,127 @@ func (r *TransactionRepository) GetTotalByCategory(categoryID uint, from, to *ti
 	return total, err
 }
 
+// GetSpendingInPeriod calculates total expense spending (in positive cents) between
+// from and to, both inclusive calendar days. A nil categoryIDs covers all categories.
+func (r *TransactionRepository) GetSpendingInPeriod(categoryIDs []uint, from, to time.Time) (int64, error) {
+	var total int64
+	query := r.spendingQuery(from, to)
+	if categoryIDs != nil {
+		query = query.Where("category_id IN ?", categoryIDs)
+	}
+	err := query.Select("COALESCE(SUM(amount), 0)").Scan(&total).Error
+	return -total, err
+}
+
+// GetSpendingByCategory calculates expense spending (in positive cents) per category
+// between from and to, both inclusive calendar days
+func (r *TransactionRepository) GetSpendingByCategory(categoryIDs []uint, from, to time.Time) (map[uint]int64, error) {
+	var rows []struct {
+		CategoryID uint
+		Total      int64
+	}
+	err := r.spendingQuery(from, to).
+		Where("category_id IN ?", categoryIDs).
+		Select("category_id, COALESCE(SUM(amount), 0) AS total").
+		Group("category_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		totals[row.CategoryID] = -row.Total
+	}
+	return totals, nil
+}
+
+// GetActivityByCategory sums transaction amounts (signed cents) per category for the
+// given accounts between from and to, both inclusive calendar days. A nil from
+// includes all history up to to. Uncategorized transactions are omitted.
+func (r *TransactionRepository) GetActivityByCategory(accountIDs []uint, from *time.Time, to time.Time) (map[uint]int64, error) {
+	var rows []struct {
+		CategoryID uint
+		Total      int64
+	}
+	query := r.db.Model(&models.Transaction{}).
+		Where("account_id IN ? AND category_id IS NOT NULL", accountIDs).
+		Where("date < ?", startOfDay(to).AddDate(0, 0, 1))
+	if from != nil {
+		query = query.Where("date >= ?", startOfDay(*from))
+	}
+	err := query.Select("category_id, COALESCE(SUM(amount), 0) AS total").
+		Group("category_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		totals[row.CategoryID] = row.Total
+	}
+	return totals, nil
+}
+
+// CategoryTotal is the sum and count of one type of transaction in one category.
+// A nil CategoryID is uncategorized transactions.
+type CategoryTotal struct {
+	CategoryID *uint
+	Type       string
+	TotalCents int64 // Signed cents
+	Count      int64
+}
+
+// GetTotalsByCategory sums income and expense transactions (signed cents) and
+// counts them per category and type between from and to, both inclusive calendar
+// days. A nil accountID covers all accounts. Transfers are omitted.
+func (r *TransactionRepository) GetTotalsByCategory(accountID *uint, from, to time.Time) ([]CategoryTotal, error) {
+	var totals []CategoryTotal
+	query := r.db.Model(&models.Transaction{}).
+		Where("type IN ?", []string{models.TransactionTypeIncome, models.TransactionTypeExpense}).
+		Where("date >= ? AND date < ?", startOfDay(from), startOfDay(to).AddDate(0, 0, 1))
+	if accountID != nil {
+		query = query.Where("account_id = ?", *accountID)
+	}
+	err := query.Select("category_id, type, COALESCE(SUM(amount), 0) AS total_cents, COUNT(*) AS count").
+		Group("category_id, type").
+		Scan(&totals).Error
+	return totals, err
+}
+
+// GetActivityByAccount sums transaction amounts (signed cents) per account. A nil
+// to includes all transactions; otherwise transactions up to and including that day.
+func (r *TransactionRepository) GetActivityByAccount(accountIDs []uint, to *time.Time) (map[uint]int64, error) {
+	var rows []struct {
+		AccountID uint
+		Total     int64
+	}
+	query := r.db.Model(&models.Transaction{}).Where("account_id IN ?", accountIDs)
+	if to != nil {
+		query = query.Where("date < ?", startOfDay(*to).AddDate(0, 0, 1))
+	}
+	err := query.Select("account_id, COALESCE(SUM(amount), 0) AS total").
+		Group("account_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		totals[row.AccountID] = row.Total
+	}
+	return totals, nil
+}
+
+// spendingQuery scopes a query to expense transactions within an inclusive date range
+func (r *TransactionRepository) spendingQuery(from, to time.Time) *gorm.DB {
+	return r.db.Model(&models.Transaction{}).
+		Where("type = ?", models.TransactionTypeExpense).
+		Where("date >= ? AND date < ?", startOfDay(from), startOfDay(to).AddDate(0, 0, 1))
+}
+
 // Reconcile marks a transaction as reconciled
 func (r *TransactionRepository) Reconcile(id uint) error {
 	now := time.Now()
@@ -206,6 +353,80 @@ func (r *TransactionRepository) Reconcile(id uint) error {
 		}).Error
 }
 
+// LinkRecurring links transactions to a recurring item, leaving any that are
+// already linked to another item unchanged
+func (r *TransactionRepository) LinkRecurring(ids []uint, recurringID uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Model(&models.Transaction{}).
+		Where("id IN ? AND recurring_id IS NULL", ids).
+		Update("recurring_id", recurringID).Error
+}
+
+// ListGenerated retrieves transactions generated from the given recurring items
+// between two days (inclusive), excluding imported ones
+func (r *TransactionRepository) ListGenerated(recurringIDs []uint, from, to time.Time) ([]*models.Transaction, error) {
+	var transactions []*models.Transaction
+	if len(recurringIDs) == 0 {
+		return transactions, nil
+	}
+	err := r.db.
+		Where("recurring_id IN ? AND import_id IS NULL AND date >= ? AND date < ?",
+			recurringIDs, startOfDay(from), startOfDay(to).AddDate(0, 0, 1)).
+		Order("date, id").
+		Find(&transactions).Error
+	return transactions, err
+}
+
+// ListUnscheduledExpenses retrieves the account, date and amount of expenses in
+// the given accounts between two days (inclusive) that did not come from a
+// recurring item
+func (r *TransactionRepository) ListUnscheduledExpenses(accountIDs []uint, from, to time.Time) ([]*models.Transaction, error) {
+	var transactions []*models.Transaction
+	if len(accountIDs) == 0 {
+		return transactions, nil
+	}
+	err := r.db.Select("id, account_id, date, amount").
+		Where("account_id IN ? AND type = ? AND recurring_id IS NULL AND date >= ? AND date < ?",
+			accountIDs, models.TransactionTypeExpense, startOfDay(from), startOfDay(to).AddDate(0, 0, 1)).
+		Order("date, id").
+		Find(&transactions).Error
+	return transactions, err
+}
+
+// ListBalanceChanges retrieves the account, date and amount of every transaction
+// in the given accounts, oldest first
+func (r *TransactionRepository) ListBalanceChanges(accountIDs []uint) ([]*models.Transaction, error) {
+	var transactions []*models.Transaction
+	if len(accountIDs) == 0 {
+		return transactions, nil
+	}
+	err := r.db.Select("id, account_id, date, amount").
+		Where("account_id IN ?", accountIDs).
+		Order("date, id").
+		Find(&transactions).Error
+	return transactions, err
+}
+
+// EarliestDate returns the date of the oldest transaction in the given
+// accounts, or nil if they have none
+func (r *TransactionRepository) EarliestDate(accountIDs []uint) (*time.Time, error) {
+	if len(accountIDs) == 0 {
+		return nil, nil
+	}
+	var tx models.Transaction
+	err := r.db.Select("id, date").
+		Where("account_id IN ?", accountIDs).
+		Order("date asc").
+		Limit(1).
+		Find(&tx).Error
+	if err != nil || tx.ID == 0 {
+		return nil, err
+	}
+	return &tx.Date, nil
+}
+
 // Unreconcile marks a transaction as not reconciled
 func (r *TransactionRepository) Unreconcile(id uint) error {
 	return r.db.Model(&models.Transaction{}).
@@ -312,3 +533,8 @@ func (r *TransactionRepository) updateAccountBalance(db *gorm.DB, accountID uint
 		Where("id = ?", accountID).
 		Update("current_balance", gorm.Expr("current_balance + ?", amountCents)).Error
 }
+
+// startOfDay truncates a time to midnight in its own location
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
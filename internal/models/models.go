@@ -108,6 +108,19 @@ type Budget struct {
 	UpdatedAt           time.Time `json:"updated_at"`
 }
 
+// EnvelopeAllocation moves money into or out of a category envelope for a month
+// (zero-based budgeting). Amount stored as cents; negative amounts remove money.
+type EnvelopeAllocation struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Month       time.Time `gorm:"not null;index:idx_envelope_month_category" json:"month"` // First day of the month
+	CategoryID  uint      `gorm:"not null;index:idx_envelope_month_category" json:"category_id"`
+	Category    *Category `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+	AmountCents int64     `gorm:"column:amount;not null" json:"amount_cents"`
+	Kind        string    `gorm:"not null;default:assign" json:"kind"` // assign, move
+	Note        string    `json:"note,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
 // RecurringItem represents a recurring transaction template
 // Amount stored as cents (int64) to avoid floating-point precision issues
 type RecurringItem struct {
@@ -215,6 +228,37 @@ const (
 	FrequencyAnnual    = "annual"
 )
 
+// BudgetPeriod constants
+const (
+	BudgetPeriodWeekly    = "weekly"
+	BudgetPeriodMonthly   = "monthly"
+	BudgetPeriodQuarterly = "quarterly"
+	BudgetPeriodAnnual    = "annual"
+)
+
+// ReminderType constants
+const (
+	ReminderTypeTransaction = "transaction"
+	ReminderTypeBudget      = "budget"
+	ReminderTypeBill        = "bill"
+	ReminderTypeLowBalance  = "low_balance"
+	ReminderTypeCustom      = "custom"
+)
+
+// ReminderPriority constants
+const (
+	ReminderPriorityLow    = "low"
+	ReminderPriorityNormal = "normal"
+	ReminderPriorityHigh   = "high"
+	ReminderPriorityUrgent = "urgent"
+)
+
+// EnvelopeAllocation kind constants
+const (
+	AllocationKindAssign = "assign"
+	AllocationKindMove   = "move"
+)
+
 // DollarsToCents converts a dollar amount (float64) to cents (int64)
 // Uses rounding to handle floating-point precision issues
 func DollarsToCents(dollars float64) int64 {
@@ -257,6 +257,21 @@ func TestFrequency_Constants(t *testing.T) {
 	assert.Equal(t, "annual", FrequencyAnnual)
 }
 
+func TestBudgetPeriod_Constants(t *testing.T) {
+	assert.Equal(t, "weekly", BudgetPeriodWeekly)
+	assert.Equal(t, "monthly", BudgetPeriodMonthly)
+	assert.Equal(t, "quarterly", BudgetPeriodQuarterly)
+	assert.Equal(t, "annual", BudgetPeriodAnnual)
+}
+
+func TestReminder_Constants(t *testing.T) {
+	assert.Equal(t, "budget", ReminderTypeBudget)
+	assert.Equal(t, "bill", ReminderTypeBill)
+	assert.Equal(t, "low_balance", ReminderTypeLowBalance)
+	assert.Equal(t, "normal", ReminderPriorityNormal)
+	assert.Equal(t, "urgent", ReminderPriorityUrgent)
+}
+
 func TestReminder_Structure(t *testing.T) {
 	now := time.Now()
 	relatedID := uint(10)
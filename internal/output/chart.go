@@ -0,0 +1,340 @@
+package output
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultChartWidth is the chart width used when none is configured
+const DefaultChartWidth = 60
+
+// DefaultChartHeight is the number of rows in a line chart when none is given
+const DefaultChartHeight = 8
+
+// minPlotWidth is the narrowest plot area a chart will draw, however narrow the
+// configured width
+const minPlotWidth = 10
+
+// Glyphs used by charts, Unicode and ASCII
+var (
+	unicodeEighths    = []rune("▏▎▍▌▋▊▉")
+	unicodeSparks     = []rune("▁▂▃▄▅▆▇█")
+	asciiSparks       = []rune("_.-=+*#")
+	unicodeStackFills = []rune("█▓▒░")
+	asciiStackFills   = []rune("#=+.")
+)
+
+// Chart renders text charts no wider than Width columns, drawn with Unicode
+// block characters or, when Unicode is false, plain ASCII
+type Chart struct {
+	Width   int
+	Unicode bool
+}
+
+// Bar is one row of a bar chart. Text is printed after the bar, typically the
+// formatted value.
+type Bar struct {
+	Label string
+	Value float64
+	Text  string
+}
+
+// StackedBar is one row of a stacked bar chart, with one value per segment
+type StackedBar struct {
+	Label    string
+	Segments []float64
+	Text     string
+}
+
+// NewChart creates a chart renderer. A width of zero or less uses DefaultChartWidth.
+func NewChart(width int, unicode bool) *Chart {
+	if width <= 0 {
+		width = DefaultChartWidth
+	}
+	return &Chart{Width: width, Unicode: unicode}
+}
+
+// Bars renders a horizontal bar chart, one line per bar, scaled so the largest
+// value fills the plot area. Zero and negative values draw no bar.
+func (c *Chart) Bars(bars []Bar) string {
+	if len(bars) == 0 {
+		return ""
+	}
+
+	labels := make([]string, len(bars))
+	texts := make([]string, len(bars))
+	var max float64
+	for i, bar := range bars {
+		labels[i], texts[i] = bar.Label, bar.Text
+		max = math.Max(max, bar.Value)
+	}
+	labelWidth, textWidth := columnWidth(labels, c.Width/3), columnWidth(texts, c.Width/3)
+	plotWidth := c.plotWidth(labelWidth + textWidth + 2)
+
+	var b strings.Builder
+	for i, bar := range bars {
+		fill := ""
+		if max > 0 && bar.Value > 0 {
+			fill = c.fill(bar.Value / max * float64(plotWidth))
+		}
+		fmt.Fprintf(&b, "%s %s %s", c.pad(labels[i], labelWidth), padRunes(fill, plotWidth), texts[i])
+		b.WriteString("\n")
+	}
+	return trimLines(b.String())
+}
+
+// StackedBars renders a horizontal bar per row made of its segments side by
+// side, each drawn with its own fill, scaled so the largest row total fills the
+// plot area. A legend naming the segments is printed below when names are given.
+func (c *Chart) StackedBars(bars []StackedBar, names []string) string {
+	if len(bars) == 0 {
+		return ""
+	}
+
+	fills := asciiStackFills
+	if c.Unicode {
+		fills = unicodeStackFills
+	}
+
+	labels := make([]string, len(bars))
+	texts := make([]string, len(bars))
+	var max float64
+	for i, bar := range bars {
+		labels[i], texts[i] = bar.Label, bar.Text
+		var total float64
+		for _, segment := range bar.Segments {
+			total += math.Max(segment, 0)
+		}
+		max = math.Max(max, total)
+	}
+	labelWidth, textWidth := columnWidth(labels, c.Width/3), columnWidth(texts, c.Width/3)
+	plotWidth := c.plotWidth(labelWidth + textWidth + 2)
+
+	var b strings.Builder
+	for i, bar := range bars {
+		var fill strings.Builder
+		var cumulative float64
+		drawn := 0
+		for j, segment := range bar.Segments {
+			if max <= 0 || segment <= 0 {
+				continue
+			}
+			// Round the running total, so segments never add up past the plot width
+			cumulative += segment
+			end := int(math.Round(cumulative / max * float64(plotWidth)))
+			fill.WriteString(strings.Repeat(string(fills[j%len(fills)]), end-drawn))
+			drawn = end
+		}
+		fmt.Fprintf(&b, "%s %s %s", c.pad(labels[i], labelWidth), padRunes(fill.String(), plotWidth), texts[i])
+		b.WriteString("\n")
+	}
+
+	if len(names) > 0 {
+		legend := make([]string, len(names))
+		for i, name := range names {
+			legend[i] = fmt.Sprintf("%c %s", fills[i%len(fills)], name)
+		}
+		fmt.Fprintf(&b, "%s %s\n", strings.Repeat(" ", labelWidth), strings.Join(legend, "  "))
+	}
+	return trimLines(b.String())
+}
+
+// Sparkline renders values as a single line of characters whose height follows
+// the value, scaled between the smallest and largest. Series longer than the
+// chart width are averaged down to fit.
+func (c *Chart) Sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	levels := asciiSparks
+	if c.Unicode {
+		levels = unicodeSparks
+	}
+
+	values = shrink(values, c.Width)
+	min, max := valueRange(values)
+
+	var b strings.Builder
+	for _, value := range values {
+		b.WriteRune(levels[scale(value, min, max, len(levels))])
+	}
+	return b.String()
+}
+
+// Line renders values as a line chart height rows tall, stretched or sampled
+// across the plot area, with the largest and smallest values labelled on the
+// axis using label (plain numbers when nil). A height of zero or less uses
+// DefaultChartHeight.
+func (c *Chart) Line(values []float64, height int, label func(float64) string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	if height <= 0 {
+		height = DefaultChartHeight
+	}
+	if label == nil {
+		label = func(v float64) string { return fmt.Sprintf("%.0f", v) }
+	}
+
+	point, connector, axis, tick, corner, rule := '*', '|', '|', '+', '+', "-"
+	if c.Unicode {
+		point, connector, axis, tick, corner, rule = '•', '│', '│', '┤', '└', "─"
+	}
+
+	min, max := valueRange(values)
+	top, bottom := label(max), label(min)
+	labelWidth := columnWidth([]string{top, bottom}, 0)
+	plotWidth := c.plotWidth(labelWidth + 2)
+
+	grid := make([][]rune, height)
+	for row := range grid {
+		grid[row] = []rune(strings.Repeat(" ", plotWidth))
+	}
+	previous := -1
+	for x := 0; x < plotWidth; x++ {
+		row := scale(interpolate(values, x, plotWidth), min, max, height)
+		// Join steps of more than one row with a vertical line
+		if previous >= 0 {
+			for r := previous + 1; r < row; r++ {
+				grid[r][x] = connector
+			}
+			for r := row + 1; r < previous; r++ {
+				grid[r][x] = connector
+			}
+		}
+		grid[row][x] = point
+		previous = row
+	}
+
+	var b strings.Builder
+	for row := height - 1; row >= 0; row-- {
+		text, mark := "", axis
+		switch row {
+		case height - 1:
+			text, mark = top, tick
+		case 0:
+			text, mark = bottom, tick
+		}
+		fmt.Fprintf(&b, "%*s %c%s\n", labelWidth, text, mark, strings.TrimRight(string(grid[row]), " "))
+	}
+	fmt.Fprintf(&b, "%s %c%s\n", strings.Repeat(" ", labelWidth), corner, strings.Repeat(rule, plotWidth))
+	return b.String()
+}
+
+// plotWidth returns the columns left for bars or points after reserved columns
+func (c *Chart) plotWidth(reserved int) int {
+	width := c.Width - reserved
+	if width < minPlotWidth {
+		return minPlotWidth
+	}
+	return width
+}
+
+// fill draws a bar cells wide, using eighth blocks for the fractional part in
+// Unicode and rounding to whole characters in ASCII
+func (c *Chart) fill(cells float64) string {
+	if !c.Unicode {
+		return strings.Repeat("#", int(math.Round(cells)))
+	}
+	full := int(cells)
+	bar := strings.Repeat("█", full)
+	if eighths := int((cells - float64(full)) * 8); eighths > 0 {
+		bar += string(unicodeEighths[eighths-1])
+	}
+	return bar
+}
+
+// pad left-aligns s in width columns, shortening it with an ellipsis if needed
+func (c *Chart) pad(s string, width int) string {
+	if utf8.RuneCountInString(s) > width {
+		ellipsis := "~"
+		if c.Unicode {
+			ellipsis = "…"
+		}
+		s = string([]rune(s)[:width-1]) + ellipsis
+	}
+	return padRunes(s, width)
+}
+
+// padRunes left-aligns s in width columns, counting runes rather than bytes
+func padRunes(s string, width int) string {
+	if n := utf8.RuneCountInString(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
+
+// columnWidth returns the widest of values in runes, capped at limit when it is
+// more than one
+func columnWidth(values []string, limit int) int {
+	width := 0
+	for _, value := range values {
+		if n := utf8.RuneCountInString(value); n > width {
+			width = n
+		}
+	}
+	if limit > 1 && width > limit {
+		return limit
+	}
+	return width
+}
+
+// trimLines removes the trailing spaces left by padding from every line
+func trimLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// valueRange returns the smallest and largest of values
+func valueRange(values []float64) (float64, float64) {
+	min, max := values[0], values[0]
+	for _, value := range values[1:] {
+		min, max = math.Min(min, value), math.Max(max, value)
+	}
+	return min, max
+}
+
+// scale maps value between min and max to one of steps levels, using the middle
+// level when all values are equal
+func scale(value, min, max float64, steps int) int {
+	if max == min {
+		return (steps - 1) / 2
+	}
+	return int(math.Round((value - min) / (max - min) * float64(steps-1)))
+}
+
+// shrink averages values down to at most width values
+func shrink(values []float64, width int) []float64 {
+	if width <= 0 || len(values) <= width {
+		return values
+	}
+	shrunk := make([]float64, width)
+	for i := range shrunk {
+		from, to := i*len(values)/width, (i+1)*len(values)/width
+		var sum float64
+		for _, value := range values[from:to] {
+			sum += value
+		}
+		shrunk[i] = sum / float64(to-from)
+	}
+	return shrunk
+}
+
+// interpolate returns the value at column x of width columns spanning values,
+// linearly interpolated between neighbouring values
+func interpolate(values []float64, x, width int) float64 {
+	if len(values) == 1 || width <= 1 {
+		return values[0]
+	}
+	position := float64(x) * float64(len(values)-1) / float64(width-1)
+	i := int(position)
+	if i >= len(values)-1 {
+		return values[len(values)-1]
+	}
+	return values[i] + (values[i+1]-values[i])*(position-float64(i))
+}
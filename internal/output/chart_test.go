@@ -0,0 +1,110 @@
+package output
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewChart_DefaultWidth(t *testing.T) {
+	assert.Equal(t, DefaultChartWidth, NewChart(0, true).Width)
+	assert.Equal(t, 40, NewChart(40, false).Width)
+}
+
+func TestChart_Bars_ASCII(t *testing.T) {
+	chart := NewChart(30, false)
+	out := chart.Bars([]Bar{
+		{Label: "Rent", Value: 1500, Text: "$1,500"},
+		{Label: "Food", Value: 750, Text: "$750"},
+		{Label: "Refund", Value: -20, Text: "-$20"},
+	})
+
+	// 30 columns less 6 for labels, 6 for values and 2 spaces leaves 16
+	assert.Equal(t, "Rent   ################ $1,500\n"+
+		"Food   ########         $750\n"+
+		"Refund                  -$20\n", out)
+}
+
+func TestChart_Bars_UnicodeEighths(t *testing.T) {
+	chart := NewChart(13, true)
+	out := chart.Bars([]Bar{
+		{Label: "A", Value: 100},
+		{Label: "B", Value: 55},
+	})
+
+	// 10 cells wide: B fills 5.5 cells
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	assert.Equal(t, "A "+strings.Repeat("█", 10), lines[0])
+	assert.Equal(t, "B █████▌", lines[1])
+}
+
+func TestChart_Bars_TruncatesLongLabels(t *testing.T) {
+	chart := NewChart(30, true)
+	out := chart.Bars([]Bar{{Label: "Food > Groceries > Organic", Value: 1}})
+	assert.True(t, strings.HasPrefix(out, "Food > Gr…"), out)
+
+	assert.Empty(t, chart.Bars(nil))
+}
+
+func TestChart_StackedBars(t *testing.T) {
+	chart := NewChart(15, false)
+	out := chart.StackedBars([]StackedBar{
+		{Label: "Jan", Segments: []float64{60, 40}},
+		{Label: "Feb", Segments: []float64{50, 0}},
+	}, []string{"Spent", "Saved"})
+
+	assert.Equal(t, "Jan ######====\n"+
+		"Feb #####\n"+
+		"    # Spent  = Saved\n", out)
+}
+
+func TestChart_Sparkline(t *testing.T) {
+	assert.Equal(t, "▁▅█", NewChart(10, true).Sparkline([]float64{0, 50, 100}))
+	assert.Equal(t, "_=#", NewChart(10, false).Sparkline([]float64{-10, 0, 10}))
+	assert.Equal(t, "▄▄", NewChart(10, true).Sparkline([]float64{5, 5}))
+	assert.Empty(t, NewChart(10, true).Sparkline(nil))
+}
+
+func TestChart_Sparkline_FitsWidth(t *testing.T) {
+	values := make([]float64, 100)
+	for i := range values {
+		values[i] = float64(i)
+	}
+	line := NewChart(20, true).Sparkline(values)
+	assert.Equal(t, 20, utf8.RuneCountInString(line))
+	assert.True(t, strings.HasPrefix(line, "▁"))
+	assert.True(t, strings.HasSuffix(line, "█"))
+}
+
+func TestChart_Line(t *testing.T) {
+	chart := NewChart(14, false)
+	out := chart.Line([]float64{0, 30}, 4, nil)
+
+	// Labels take 2 columns, the axis 2 more, leaving 10 for the plot
+	assert.Equal(t, "30 +        **\n"+
+		"   |     ***\n"+
+		"   |  ***\n"+
+		" 0 +**\n"+
+		"   +----------\n", out)
+}
+
+func TestChart_Line_Unicode(t *testing.T) {
+	chart := NewChart(20, true)
+	out := chart.Line([]float64{100, 100, 100}, 3, func(v float64) string { return FormatCurrency(v, "USD") })
+
+	lines := strings.Split(out, "\n")
+	assert.Equal(t, "$100.00 ┤", lines[0])
+	assert.Equal(t, "        │"+strings.Repeat("•", 11), lines[1])
+	assert.Equal(t, "$100.00 ┤", lines[2])
+	assert.Equal(t, "        └"+strings.Repeat("─", 11), lines[3])
+}
+
+func TestChart_Line_ConnectsSteps(t *testing.T) {
+	out := NewChart(12, false).Line([]float64{0, 0, 0, 0, 0, 0, 0, 0, 0, 10}, 5, nil)
+	lines := strings.Split(out, "\n")
+	assert.Equal(t, "10 +         *", lines[0])
+	assert.Equal(t, "   |         |", lines[1])
+	assert.Equal(t, " 0 +*********", lines[4])
+}
@@ -1,12 +1,14 @@
 package output
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"strings"
 
+	"github.com/fintrack/fintrack/internal/config"
 	"github.com/spf13/cobra"
 )
 
@@ -16,6 +18,7 @@ type Format string
 const (
 	FormatTable Format = "table"
 	FormatJSON  Format = "json"
+	FormatCSV   Format = "csv"
 )
 
 // Response represents a standard JSON response structure
@@ -23,6 +26,20 @@ type Response struct {
 	Status string      `json:"status"` // success, error
 	Data   interface{} `json:"data,omitempty"`
 	Error  string      `json:"error,omitempty"`
+	Meta   *Meta       `json:"meta,omitempty"`
+}
+
+// Meta describes the context a response was produced in
+type Meta struct {
+	Profile string `json:"profile,omitempty"` // Active config profile
+}
+
+// responseMeta returns the metadata for responses, or nil when there is none
+func responseMeta() *Meta {
+	if profile := config.ActiveProfile(); profile != "" {
+		return &Meta{Profile: profile}
+	}
+	return nil
 }
 
 // GetFormat determines the output format from command flags
@@ -31,6 +48,10 @@ func GetFormat(cmd *cobra.Command) Format {
 	if jsonFlag {
 		return FormatJSON
 	}
+	csvFlag, _ := cmd.Flags().GetBool("csv")
+	if csvFlag {
+		return FormatCSV
+	}
 	return FormatTable
 }
 
@@ -42,6 +63,7 @@ func Print(cmd *cobra.Command, data interface{}) error {
 		return PrintJSON(os.Stdout, Response{
 			Status: "success",
 			Data:   data,
+			Meta:   responseMeta(),
 		})
 	}
 
@@ -56,6 +78,18 @@ func PrintJSON(w io.Writer, data interface{}) error {
 	return encoder.Encode(data)
 }
 
+// PrintCSV outputs a header row and data rows as CSV
+func PrintCSV(w io.Writer, headers []string, rows [][]string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(headers); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
 // PrintError outputs an error message
 func PrintError(cmd *cobra.Command, err error) error {
 	format := GetFormat(cmd)
@@ -64,6 +98,7 @@ func PrintError(cmd *cobra.Command, err error) error {
 		return PrintJSON(os.Stdout, Response{
 			Status: "error",
 			Error:  err.Error(),
+			Meta:   responseMeta(),
 		})
 	}
 
@@ -79,6 +114,7 @@ func PrintSuccess(cmd *cobra.Command, message string) error {
 		return PrintJSON(os.Stdout, Response{
 			Status: "success",
 			Data:   map[string]string{"message": message},
+			Meta:   responseMeta(),
 		})
 	}
 
@@ -3,10 +3,14 @@ package output
 import (
 	"bytes"
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/fintrack/fintrack/internal/config"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -28,6 +32,27 @@ func TestGetFormat_Table(t *testing.T) {
 	assert.Equal(t, FormatTable, format)
 }
 
+func TestGetFormat_CSV(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("json", false, "JSON output")
+	cmd.Flags().Bool("csv", false, "CSV output")
+	err := cmd.Flags().Set("csv", "true")
+	assert.NoError(t, err)
+
+	format := GetFormat(cmd)
+	assert.Equal(t, FormatCSV, format)
+}
+
+func TestPrintCSV(t *testing.T) {
+	var buf bytes.Buffer
+	err := PrintCSV(&buf, []string{"category", "amount"}, [][]string{
+		{"Food > Groceries", "412.50"},
+		{"Gifts, misc", "20.00"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "category,amount\nFood > Groceries,412.50\n\"Gifts, misc\",20.00\n", buf.String())
+}
+
 func TestPrintJSON(t *testing.T) {
 	var buf bytes.Buffer
 	data := Response{
@@ -215,6 +240,29 @@ func TestResponse_Error(t *testing.T) {
 	assert.Contains(t, string(data), "test error")
 }
 
+func TestResponse_Meta(t *testing.T) {
+	data, err := json.Marshal(Response{Status: "success"})
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "meta")
+
+	data, err = json.Marshal(Response{Status: "success", Meta: &Meta{Profile: "business"}})
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"meta":{"profile":"business"}`)
+}
+
+func TestResponseMeta_ActiveProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("profiles:\n  business:\n    defaults:\n      account: Business\n"), 0644))
+
+	viper.Reset()
+	assert.NoError(t, config.Init(path, ""))
+	assert.Nil(t, responseMeta())
+
+	viper.Reset()
+	assert.NoError(t, config.Init(path, "business"))
+	assert.Equal(t, &Meta{Profile: "business"}, responseMeta())
+}
+
 func TestFormatCurrencyCents_WithThousandSeparators(t *testing.T) {
 	tests := []struct {
 		cents    int64
@@ -0,0 +1,262 @@
+// Package recurrence calculates the dates on which a recurring item occurs.
+//
+// It is pure date arithmetic with no database access, shared by schedule
+// generation, reminders, the calendar and cash flow projection. All dates are
+// calendar days: times of day and locations are dropped and results are
+// midnight UTC.
+package recurrence
+
+import (
+	"time"
+
+	"github.com/fintrack/fintrack/internal/models"
+)
+
+// Adjustment moves occurrences that fall on a weekend to a business day
+type Adjustment int
+
+const (
+	// AdjustNone keeps occurrences on weekends
+	AdjustNone Adjustment = iota
+	// AdjustFollowing moves weekend occurrences to the next Monday
+	AdjustFollowing
+	// AdjustPreceding moves weekend occurrences to the previous Friday
+	AdjustPreceding
+	// AdjustModifiedFollowing moves weekend occurrences to the next Monday unless
+	// that is in the next month, in which case they move to the previous Friday
+	AdjustModifiedFollowing
+)
+
+// maxAdjustDays is the furthest a business-day adjustment can move a date
+const maxAdjustDays = 2
+
+// Rule describes when a recurring item occurs.
+//
+// Daily, weekly and biweekly rules step a fixed number of days from the first
+// occurrence. Weekly and biweekly rules with DayOfWeek start on the first such
+// weekday on or after Start. Monthly, quarterly and annual rules fall on
+// DayOfMonth (default: Start's day); a day past the end of a shorter month
+// lands on that month's last day without shifting later occurrences, so the
+// 31st gives Jan 31, Feb 28, Mar 31.
+type Rule struct {
+	Frequency  string     // One of the models.Frequency* constants
+	Interval   int        // Repeat every N periods; values below 1 mean 1
+	DayOfMonth *int       // 1-31, monthly, quarterly and annual rules only
+	DayOfWeek  *int       // 0 (Sunday) to 6, weekly and biweekly rules only
+	Start      time.Time  // No occurrence falls before this day
+	End        *time.Time // Occurrences whose unadjusted date is after this day are dropped
+	Adjust     Adjustment // Optional business-day adjustment
+}
+
+// FromItem builds the rule for a recurring item
+func FromItem(item *models.RecurringItem) Rule {
+	return Rule{
+		Frequency:  item.Frequency,
+		Interval:   item.FrequencyInterval,
+		DayOfMonth: item.DayOfMonth,
+		DayOfWeek:  item.DayOfWeek,
+		Start:      item.StartDate,
+		End:        item.EndDate,
+	}
+}
+
+// First returns the first occurrence of the rule, or false if it has none
+func (r Rule) First() (time.Time, bool) {
+	return r.OnOrAfter(r.Start)
+}
+
+// OnOrAfter returns the first occurrence on or after date, or false if the rule
+// has ended by then
+func (r Rule) OnOrAfter(date time.Time) (time.Time, bool) {
+	date = Day(date)
+	for k := r.indexNear(date); ; k++ {
+		nominal, ok := r.nominal(k)
+		if !ok {
+			return time.Time{}, false
+		}
+		if d := r.adjust(nominal); !d.Before(date) {
+			return d, true
+		}
+	}
+}
+
+// Next returns the first occurrence strictly after date, or false if the rule
+// has ended by then
+func (r Rule) Next(date time.Time) (time.Time, bool) {
+	return r.OnOrAfter(Day(date).AddDate(0, 0, 1))
+}
+
+// Occurrences lists every occurrence between from and to, both inclusive
+func (r Rule) Occurrences(from, to time.Time) []time.Time {
+	from, to = Day(from), Day(to)
+
+	var dates []time.Time
+	for k := r.indexNear(from); ; k++ {
+		nominal, ok := r.nominal(k)
+		if !ok || nominal.After(to.AddDate(0, 0, maxAdjustDays)) {
+			break
+		}
+		d := r.adjust(nominal)
+		if d.Before(from) || d.After(to) {
+			continue
+		}
+		// Adjusting daily rules can map a weekend onto the same Monday or Friday
+		if n := len(dates); n > 0 && !d.After(dates[n-1]) {
+			continue
+		}
+		dates = append(dates, d)
+	}
+	return dates
+}
+
+// nominal returns the unadjusted k-th occurrence (k >= 0), or false once it is
+// past the end date
+func (r Rule) nominal(k int) (time.Time, bool) {
+	var d time.Time
+	if days := r.stepDays(); days > 0 {
+		d = r.anchor().AddDate(0, 0, k*days)
+	} else {
+		months := r.stepMonths()
+		base, offset := r.monthBase()
+		d = r.monthOccurrence(base, (k+offset)*months)
+	}
+
+	if r.End != nil && d.After(Day(*r.End)) {
+		return time.Time{}, false
+	}
+	return d, true
+}
+
+// indexNear returns an occurrence index at or just before the first one that
+// could fall on or after date, so callers only step forward a few times
+func (r Rule) indexNear(date time.Time) int {
+	var k int
+	if days := r.stepDays(); days > 0 {
+		k = int(date.Sub(r.anchor()).Hours()/24) / days
+	} else {
+		base, offset := r.monthBase()
+		months := (date.Year()-base.Year())*12 + int(date.Month()-base.Month())
+		k = months/r.stepMonths() - offset
+	}
+
+	// Step back far enough that an adjustment can't hide an earlier match
+	k -= maxAdjustDays + 1
+	if k < 0 {
+		return 0
+	}
+	return k
+}
+
+// anchor returns the first unadjusted occurrence of a day-stepped rule
+func (r Rule) anchor() time.Time {
+	start := Day(r.Start)
+	if r.DayOfWeek != nil && r.Frequency != models.FrequencyDaily {
+		offset := (*r.DayOfWeek - int(start.Weekday()) + 7) % 7
+		return start.AddDate(0, 0, offset)
+	}
+	return start
+}
+
+// monthBase returns the first day of Start's month and whether the occurrence in
+// that month falls before Start (offset 1) and must be skipped
+func (r Rule) monthBase() (time.Time, int) {
+	start := Day(r.Start)
+	base := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if r.monthOccurrence(base, 0).Before(start) {
+		return base, 1
+	}
+	return base, 0
+}
+
+// monthOccurrence returns the occurrence in the month that is months after base
+func (r Rule) monthOccurrence(base time.Time, months int) time.Time {
+	month := base.AddDate(0, months, 0)
+
+	day := Day(r.Start).Day()
+	if r.DayOfMonth != nil {
+		day = *r.DayOfMonth
+	}
+	if last := DaysInMonth(month.Year(), month.Month()); day > last {
+		day = last
+	}
+	return time.Date(month.Year(), month.Month(), day, 0, 0, 0, 0, time.UTC)
+}
+
+// stepDays returns the days between occurrences, or 0 for month-based rules
+func (r Rule) stepDays() int {
+	switch r.Frequency {
+	case models.FrequencyDaily:
+		return r.interval()
+	case models.FrequencyWeekly:
+		return 7 * r.interval()
+	case models.FrequencyBiweekly:
+		return 14 * r.interval()
+	}
+	return 0
+}
+
+// stepMonths returns the months between occurrences of a month-based rule.
+// Unknown frequencies are treated as monthly.
+func (r Rule) stepMonths() int {
+	switch r.Frequency {
+	case models.FrequencyQuarterly:
+		return 3 * r.interval()
+	case models.FrequencyAnnual:
+		return 12 * r.interval()
+	}
+	return r.interval()
+}
+
+func (r Rule) interval() int {
+	if r.Interval < 1 {
+		return 1
+	}
+	return r.Interval
+}
+
+// adjust applies the rule's business-day adjustment to a date
+func (r Rule) adjust(d time.Time) time.Time {
+	switch r.Adjust {
+	case AdjustFollowing:
+		return following(d)
+	case AdjustPreceding:
+		return preceding(d)
+	case AdjustModifiedFollowing:
+		if f := following(d); f.Month() == d.Month() {
+			return f
+		}
+		return preceding(d)
+	}
+	return d
+}
+
+func following(d time.Time) time.Time {
+	for IsWeekend(d) {
+		d = d.AddDate(0, 0, 1)
+	}
+	return d
+}
+
+func preceding(d time.Time) time.Time {
+	for IsWeekend(d) {
+		d = d.AddDate(0, 0, -1)
+	}
+	return d
+}
+
+// IsWeekend reports whether d is a Saturday or Sunday
+func IsWeekend(d time.Time) bool {
+	wd := d.Weekday()
+	return wd == time.Saturday || wd == time.Sunday
+}
+
+// DaysInMonth returns the number of days in the given month
+func DaysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// Day truncates t to its calendar day at midnight UTC, keeping the date as
+// written in t's own location
+func Day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,230 @@
+package recurrence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func formatDates(dates []time.Time) []string {
+	out := make([]string, len(dates))
+	for i, d := range dates {
+		out[i] = d.Format("2006-01-02")
+	}
+	return out
+}
+
+func TestOccurrences(t *testing.T) {
+	end := date(2026, 3, 15)
+
+	tests := []struct {
+		name     string
+		rule     Rule
+		from, to time.Time
+		want     []string
+	}{
+		{
+			name: "daily every 3 days",
+			rule: Rule{Frequency: models.FrequencyDaily, Interval: 3, Start: date(2026, 1, 1)},
+			from: date(2026, 1, 1), to: date(2026, 1, 12),
+			want: []string{"2026-01-01", "2026-01-04", "2026-01-07", "2026-01-10"},
+		},
+		{
+			name: "weekly anchored on Friday",
+			rule: Rule{Frequency: models.FrequencyWeekly, Interval: 1, DayOfWeek: intPtr(5), Start: date(2026, 10, 14)},
+			from: date(2026, 10, 1), to: date(2026, 10, 31),
+			want: []string{"2026-10-16", "2026-10-23", "2026-10-30"},
+		},
+		{
+			name: "weekly without weekday uses start",
+			rule: Rule{Frequency: models.FrequencyWeekly, Interval: 2, Start: date(2026, 10, 14)},
+			from: date(2026, 10, 1), to: date(2026, 11, 30),
+			want: []string{"2026-10-14", "2026-10-28", "2026-11-11", "2026-11-25"},
+		},
+		{
+			name: "biweekly",
+			rule: Rule{Frequency: models.FrequencyBiweekly, Interval: 1, DayOfWeek: intPtr(5), Start: date(2026, 1, 2)},
+			from: date(2026, 1, 10), to: date(2026, 2, 28),
+			want: []string{"2026-01-16", "2026-01-30", "2026-02-13", "2026-02-27"},
+		},
+		{
+			name: "monthly on the 31st clamps without drifting",
+			rule: Rule{Frequency: models.FrequencyMonthly, Interval: 1, DayOfMonth: intPtr(31), Start: date(2026, 1, 1)},
+			from: date(2026, 1, 1), to: date(2026, 5, 31),
+			want: []string{"2026-01-31", "2026-02-28", "2026-03-31", "2026-04-30", "2026-05-31"},
+		},
+		{
+			name: "monthly on the 29th in a leap year",
+			rule: Rule{Frequency: models.FrequencyMonthly, Interval: 1, DayOfMonth: intPtr(29), Start: date(2028, 1, 1)},
+			from: date(2028, 1, 1), to: date(2028, 3, 31),
+			want: []string{"2028-01-29", "2028-02-29", "2028-03-29"},
+		},
+		{
+			name: "monthly day before start skips to next month",
+			rule: Rule{Frequency: models.FrequencyMonthly, Interval: 1, DayOfMonth: intPtr(1), Start: date(2026, 10, 16)},
+			from: date(2026, 10, 1), to: date(2026, 12, 31),
+			want: []string{"2026-11-01", "2026-12-01"},
+		},
+		{
+			name: "monthly without day uses start day",
+			rule: Rule{Frequency: models.FrequencyMonthly, Interval: 2, Start: date(2026, 1, 31)},
+			from: date(2026, 1, 1), to: date(2026, 8, 31),
+			want: []string{"2026-01-31", "2026-03-31", "2026-05-31", "2026-07-31"},
+		},
+		{
+			name: "quarterly",
+			rule: Rule{Frequency: models.FrequencyQuarterly, Interval: 1, DayOfMonth: intPtr(15), Start: date(2026, 1, 15)},
+			from: date(2026, 1, 1), to: date(2026, 12, 31),
+			want: []string{"2026-01-15", "2026-04-15", "2026-07-15", "2026-10-15"},
+		},
+		{
+			name: "annual on leap day",
+			rule: Rule{Frequency: models.FrequencyAnnual, Interval: 1, Start: date(2028, 2, 29)},
+			from: date(2028, 1, 1), to: date(2032, 12, 31),
+			want: []string{"2028-02-29", "2029-02-28", "2030-02-28", "2031-02-28", "2032-02-29"},
+		},
+		{
+			name: "end date cuts off",
+			rule: Rule{Frequency: models.FrequencyMonthly, Interval: 1, DayOfMonth: intPtr(15), Start: date(2026, 1, 1), End: &end},
+			from: date(2026, 1, 1), to: date(2026, 12, 31),
+			want: []string{"2026-01-15", "2026-02-15", "2026-03-15"},
+		},
+		{
+			name: "window before start",
+			rule: Rule{Frequency: models.FrequencyMonthly, Interval: 1, Start: date(2026, 6, 1)},
+			from: date(2026, 1, 1), to: date(2026, 5, 31),
+			want: []string{},
+		},
+		{
+			name: "following adjustment",
+			rule: Rule{Frequency: models.FrequencyMonthly, Interval: 1, DayOfMonth: intPtr(1), Start: date(2026, 11, 1), Adjust: AdjustFollowing},
+			from: date(2026, 11, 1), to: date(2027, 1, 31),
+			want: []string{"2026-11-02", "2026-12-01", "2027-01-01"},
+		},
+		{
+			name: "preceding adjustment",
+			rule: Rule{Frequency: models.FrequencyMonthly, Interval: 1, DayOfMonth: intPtr(1), Start: date(2026, 11, 1), Adjust: AdjustPreceding},
+			from: date(2026, 10, 1), to: date(2026, 12, 31),
+			want: []string{"2026-10-30", "2026-12-01"},
+		},
+		{
+			name: "modified following stays in month",
+			rule: Rule{Frequency: models.FrequencyMonthly, Interval: 1, DayOfMonth: intPtr(31), Start: date(2026, 10, 1), Adjust: AdjustModifiedFollowing},
+			from: date(2026, 10, 1), to: date(2027, 1, 31),
+			want: []string{"2026-10-30", "2026-11-30", "2026-12-31", "2027-01-29"},
+		},
+		{
+			name: "daily with business days drops duplicate Mondays",
+			rule: Rule{Frequency: models.FrequencyDaily, Interval: 1, Start: date(2026, 10, 16), Adjust: AdjustFollowing},
+			from: date(2026, 10, 16), to: date(2026, 10, 20),
+			want: []string{"2026-10-16", "2026-10-19", "2026-10-20"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatDates(tt.rule.Occurrences(tt.from, tt.to))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestOccurrences_FarFromStart(t *testing.T) {
+	rule := Rule{Frequency: models.FrequencyDaily, Interval: 1, Start: date(2000, 1, 1)}
+	got := rule.Occurrences(date(2026, 10, 16), date(2026, 10, 17))
+	assert.Equal(t, []string{"2026-10-16", "2026-10-17"}, formatDates(got))
+
+	monthly := Rule{Frequency: models.FrequencyMonthly, Interval: 1, DayOfMonth: intPtr(31), Start: date(2000, 1, 1)}
+	got = monthly.Occurrences(date(2026, 2, 1), date(2026, 2, 28))
+	assert.Equal(t, []string{"2026-02-28"}, formatDates(got))
+}
+
+func TestFirstAndNext(t *testing.T) {
+	rule := Rule{Frequency: models.FrequencyMonthly, Interval: 1, DayOfMonth: intPtr(31), Start: date(2026, 1, 10)}
+
+	first, ok := rule.First()
+	assert.True(t, ok)
+	assert.Equal(t, date(2026, 1, 31), first)
+
+	next, ok := rule.Next(first)
+	assert.True(t, ok)
+	assert.Equal(t, date(2026, 2, 28), next)
+
+	next, ok = rule.Next(next)
+	assert.True(t, ok)
+	assert.Equal(t, date(2026, 3, 31), next)
+
+	onOrAfter, ok := rule.OnOrAfter(date(2026, 3, 31))
+	assert.True(t, ok)
+	assert.Equal(t, date(2026, 3, 31), onOrAfter)
+}
+
+func TestNext_AfterEnd(t *testing.T) {
+	end := date(2026, 3, 31)
+	rule := Rule{Frequency: models.FrequencyMonthly, Interval: 1, DayOfMonth: intPtr(31), Start: date(2026, 1, 1), End: &end}
+
+	_, ok := rule.Next(date(2026, 3, 31))
+	assert.False(t, ok)
+
+	before := date(2025, 12, 1)
+	_, ok = Rule{Frequency: models.FrequencyWeekly, Interval: 1, Start: date(2026, 1, 1), End: &before}.First()
+	assert.False(t, ok)
+}
+
+func TestRule_IgnoresTimeOfDay(t *testing.T) {
+	loc := time.FixedZone("UTC-5", -5*60*60)
+	rule := Rule{Frequency: models.FrequencyWeekly, Interval: 1, Start: time.Date(2026, 10, 16, 23, 30, 0, 0, loc)}
+
+	next, ok := rule.Next(time.Date(2026, 10, 16, 8, 0, 0, 0, loc))
+	assert.True(t, ok)
+	assert.Equal(t, date(2026, 10, 23), next)
+}
+
+func TestRule_ZeroIntervalMeansOne(t *testing.T) {
+	rule := Rule{Frequency: models.FrequencyDaily, Start: date(2026, 10, 16)}
+	next, ok := rule.Next(date(2026, 10, 16))
+	assert.True(t, ok)
+	assert.Equal(t, date(2026, 10, 17), next)
+}
+
+func TestFromItem(t *testing.T) {
+	end := date(2027, 1, 1)
+	item := &models.RecurringItem{
+		Frequency:         models.FrequencyQuarterly,
+		FrequencyInterval: 2,
+		DayOfMonth:        intPtr(10),
+		StartDate:         date(2026, 1, 1),
+		EndDate:           &end,
+	}
+
+	rule := FromItem(item)
+	assert.Equal(t, models.FrequencyQuarterly, rule.Frequency)
+	assert.Equal(t, 2, rule.Interval)
+	assert.Equal(t, 10, *rule.DayOfMonth)
+	assert.Equal(t, &end, rule.End)
+	assert.Equal(t, []string{"2026-01-10", "2026-07-10"}, formatDates(rule.Occurrences(date(2026, 1, 1), date(2026, 12, 31))))
+}
+
+func TestDaysInMonth(t *testing.T) {
+	assert.Equal(t, 31, DaysInMonth(2026, time.January))
+	assert.Equal(t, 28, DaysInMonth(2026, time.February))
+	assert.Equal(t, 29, DaysInMonth(2028, time.February))
+	assert.Equal(t, 30, DaysInMonth(2026, time.November))
+	assert.Equal(t, 31, DaysInMonth(2026, time.December))
+}
+
+func TestIsWeekend(t *testing.T) {
+	assert.True(t, IsWeekend(date(2026, 10, 17)))
+	assert.True(t, IsWeekend(date(2026, 10, 18)))
+	assert.False(t, IsWeekend(date(2026, 10, 16)))
+}
@@ -0,0 +1,63 @@
+package recurrence
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fintrack/fintrack/internal/models"
+)
+
+// RRULE returns the rule as an RFC 5545 recurrence rule value, such as
+// "FREQ=MONTHLY;BYMONTHDAY=1", for an event whose DTSTART is one of the rule's
+// occurrences. Day-stepped rules repeat from DTSTART. Month-based rules name
+// their day of the month; a day past the 28th becomes "the last of the 28th up
+// to that day", so short months land on their last day as Occurrences does.
+// Business-day adjustments can't be expressed and are left out.
+func (r Rule) RRULE() string {
+	var parts []string
+	switch r.Frequency {
+	case models.FrequencyDaily:
+		parts = append(parts, "FREQ=DAILY")
+	case models.FrequencyWeekly, models.FrequencyBiweekly:
+		parts = append(parts, "FREQ=WEEKLY")
+	case models.FrequencyAnnual:
+		parts = append(parts, "FREQ=YEARLY")
+	default:
+		parts = append(parts, "FREQ=MONTHLY")
+	}
+
+	interval := r.interval()
+	switch r.Frequency {
+	case models.FrequencyBiweekly:
+		interval *= 2
+	case models.FrequencyQuarterly:
+		interval *= 3
+	}
+	if interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", interval))
+	}
+
+	if r.stepDays() == 0 {
+		if r.Frequency == models.FrequencyAnnual {
+			parts = append(parts, fmt.Sprintf("BYMONTH=%d", int(Day(r.Start).Month())))
+		}
+		day := Day(r.Start).Day()
+		if r.DayOfMonth != nil {
+			day = *r.DayOfMonth
+		}
+		if day <= 28 {
+			parts = append(parts, fmt.Sprintf("BYMONTHDAY=%d", day))
+		} else {
+			days := make([]string, 0, day-27)
+			for d := 28; d <= day; d++ {
+				days = append(days, fmt.Sprintf("%d", d))
+			}
+			parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","), "BYSETPOS=-1")
+		}
+	}
+
+	if r.End != nil {
+		parts = append(parts, "UNTIL="+Day(*r.End).Format("20060102"))
+	}
+	return strings.Join(parts, ";")
+}
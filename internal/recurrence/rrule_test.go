@@ -0,0 +1,60 @@
+package recurrence
+
+import (
+	"testing"
+
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRRULE(t *testing.T) {
+	end := date(2027, 6, 30)
+
+	tests := []struct {
+		name string
+		rule Rule
+		want string
+	}{
+		{
+			name: "daily",
+			rule: Rule{Frequency: models.FrequencyDaily, Interval: 1, Start: date(2026, 1, 1)},
+			want: "FREQ=DAILY",
+		},
+		{
+			name: "weekly every 3 weeks",
+			rule: Rule{Frequency: models.FrequencyWeekly, Interval: 3, DayOfWeek: intPtr(5), Start: date(2026, 1, 1)},
+			want: "FREQ=WEEKLY;INTERVAL=3",
+		},
+		{
+			name: "biweekly",
+			rule: Rule{Frequency: models.FrequencyBiweekly, Interval: 1, Start: date(2026, 1, 2)},
+			want: "FREQ=WEEKLY;INTERVAL=2",
+		},
+		{
+			name: "monthly on start day until end",
+			rule: Rule{Frequency: models.FrequencyMonthly, Interval: 1, Start: date(2026, 1, 15), End: &end},
+			want: "FREQ=MONTHLY;BYMONTHDAY=15;UNTIL=20270630",
+		},
+		{
+			name: "monthly on the 31st",
+			rule: Rule{Frequency: models.FrequencyMonthly, Interval: 1, DayOfMonth: intPtr(31), Start: date(2026, 1, 1)},
+			want: "FREQ=MONTHLY;BYMONTHDAY=28,29,30,31;BYSETPOS=-1",
+		},
+		{
+			name: "quarterly",
+			rule: Rule{Frequency: models.FrequencyQuarterly, Interval: 1, DayOfMonth: intPtr(10), Start: date(2026, 1, 1)},
+			want: "FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=10",
+		},
+		{
+			name: "annual on a leap day",
+			rule: Rule{Frequency: models.FrequencyAnnual, Interval: 1, Start: date(2028, 2, 29)},
+			want: "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=28,29;BYSETPOS=-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rule.RRULE())
+		})
+	}
+}
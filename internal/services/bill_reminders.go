@@ -0,0 +1,209 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"gorm.io/gorm"
+)
+
+// ReminderSyncResult summarizes a reminder sync pass
+type ReminderSyncResult struct {
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Dismissed int `json:"dismissed"`
+}
+
+// BillReminderService keeps bill reminders in step with recurring expenses
+type BillReminderService struct {
+	db                   *gorm.DB
+	autoDismissAfterDays int
+	now                  func() time.Time
+}
+
+// NewBillReminderService creates a new bill reminder service. Reminders more than
+// autoDismissAfterDays past their date are dismissed by Sync; zero keeps them.
+func NewBillReminderService(db *gorm.DB, autoDismissAfterDays int) *BillReminderService {
+	return &BillReminderService{
+		db:                   db,
+		autoDismissAfterDays: autoDismissAfterDays,
+		now:                  time.Now,
+	}
+}
+
+// Sync makes sure every active recurring expense has a bill reminder
+// ReminderDaysBefore days ahead of its next due date. An open reminder left
+// behind by an older schedule is moved to the new date rather than duplicated,
+// and a reminder on or after that date (including one the user dismissed or
+// snoozed) counts as already covering it. Paused and ended items have their open
+// bill reminders dismissed. Finally any reminder left undismissed for more than
+// the auto-dismiss period is dismissed.
+func (s *BillReminderService) Sync() (*ReminderSyncResult, error) {
+	result := &ReminderSyncResult{}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		recurringRepo := repositories.NewRecurringRepository(tx)
+		reminderRepo := repositories.NewReminderRepository(tx)
+
+		items, err := recurringRepo.List(false)
+		if err != nil {
+			return fmt.Errorf("failed to list recurring items: %w", err)
+		}
+
+		for _, item := range items {
+			reminders, err := reminderRepo.ListByRelated(models.ReminderTypeBill, item.ID)
+			if err != nil {
+				return fmt.Errorf("failed to list bill reminders: %w", err)
+			}
+
+			if !needsBillReminder(item) {
+				n, err := dismissOpenReminders(reminderRepo, reminders)
+				if err != nil {
+					return err
+				}
+				result.Dismissed += n
+				continue
+			}
+
+			remindDate := billRemindDate(item)
+			var open *models.Reminder
+			covered := false
+			for _, r := range reminders {
+				if !startOfDayUTC(r.RemindDate).Before(remindDate) {
+					covered = true
+					break
+				}
+				if !r.IsDismissed {
+					open = r
+				}
+			}
+			if covered {
+				continue
+			}
+
+			if open != nil {
+				applyBillReminder(open, item)
+				if err := reminderRepo.Update(open); err != nil {
+					return fmt.Errorf("failed to update bill reminder: %w", err)
+				}
+				result.Updated++
+				continue
+			}
+
+			itemID := item.ID
+			reminder := &models.Reminder{
+				Type:      models.ReminderTypeBill,
+				RelatedID: &itemID,
+				Priority:  models.ReminderPriorityNormal,
+			}
+			applyBillReminder(reminder, item)
+			if err := reminderRepo.Create(reminder); err != nil {
+				return fmt.Errorf("failed to create bill reminder: %w", err)
+			}
+			result.Created++
+		}
+
+		if s.autoDismissAfterDays > 0 {
+			cutoff := startOfDayUTC(s.now()).AddDate(0, 0, -s.autoDismissAfterDays)
+			n, err := reminderRepo.DismissBefore(cutoff)
+			if err != nil {
+				return fmt.Errorf("failed to dismiss stale reminders: %w", err)
+			}
+			result.Dismissed += int(n)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DismissBillReminders dismisses the open bill reminders of the recurring items
+// the given transactions were generated from or matched to, since those bills
+// have now been recorded. Only reminders dated on or before a transaction's
+// date plus the item's ReminderDaysBefore are dismissed, so recording one bill
+// leaves the reminder for a later due date open. It is registered as a create
+// hook, so editing an old transaction doesn't dismiss anything.
+func DismissBillReminders(db *gorm.DB, transactions []*models.Transaction) error {
+	latest := make(map[uint]time.Time)
+	var ids []uint
+	for _, tx := range transactions {
+		if tx.RecurringID == nil {
+			continue
+		}
+		date, seen := latest[*tx.RecurringID]
+		if !seen {
+			ids = append(ids, *tx.RecurringID)
+		}
+		if !seen || tx.Date.After(date) {
+			latest[*tx.RecurringID] = tx.Date
+		}
+	}
+
+	recurringRepo := repositories.NewRecurringRepository(db)
+	reminderRepo := repositories.NewReminderRepository(db)
+	for _, id := range ids {
+		item, err := recurringRepo.GetByID(id)
+		if err != nil {
+			return fmt.Errorf("failed to load recurring item %d: %w", id, err)
+		}
+		through := startOfDayUTC(latest[id]).AddDate(0, 0, item.ReminderDaysBefore)
+
+		reminders, err := reminderRepo.ListByRelated(models.ReminderTypeBill, id)
+		if err != nil {
+			return fmt.Errorf("failed to list bill reminders: %w", err)
+		}
+		var settled []*models.Reminder
+		for _, r := range reminders {
+			if !startOfDayUTC(r.RemindDate).After(through) {
+				settled = append(settled, r)
+			}
+		}
+		if _, err := dismissOpenReminders(reminderRepo, settled); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// needsBillReminder reports whether an item is an active expense with an
+// occurrence still to come
+func needsBillReminder(item *models.RecurringItem) bool {
+	if !item.IsActive || item.AmountCents >= 0 {
+		return false
+	}
+	return item.EndDate == nil || !startOfDayUTC(item.NextDate).After(startOfDayUTC(*item.EndDate))
+}
+
+// billRemindDate returns the day an item's next bill reminder is due
+func billRemindDate(item *models.RecurringItem) time.Time {
+	return startOfDayUTC(item.NextDate).AddDate(0, 0, -item.ReminderDaysBefore)
+}
+
+// applyBillReminder sets a bill reminder's date and text for an item's next due date
+func applyBillReminder(reminder *models.Reminder, item *models.RecurringItem) {
+	reminder.Title = fmt.Sprintf("Bill due: %s", item.Name)
+	reminder.Message = fmt.Sprintf("$%.2f due %s",
+		models.CentsToDollars(-item.AmountCents),
+		item.NextDate.Format("2006-01-02"))
+	reminder.RemindDate = billRemindDate(item)
+}
+
+// dismissOpenReminders dismisses the undismissed reminders in the list and
+// returns how many it dismissed
+func dismissOpenReminders(reminderRepo *repositories.ReminderRepository, reminders []*models.Reminder) (int, error) {
+	n := 0
+	for _, r := range reminders {
+		if r.IsDismissed {
+			continue
+		}
+		if err := reminderRepo.Dismiss(r.ID); err != nil {
+			return n, fmt.Errorf("failed to dismiss reminder: %w", err)
+		}
+		n++
+	}
+	return n, nil
+}
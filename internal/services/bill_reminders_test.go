@@ -0,0 +1,222 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// BillReminderTestSuite is the test suite for bill reminder sync
+type BillReminderTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	svc     *BillReminderService
+	account *models.Account
+	rent    *models.RecurringItem
+}
+
+// SetupTest runs before each test
+func (suite *BillReminderTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), db.AutoMigrate(&models.Account{}, &models.Transaction{}, &models.RecurringItem{}, &models.Reminder{}))
+
+	suite.db = db
+	suite.svc = NewBillReminderService(db, 30)
+	suite.svc.now = func() time.Time { return time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC) }
+
+	suite.account = &models.Account{Name: "Checking", Type: models.AccountTypeChecking, Currency: "USD", IsActive: true}
+	assert.NoError(suite.T(), repositories.NewAccountRepository(db).Create(suite.account))
+
+	suite.rent = suite.item("Rent", -150000, time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC))
+
+	repositories.RegisterTransactionCreateHook(HookBillReminders, DismissBillReminders)
+}
+
+// TearDownTest runs after each test
+func (suite *BillReminderTestSuite) TearDownTest() {
+	repositories.UnregisterTransactionCreateHook(HookBillReminders)
+}
+
+func (suite *BillReminderTestSuite) item(name string, cents int64, next time.Time) *models.RecurringItem {
+	item := &models.RecurringItem{
+		AccountID:          suite.account.ID,
+		Name:               name,
+		AmountCents:        cents,
+		Frequency:          models.FrequencyMonthly,
+		FrequencyInterval:  1,
+		StartDate:          next,
+		NextDate:           next,
+		ReminderDaysBefore: 3,
+		IsActive:           true,
+	}
+	assert.NoError(suite.T(), repositories.NewRecurringRepository(suite.db).Create(item))
+	return item
+}
+
+func (suite *BillReminderTestSuite) reminders(item *models.RecurringItem) []*models.Reminder {
+	reminders, err := repositories.NewReminderRepository(suite.db).ListByRelated(models.ReminderTypeBill, item.ID)
+	assert.NoError(suite.T(), err)
+	return reminders
+}
+
+func (suite *BillReminderTestSuite) sync() *ReminderSyncResult {
+	result, err := suite.svc.Sync()
+	assert.NoError(suite.T(), err)
+	return result
+}
+
+func (suite *BillReminderTestSuite) TestSyncCreatesBillReminders() {
+	// Given rent due on 1 Nov and a monthly salary
+	suite.item("Salary", 400000, time.Date(2026, 10, 30, 0, 0, 0, 0, time.UTC))
+
+	// When reminders are synced
+	result := suite.sync()
+
+	// Then only the expense gets a reminder, three days ahead of its due date
+	assert.Equal(suite.T(), 1, result.Created)
+	reminders := suite.reminders(suite.rent)
+	assert.Len(suite.T(), reminders, 1)
+	assert.Equal(suite.T(), "Bill due: Rent", reminders[0].Title)
+	assert.Equal(suite.T(), "$1500.00 due 2026-11-01", reminders[0].Message)
+	assert.Equal(suite.T(), "2026-10-29", reminders[0].RemindDate.Format("2006-01-02"))
+
+	// And syncing again changes nothing
+	result = suite.sync()
+	assert.Equal(suite.T(), ReminderSyncResult{}, *result)
+	assert.Len(suite.T(), suite.reminders(suite.rent), 1)
+}
+
+func (suite *BillReminderTestSuite) TestSyncMovesReminderWhenScheduleChanges() {
+	suite.sync()
+
+	suite.rent.NextDate = time.Date(2026, 11, 5, 0, 0, 0, 0, time.UTC)
+	assert.NoError(suite.T(), repositories.NewRecurringRepository(suite.db).Update(suite.rent))
+
+	result := suite.sync()
+	assert.Equal(suite.T(), 1, result.Updated)
+	reminders := suite.reminders(suite.rent)
+	assert.Len(suite.T(), reminders, 1)
+	assert.Equal(suite.T(), "2026-11-02", reminders[0].RemindDate.Format("2006-01-02"))
+	assert.Contains(suite.T(), reminders[0].Message, "2026-11-05")
+}
+
+func (suite *BillReminderTestSuite) TestSyncRespectsDismissedAndSnoozed() {
+	suite.sync()
+	reminder := suite.reminders(suite.rent)[0]
+	repo := repositories.NewReminderRepository(suite.db)
+
+	assert.NoError(suite.T(), repo.Snooze(reminder.ID, time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC)))
+	suite.sync()
+	reminders := suite.reminders(suite.rent)
+	assert.Len(suite.T(), reminders, 1)
+	assert.Equal(suite.T(), "2026-10-31", reminders[0].RemindDate.Format("2006-01-02"))
+
+	assert.NoError(suite.T(), repo.Dismiss(reminder.ID))
+	assert.Equal(suite.T(), 0, suite.sync().Created)
+	assert.Len(suite.T(), suite.reminders(suite.rent), 1)
+}
+
+func (suite *BillReminderTestSuite) TestRecordedBillDismissesReminder() {
+	suite.sync()
+
+	// When the rent payment is generated from the schedule
+	recurringID := suite.rent.ID
+	tx := &models.Transaction{
+		AccountID:   suite.account.ID,
+		Date:        suite.rent.NextDate,
+		AmountCents: suite.rent.AmountCents,
+		Type:        models.TransactionTypeExpense,
+		RecurringID: &recurringID,
+		Tags:        models.StringArray{},
+	}
+	assert.NoError(suite.T(), repositories.NewTransactionRepository(suite.db).Create(tx))
+
+	// Then its reminder is dismissed
+	reminders := suite.reminders(suite.rent)
+	assert.Len(suite.T(), reminders, 1)
+	assert.True(suite.T(), reminders[0].IsDismissed)
+
+	// And once the schedule advances, the next month's reminder is created
+	suite.rent.NextDate = time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+	assert.NoError(suite.T(), repositories.NewRecurringRepository(suite.db).Update(suite.rent))
+	assert.Equal(suite.T(), 1, suite.sync().Created)
+	reminders = suite.reminders(suite.rent)
+	assert.Len(suite.T(), reminders, 2)
+	assert.Equal(suite.T(), "2026-11-28", reminders[1].RemindDate.Format("2006-01-02"))
+}
+
+func (suite *BillReminderTestSuite) TestEditingRecordedBillKeepsNextReminder() {
+	// Given last month's rent was recorded and this month's reminder is open
+	recurringID := suite.rent.ID
+	tx := &models.Transaction{
+		AccountID:   suite.account.ID,
+		Date:        time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		AmountCents: suite.rent.AmountCents,
+		Type:        models.TransactionTypeExpense,
+		RecurringID: &recurringID,
+		Tags:        models.StringArray{},
+	}
+	txRepo := repositories.NewTransactionRepository(suite.db)
+	assert.NoError(suite.T(), txRepo.Create(tx))
+	suite.sync()
+
+	// When the old payment is edited
+	tx.Description = "October rent"
+	assert.NoError(suite.T(), txRepo.Update(tx))
+
+	// Then the reminder for the next due date stays open
+	reminders := suite.reminders(suite.rent)
+	assert.Len(suite.T(), reminders, 1)
+	assert.False(suite.T(), reminders[0].IsDismissed)
+	assert.Equal(suite.T(), 0, suite.sync().Created)
+}
+
+func (suite *BillReminderTestSuite) TestRecordedBillKeepsLaterReminders() {
+	// Given the reminder for the November rent is open
+	suite.sync()
+
+	// When a payment for the October rent is recorded late
+	recurringID := suite.rent.ID
+	tx := &models.Transaction{
+		AccountID:   suite.account.ID,
+		Date:        time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC),
+		AmountCents: suite.rent.AmountCents,
+		Type:        models.TransactionTypeExpense,
+		RecurringID: &recurringID,
+		Tags:        models.StringArray{},
+	}
+	assert.NoError(suite.T(), repositories.NewTransactionRepository(suite.db).Create(tx))
+
+	// Then the November reminder stays open
+	reminders := suite.reminders(suite.rent)
+	assert.Len(suite.T(), reminders, 1)
+	assert.False(suite.T(), reminders[0].IsDismissed)
+}
+
+func (suite *BillReminderTestSuite) TestSyncDismissesPausedAndStale() {
+	suite.sync()
+	assert.NoError(suite.T(), repositories.NewRecurringRepository(suite.db).SetActive(suite.rent.ID, false))
+
+	old := &models.Reminder{
+		Type:       models.ReminderTypeCustom,
+		Title:      "Call the bank",
+		RemindDate: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+		Priority:   models.ReminderPriorityNormal,
+	}
+	assert.NoError(suite.T(), repositories.NewReminderRepository(suite.db).Create(old))
+
+	result := suite.sync()
+	assert.Equal(suite.T(), 2, result.Dismissed)
+	assert.True(suite.T(), suite.reminders(suite.rent)[0].IsDismissed)
+}
+
+func TestBillReminderTestSuite(t *testing.T) {
+	suite.Run(t, new(BillReminderTestSuite))
+}
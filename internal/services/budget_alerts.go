@@ -0,0 +1,160 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"gorm.io/gorm"
+)
+
+// budgetAlertPriority maps a budget state to the priority of its reminder
+var budgetAlertPriority = map[string]string{
+	BudgetStateWarning:  models.ReminderPriorityHigh,
+	BudgetStateExceeded: models.ReminderPriorityUrgent,
+}
+
+// BudgetAlertService creates budget reminders when spending crosses a budget's
+// alert threshold or its limit
+type BudgetAlertService struct {
+	budgetRepo   *repositories.BudgetRepository
+	categoryRepo *repositories.CategoryRepository
+	txRepo       *repositories.TransactionRepository
+	reminderRepo *repositories.ReminderRepository
+	now          func() time.Time
+}
+
+// NewBudgetAlertService creates a new budget alert service
+func NewBudgetAlertService(db *gorm.DB) *BudgetAlertService {
+	return &BudgetAlertService{
+		budgetRepo:   repositories.NewBudgetRepository(db),
+		categoryRepo: repositories.NewCategoryRepository(db),
+		txRepo:       repositories.NewTransactionRepository(db),
+		reminderRepo: repositories.NewReminderRepository(db),
+		now:          time.Now,
+	}
+}
+
+// Check evaluates the active budgets whose period and category tree include any of
+// the given expense transactions, and creates a reminder for each budget that has
+// reached its threshold or limit. A budget period gets at most one reminder per
+// state, even if that reminder was dismissed.
+func (s *BudgetAlertService) Check(transactions []*models.Transaction) ([]*models.Reminder, error) {
+	var expenses []*models.Transaction
+	for _, tx := range transactions {
+		if tx.Type == models.TransactionTypeExpense {
+			expenses = append(expenses, tx)
+		}
+	}
+	if len(expenses) == 0 {
+		return nil, nil
+	}
+
+	budgets, err := s.budgetRepo.List(true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list budgets: %w", err)
+	}
+
+	var created []*models.Reminder
+	for _, budget := range budgets {
+		affected, err := s.affects(budget, expenses)
+		if err != nil {
+			return nil, err
+		}
+		if !affected {
+			continue
+		}
+
+		reminder, err := s.checkBudget(budget)
+		if err != nil {
+			return nil, err
+		}
+		if reminder != nil {
+			created = append(created, reminder)
+		}
+	}
+	return created, nil
+}
+
+// affects reports whether any of the expenses falls in the budget's period and category tree
+func (s *BudgetAlertService) affects(budget *models.Budget, expenses []*models.Transaction) (bool, error) {
+	var tree map[uint]bool
+	if budget.CategoryID != nil {
+		ids, err := s.categoryRepo.GetDescendantIDs(*budget.CategoryID)
+		if err != nil {
+			return false, fmt.Errorf("failed to resolve category tree: %w", err)
+		}
+		tree = map[uint]bool{*budget.CategoryID: true}
+		for _, id := range ids {
+			tree[id] = true
+		}
+	}
+
+	start := startOfDayUTC(budget.PeriodStart)
+	end := startOfDayUTC(budget.PeriodEnd).AddDate(0, 0, 1)
+	for _, tx := range expenses {
+		day := startOfDayUTC(tx.Date)
+		if day.Before(start) || !day.Before(end) {
+			continue
+		}
+		if tree == nil || (tx.CategoryID != nil && tree[*tx.CategoryID]) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkBudget creates a reminder for the budget's current state unless one already exists
+func (s *BudgetAlertService) checkBudget(budget *models.Budget) (*models.Reminder, error) {
+	spent, err := budgetSpending(s.categoryRepo, s.txRepo, budget)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate spending: %w", err)
+	}
+
+	status := NewBudgetStatus(budget, spent)
+	priority, ok := budgetAlertPriority[status.State]
+	if !ok {
+		return nil, nil
+	}
+
+	existing, err := s.reminderRepo.ListByRelated(models.ReminderTypeBudget, budget.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check reminders: %w", err)
+	}
+	for _, r := range existing {
+		if r.Priority == priority {
+			return nil, nil
+		}
+	}
+
+	reminder := newBudgetReminder(status, priority, s.now())
+	if err := s.reminderRepo.Create(reminder); err != nil {
+		return nil, fmt.Errorf("failed to create reminder: %w", err)
+	}
+	return reminder, nil
+}
+
+// newBudgetReminder builds the reminder for a budget that is over its threshold or limit
+func newBudgetReminder(status *BudgetStatus, priority string, now time.Time) *models.Reminder {
+	budget := status.Budget
+
+	title := fmt.Sprintf("Budget %q reached %.0f%%", budget.Name, status.PercentUsed*100)
+	if status.State == BudgetStateExceeded {
+		title = fmt.Sprintf("Budget %q exceeded", budget.Name)
+	}
+
+	budgetID := budget.ID
+	return &models.Reminder{
+		Type:      models.ReminderTypeBudget,
+		RelatedID: &budgetID,
+		Title:     title,
+		Message: fmt.Sprintf("Spent $%.2f of $%.2f for %s to %s",
+			models.CentsToDollars(status.SpentCents),
+			models.CentsToDollars(status.LimitCents),
+			budget.PeriodStart.Format("2006-01-02"),
+			budget.PeriodEnd.Format("2006-01-02")),
+		RemindDate: startOfDayUTC(now),
+		Priority:   priority,
+	}
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// BudgetAlertTestSuite is the test suite for budget threshold alerts
+type BudgetAlertTestSuite struct {
+	suite.Suite
+	db       *gorm.DB
+	svc      *BudgetAlertService
+	txRepo   *repositories.TransactionRepository
+	category *models.Category
+	budget   *models.Budget
+}
+
+// SetupTest runs before each test
+func (suite *BudgetAlertTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), db.AutoMigrate(&models.Account{}, &models.Category{}, &models.Transaction{}, &models.Budget{}, &models.Reminder{}))
+
+	suite.db = db
+	suite.svc = NewBudgetAlertService(db)
+	suite.svc.now = func() time.Time { return time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC) }
+	suite.txRepo = repositories.NewTransactionRepository(db)
+
+	suite.category = &models.Category{Name: "Dining", Type: models.CategoryTypeExpense}
+	assert.NoError(suite.T(), db.Create(suite.category).Error)
+
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	suite.budget = &models.Budget{
+		Name:             "Dining",
+		CategoryID:       &suite.category.ID,
+		PeriodType:       models.BudgetPeriodMonthly,
+		PeriodStart:      start,
+		PeriodEnd:        BudgetPeriodEnd(models.BudgetPeriodMonthly, start),
+		LimitAmountCents: 20000,
+		AlertThreshold:   0.80,
+		IsActive:         true,
+	}
+	assert.NoError(suite.T(), db.Create(suite.budget).Error)
+}
+
+// TearDownTest runs after each test
+func (suite *BudgetAlertTestSuite) TearDownTest() {
+	repositories.UnregisterTransactionHook(HookBudgetAlerts)
+}
+
+func (suite *BudgetAlertTestSuite) spend(day int, cents int64) *models.Transaction {
+	tx := &models.Transaction{
+		AccountID:   1,
+		Date:        time.Date(2026, 10, day, 0, 0, 0, 0, time.UTC),
+		AmountCents: -cents,
+		CategoryID:  &suite.category.ID,
+		Type:        models.TransactionTypeExpense,
+	}
+	assert.NoError(suite.T(), suite.txRepo.Create(tx))
+	return tx
+}
+
+func (suite *BudgetAlertTestSuite) reminders() []*models.Reminder {
+	reminders, err := repositories.NewReminderRepository(suite.db).ListByRelated(models.ReminderTypeBudget, suite.budget.ID)
+	assert.NoError(suite.T(), err)
+	return reminders
+}
+
+func (suite *BudgetAlertTestSuite) TestCheck_BelowThreshold() {
+	tx := suite.spend(5, 10000)
+
+	created, err := suite.svc.Check([]*models.Transaction{tx})
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), created)
+}
+
+func (suite *BudgetAlertTestSuite) TestCheck_ThresholdThenExceeded() {
+	// Given spending at 85% of the limit
+	tx := suite.spend(5, 17000)
+
+	// When checked twice
+	created, err := suite.svc.Check([]*models.Transaction{tx})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), created, 1)
+	assert.Equal(suite.T(), models.ReminderPriorityHigh, created[0].Priority)
+	assert.Equal(suite.T(), "2026-10-16", created[0].RemindDate.Format("2006-01-02"))
+
+	created, err = suite.svc.Check([]*models.Transaction{tx})
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), created, "threshold reminder must not be duplicated")
+
+	// Then going over the limit adds one urgent reminder
+	tx = suite.spend(6, 5000)
+	created, err = suite.svc.Check([]*models.Transaction{tx})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), created, 1)
+	assert.Equal(suite.T(), models.ReminderPriorityUrgent, created[0].Priority)
+	assert.Contains(suite.T(), created[0].Title, "exceeded")
+
+	assert.Len(suite.T(), suite.reminders(), 2)
+}
+
+func (suite *BudgetAlertTestSuite) TestCheck_DismissedReminderNotRecreated() {
+	tx := suite.spend(5, 25000)
+	created, err := suite.svc.Check([]*models.Transaction{tx})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), created, 1)
+	assert.NoError(suite.T(), suite.db.Model(created[0]).Update("is_dismissed", true).Error)
+
+	tx = suite.spend(7, 1000)
+	created, err = suite.svc.Check([]*models.Transaction{tx})
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), created)
+}
+
+func (suite *BudgetAlertTestSuite) TestCheck_IgnoresOtherPeriodsAndCategories() {
+	other := &models.Category{Name: "Travel", Type: models.CategoryTypeExpense}
+	assert.NoError(suite.T(), suite.db.Create(other).Error)
+
+	outside := &models.Transaction{Date: time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC), AmountCents: -30000, CategoryID: &suite.category.ID, Type: models.TransactionTypeExpense}
+	elsewhere := &models.Transaction{Date: time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC), AmountCents: -30000, CategoryID: &other.ID, Type: models.TransactionTypeExpense}
+	assert.NoError(suite.T(), suite.db.Create(outside).Error)
+	assert.NoError(suite.T(), suite.db.Create(elsewhere).Error)
+
+	created, err := suite.svc.Check([]*models.Transaction{outside, elsewhere})
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), created)
+}
+
+func (suite *BudgetAlertTestSuite) TestHook_CreatesReminderOnBatchInsert() {
+	repositories.RegisterTransactionHook(HookBudgetAlerts, func(tx *gorm.DB, txs []*models.Transaction) error {
+		_, err := NewBudgetAlertService(tx).Check(txs)
+		return err
+	})
+
+	batch := []*models.Transaction{
+		{AccountID: 1, Date: time.Date(2026, 10, 3, 0, 0, 0, 0, time.UTC), AmountCents: -9000, CategoryID: &suite.category.ID, Type: models.TransactionTypeExpense},
+		{AccountID: 1, Date: time.Date(2026, 10, 4, 0, 0, 0, 0, time.UTC), AmountCents: -9000, CategoryID: &suite.category.ID, Type: models.TransactionTypeExpense},
+	}
+	assert.NoError(suite.T(), suite.txRepo.CreateBatch(batch, 10))
+
+	reminders := suite.reminders()
+	assert.Len(suite.T(), reminders, 1)
+	assert.Equal(suite.T(), models.ReminderPriorityHigh, reminders[0].Priority)
+}
+
+func TestBudgetAlertTestSuite(t *testing.T) {
+	suite.Run(t, new(BudgetAlertTestSuite))
+}
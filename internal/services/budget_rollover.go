@@ -0,0 +1,154 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"gorm.io/gorm"
+)
+
+// errRolloverDryRun aborts the rollover transaction after a dry run
+var errRolloverDryRun = errors.New("rollover dry run")
+
+// RolloverEntry records one closed budget period and the period created after it
+type RolloverEntry struct {
+	FromBudgetID uint           `json:"from_budget_id"`
+	Budget       *models.Budget `json:"budget"`
+	SpentCents   int64          `json:"spent_cents"`
+	CarriedCents int64          `json:"carried_cents"` // Unspent (positive) or overspent (negative) amount carried forward
+}
+
+// RolloverResult summarizes a rollover run
+type RolloverResult struct {
+	Entries []RolloverEntry `json:"entries"`
+	Skipped int             `json:"skipped"` // Closed periods whose successor already existed
+}
+
+// BudgetRolloverService advances budgets whose period has ended
+type BudgetRolloverService struct {
+	db *gorm.DB
+}
+
+// NewBudgetRolloverService creates a new budget rollover service
+func NewBudgetRolloverService(db *gorm.DB) *BudgetRolloverService {
+	return &BudgetRolloverService{db: db}
+}
+
+// Run creates the next period for every active budget whose period ended before asOf.
+// Budgets with RolloverEnabled carry their unspent (or overspent) amount into the new
+// period's RolloverAmountCents. Missed periods are backfilled one by one so each carry
+// reflects that period's actual spending. The closed period is deactivated, and an
+// existing successor is never modified, so running twice never double-carries.
+// A dry run computes the same result and rolls the database transaction back.
+func (s *BudgetRolloverService) Run(asOf time.Time, dryRun bool) (*RolloverResult, error) {
+	result := &RolloverResult{Entries: []RolloverEntry{}}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		budgetRepo := repositories.NewBudgetRepository(tx)
+		categoryRepo := repositories.NewCategoryRepository(tx)
+		txRepo := repositories.NewTransactionRepository(tx)
+
+		cutoff := startOfDayUTC(asOf)
+		closed, err := budgetRepo.ListEndedBefore(cutoff)
+		if err != nil {
+			return fmt.Errorf("failed to list closed budgets: %w", err)
+		}
+
+		processed := make(map[uint]bool)
+		for _, budget := range closed {
+			current := budget
+			for !processed[current.ID] && current.PeriodEnd.Before(cutoff) {
+				processed[current.ID] = true
+				nextStart := current.PeriodEnd.AddDate(0, 0, 1)
+
+				next, err := budgetRepo.FindPeriod(current, nextStart)
+				if err != nil {
+					return err
+				}
+
+				if next != nil {
+					result.Skipped++
+				} else {
+					entry, err := s.rollForward(budgetRepo, categoryRepo, txRepo, current, nextStart)
+					if err != nil {
+						return err
+					}
+					result.Entries = append(result.Entries, *entry)
+					next = entry.Budget
+				}
+
+				current.IsActive = false
+				if err := budgetRepo.Update(current); err != nil {
+					return fmt.Errorf("failed to close budget #%d: %w", current.ID, err)
+				}
+
+				// An inactive successor was closed by an earlier run along with its own successor
+				if !next.IsActive {
+					break
+				}
+				current = next
+			}
+		}
+
+		if dryRun {
+			return errRolloverDryRun
+		}
+		return nil
+	})
+
+	if errors.Is(err, errRolloverDryRun) {
+		for i := range result.Entries {
+			result.Entries[i].Budget.ID = 0
+		}
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// rollForward creates the budget for the period starting at nextStart
+func (s *BudgetRolloverService) rollForward(budgetRepo *repositories.BudgetRepository, categoryRepo *repositories.CategoryRepository, txRepo *repositories.TransactionRepository, current *models.Budget, nextStart time.Time) (*RolloverEntry, error) {
+	spent, err := budgetSpending(categoryRepo, txRepo, current)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate spending for budget #%d: %w", current.ID, err)
+	}
+
+	var carried int64
+	if current.RolloverEnabled {
+		carried = current.LimitAmountCents + current.RolloverAmountCents - spent
+	}
+
+	next := &models.Budget{
+		Name:                current.Name,
+		CategoryID:          current.CategoryID,
+		PeriodType:          current.PeriodType,
+		PeriodStart:         nextStart,
+		PeriodEnd:           BudgetPeriodEnd(current.PeriodType, nextStart),
+		LimitAmountCents:    current.LimitAmountCents,
+		RolloverEnabled:     current.RolloverEnabled,
+		RolloverAmountCents: carried,
+		AlertThreshold:      current.AlertThreshold,
+		IsActive:            true,
+	}
+
+	if err := budgetRepo.Create(next); err != nil {
+		return nil, fmt.Errorf("failed to create next period for budget #%d: %w", current.ID, err)
+	}
+
+	return &RolloverEntry{
+		FromBudgetID: current.ID,
+		Budget:       next,
+		SpentCents:   spent,
+		CarriedCents: carried,
+	}, nil
+}
+
+// startOfDayUTC returns midnight UTC of the calendar day of t
+func startOfDayUTC(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// BudgetRolloverTestSuite is the test suite for the budget rollover service
+type BudgetRolloverTestSuite struct {
+	suite.Suite
+	db       *gorm.DB
+	svc      *BudgetRolloverService
+	category *models.Category
+}
+
+// SetupTest runs before each test
+func (suite *BudgetRolloverTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), db.AutoMigrate(&models.Category{}, &models.Transaction{}, &models.Budget{}))
+
+	suite.db = db
+	suite.svc = NewBudgetRolloverService(db)
+	suite.category = &models.Category{Name: "Dining", Type: models.CategoryTypeExpense}
+	assert.NoError(suite.T(), db.Create(suite.category).Error)
+}
+
+func (suite *BudgetRolloverTestSuite) createBudget(start time.Time, rollover bool) *models.Budget {
+	budget := &models.Budget{
+		Name:             "Dining",
+		CategoryID:       &suite.category.ID,
+		PeriodType:       models.BudgetPeriodMonthly,
+		PeriodStart:      start,
+		PeriodEnd:        BudgetPeriodEnd(models.BudgetPeriodMonthly, start),
+		LimitAmountCents: 20000,
+		RolloverEnabled:  rollover,
+		AlertThreshold:   0.80,
+		IsActive:         true,
+	}
+	assert.NoError(suite.T(), suite.db.Create(budget).Error)
+	return budget
+}
+
+func (suite *BudgetRolloverTestSuite) spend(date time.Time, cents int64) {
+	tx := &models.Transaction{
+		AccountID:   1,
+		Date:        date,
+		AmountCents: -cents,
+		CategoryID:  &suite.category.ID,
+		Type:        models.TransactionTypeExpense,
+	}
+	assert.NoError(suite.T(), suite.db.Create(tx).Error)
+}
+
+func (suite *BudgetRolloverTestSuite) TestRun_CarriesUnspentAmount() {
+	suite.createBudget(time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), true)
+	suite.spend(time.Date(2026, 9, 10, 0, 0, 0, 0, time.UTC), 15000)
+
+	result, err := suite.svc.Run(time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC), false)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Entries, 1)
+
+	next := result.Entries[0].Budget
+	assert.Equal(suite.T(), "2026-10-01", next.PeriodStart.Format("2006-01-02"))
+	assert.Equal(suite.T(), "2026-10-31", next.PeriodEnd.Format("2006-01-02"))
+	assert.Equal(suite.T(), int64(5000), next.RolloverAmountCents)
+	assert.True(suite.T(), next.IsActive)
+}
+
+func (suite *BudgetRolloverTestSuite) TestRun_CarriesOverspending() {
+	suite.createBudget(time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), true)
+	suite.spend(time.Date(2026, 9, 10, 0, 0, 0, 0, time.UTC), 25000)
+
+	result, err := suite.svc.Run(time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC), false)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(-5000), result.Entries[0].Budget.RolloverAmountCents)
+}
+
+func (suite *BudgetRolloverTestSuite) TestRun_WithoutRolloverStartsFresh() {
+	suite.createBudget(time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), false)
+
+	result, err := suite.svc.Run(time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC), false)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Entries, 1)
+	assert.Zero(suite.T(), result.Entries[0].Budget.RolloverAmountCents)
+}
+
+func (suite *BudgetRolloverTestSuite) TestRun_IsIdempotent() {
+	suite.createBudget(time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), true)
+	asOf := time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC)
+
+	_, err := suite.svc.Run(asOf, false)
+	assert.NoError(suite.T(), err)
+	result, err := suite.svc.Run(asOf, false)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), result.Entries)
+
+	var count int64
+	suite.db.Model(&models.Budget{}).Count(&count)
+	assert.Equal(suite.T(), int64(2), count)
+}
+
+func (suite *BudgetRolloverTestSuite) TestRun_BackfillsMissedPeriods() {
+	suite.createBudget(time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC), true)
+	suite.spend(time.Date(2026, 7, 15, 0, 0, 0, 0, time.UTC), 10000)
+	suite.spend(time.Date(2026, 8, 15, 0, 0, 0, 0, time.UTC), 25000)
+	suite.spend(time.Date(2026, 9, 15, 0, 0, 0, 0, time.UTC), 20000)
+
+	result, err := suite.svc.Run(time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC), false)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Entries, 3)
+
+	// July: 200-100 = +100; August: 200+100-250 = +50; September: 200+50-200 = +50
+	assert.Equal(suite.T(), int64(10000), result.Entries[0].CarriedCents)
+	assert.Equal(suite.T(), int64(5000), result.Entries[1].CarriedCents)
+	assert.Equal(suite.T(), int64(5000), result.Entries[2].CarriedCents)
+
+	var active []models.Budget
+	suite.db.Where("is_active = ?", true).Find(&active)
+	assert.Len(suite.T(), active, 1)
+	assert.Equal(suite.T(), "2026-10-01", active[0].PeriodStart.Format("2006-01-02"))
+}
+
+func (suite *BudgetRolloverTestSuite) TestRun_DryRunSavesNothing() {
+	suite.createBudget(time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), true)
+
+	result, err := suite.svc.Run(time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC), true)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Entries, 1)
+
+	var count int64
+	suite.db.Model(&models.Budget{}).Where("is_active = ?", true).Count(&count)
+	assert.Equal(suite.T(), int64(1), count)
+	suite.db.Model(&models.Budget{}).Count(&count)
+	assert.Equal(suite.T(), int64(1), count)
+}
+
+func TestBudgetRolloverTestSuite(t *testing.T) {
+	suite.Run(t, new(BudgetRolloverTestSuite))
+}
@@ -0,0 +1,261 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"gorm.io/gorm"
+)
+
+// Budget alert states reported by BudgetStatus
+const (
+	BudgetStateOK       = "ok"
+	BudgetStateWarning  = "warning"
+	BudgetStateExceeded = "exceeded"
+)
+
+// BudgetStatus describes spending against a budget for its period
+type BudgetStatus struct {
+	Budget         *models.Budget     `json:"budget"`
+	LimitCents     int64              `json:"limit_cents"` // LimitAmountCents plus any rollover
+	SpentCents     int64              `json:"spent_cents"`
+	RemainingCents int64              `json:"remaining_cents"`
+	PercentUsed    float64            `json:"percent_used"` // 0.0-1.0+, fraction of LimitCents
+	State          string             `json:"state"`        // ok, warning, exceeded
+	Breakdown      []CategorySpending `json:"breakdown,omitempty"`
+}
+
+// CategorySpending is the spending of one branch of a budget's category tree
+type CategorySpending struct {
+	CategoryID   uint    `json:"category_id"`
+	CategoryName string  `json:"category_name"`
+	SpentCents   int64   `json:"spent_cents"`  // Includes all descendants of the category
+	PercentUsed  float64 `json:"percent_used"` // Fraction of the parent budget's limit
+	Direct       bool    `json:"direct"`       // Spending booked directly on the budget's own category
+}
+
+// BudgetService computes budget status from the transactions table
+type BudgetService struct {
+	db           *gorm.DB
+	budgetRepo   *repositories.BudgetRepository
+	categoryRepo *repositories.CategoryRepository
+	txRepo       *repositories.TransactionRepository
+}
+
+// NewBudgetService creates a new budget service
+func NewBudgetService(db *gorm.DB) *BudgetService {
+	return &BudgetService{
+		db:           db,
+		budgetRepo:   repositories.NewBudgetRepository(db),
+		categoryRepo: repositories.NewCategoryRepository(db),
+		txRepo:       repositories.NewTransactionRepository(db),
+	}
+}
+
+// Status computes spent-vs-limit for a single budget over its period. Spending in
+// any descendant of the budget's category counts toward the limit, and the status
+// carries a breakdown per direct subcategory.
+func (s *BudgetService) Status(budget *models.Budget) (*BudgetStatus, error) {
+	if budget.CategoryID == nil {
+		spent, err := s.txRepo.GetSpendingInPeriod(nil, budget.PeriodStart, budget.PeriodEnd)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate spending: %w", err)
+		}
+		return NewBudgetStatus(budget, spent), nil
+	}
+
+	tree, err := s.categoryRepo.ListDescendants(*budget.CategoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve category tree: %w", err)
+	}
+
+	ids := make([]uint, 0, len(tree))
+	for _, c := range tree {
+		ids = append(ids, c.ID)
+	}
+
+	byCategory, err := s.txRepo.GetSpendingByCategory(ids, budget.PeriodStart, budget.PeriodEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate spending: %w", err)
+	}
+
+	var spent int64
+	for _, cents := range byCategory {
+		spent += cents
+	}
+
+	status := NewBudgetStatus(budget, spent)
+	status.Breakdown = categoryBreakdown(*budget.CategoryID, tree, byCategory, status.LimitCents)
+	return status, nil
+}
+
+// StatusAll computes status for every budget, optionally only active ones
+func (s *BudgetService) StatusAll(activeOnly bool) ([]*BudgetStatus, error) {
+	budgets, err := s.budgetRepo.List(activeOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*BudgetStatus, 0, len(budgets))
+	for _, budget := range budgets {
+		status, err := s.Status(budget)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// NewBudgetStatus builds a status from a budget and the amount spent in its period
+func NewBudgetStatus(budget *models.Budget, spentCents int64) *BudgetStatus {
+	limit := budget.LimitAmountCents + budget.RolloverAmountCents
+	status := &BudgetStatus{
+		Budget:         budget,
+		LimitCents:     limit,
+		SpentCents:     spentCents,
+		RemainingCents: limit - spentCents,
+	}
+
+	if limit > 0 {
+		status.PercentUsed = float64(spentCents) / float64(limit)
+	} else if spentCents > 0 {
+		status.PercentUsed = 1
+	}
+
+	switch {
+	case status.PercentUsed >= 1 && spentCents > 0:
+		status.State = BudgetStateExceeded
+	case budget.AlertThreshold > 0 && status.PercentUsed >= budget.AlertThreshold:
+		status.State = BudgetStateWarning
+	default:
+		status.State = BudgetStateOK
+	}
+
+	return status
+}
+
+// budgetSpending calculates spending for a budget's period across its whole category tree
+func budgetSpending(categoryRepo *repositories.CategoryRepository, txRepo *repositories.TransactionRepository, budget *models.Budget) (int64, error) {
+	if budget.CategoryID == nil {
+		return txRepo.GetSpendingInPeriod(nil, budget.PeriodStart, budget.PeriodEnd)
+	}
+
+	ids, err := categoryRepo.GetDescendantIDs(*budget.CategoryID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve category tree: %w", err)
+	}
+	if len(ids) == 0 {
+		ids = []uint{*budget.CategoryID}
+	}
+	return txRepo.GetSpendingInPeriod(ids, budget.PeriodStart, budget.PeriodEnd)
+}
+
+// categoryBreakdown sums per-category spending into one entry per direct child of
+// rootID, plus an entry for spending booked on the root category itself
+func categoryBreakdown(rootID uint, tree []*models.Category, byCategory map[uint]int64, limitCents int64) []CategorySpending {
+	children := make(map[uint][]*models.Category)
+	var root *models.Category
+	for _, c := range tree {
+		if c.ID == rootID {
+			root = c
+			continue
+		}
+		if c.ParentID != nil {
+			children[*c.ParentID] = append(children[*c.ParentID], c)
+		}
+	}
+
+	var subtreeTotal func(id uint, seen map[uint]bool) int64
+	subtreeTotal = func(id uint, seen map[uint]bool) int64 {
+		if seen[id] {
+			return 0
+		}
+		seen[id] = true
+		total := byCategory[id]
+		for _, child := range children[id] {
+			total += subtreeTotal(child.ID, seen)
+		}
+		return total
+	}
+
+	percent := func(cents int64) float64 {
+		if limitCents <= 0 {
+			return 0
+		}
+		return float64(cents) / float64(limitCents)
+	}
+
+	var breakdown []CategorySpending
+	if direct := byCategory[rootID]; direct != 0 && root != nil {
+		breakdown = append(breakdown, CategorySpending{
+			CategoryID:   root.ID,
+			CategoryName: root.Name,
+			SpentCents:   direct,
+			PercentUsed:  percent(direct),
+			Direct:       true,
+		})
+	}
+
+	seen := map[uint]bool{rootID: true}
+	for _, child := range children[rootID] {
+		cents := subtreeTotal(child.ID, seen)
+		breakdown = append(breakdown, CategorySpending{
+			CategoryID:   child.ID,
+			CategoryName: child.Name,
+			SpentCents:   cents,
+			PercentUsed:  percent(cents),
+		})
+	}
+
+	return breakdown
+}
+
+// IsValidBudgetPeriod reports whether periodType is a supported budget period
+func IsValidBudgetPeriod(periodType string) bool {
+	switch periodType {
+	case models.BudgetPeriodWeekly, models.BudgetPeriodMonthly,
+		models.BudgetPeriodQuarterly, models.BudgetPeriodAnnual:
+		return true
+	}
+	return false
+}
+
+// BudgetPeriodStart returns the start of the period of the given type containing date.
+// Weeks start on Monday.
+func BudgetPeriodStart(periodType string, date time.Time) time.Time {
+	d := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	switch periodType {
+	case models.BudgetPeriodWeekly:
+		offset := (int(d.Weekday()) + 6) % 7
+		return d.AddDate(0, 0, -offset)
+	case models.BudgetPeriodQuarterly:
+		quarterMonth := time.Month((int(d.Month())-1)/3*3 + 1)
+		return time.Date(d.Year(), quarterMonth, 1, 0, 0, 0, 0, time.UTC)
+	case models.BudgetPeriodAnnual:
+		return time.Date(d.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(d.Year(), d.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// BudgetPeriodEnd returns the last day (inclusive) of the period beginning at start
+func BudgetPeriodEnd(periodType string, start time.Time) time.Time {
+	return NextBudgetPeriodStart(periodType, start).AddDate(0, 0, -1)
+}
+
+// NextBudgetPeriodStart returns the first day of the period following the one starting at start
+func NextBudgetPeriodStart(periodType string, start time.Time) time.Time {
+	switch periodType {
+	case models.BudgetPeriodWeekly:
+		return start.AddDate(0, 0, 7)
+	case models.BudgetPeriodQuarterly:
+		return start.AddDate(0, 3, 0)
+	case models.BudgetPeriodAnnual:
+		return start.AddDate(1, 0, 0)
+	default:
+		return start.AddDate(0, 1, 0)
+	}
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestNewBudgetStatus(t *testing.T) {
+	budget := &models.Budget{LimitAmountCents: 50000, AlertThreshold: 0.80}
+
+	status := NewBudgetStatus(budget, 10000)
+	assert.Equal(t, int64(50000), status.LimitCents)
+	assert.Equal(t, int64(40000), status.RemainingCents)
+	assert.InDelta(t, 0.20, status.PercentUsed, 0.0001)
+	assert.Equal(t, BudgetStateOK, status.State)
+
+	status = NewBudgetStatus(budget, 40000)
+	assert.Equal(t, BudgetStateWarning, status.State)
+
+	status = NewBudgetStatus(budget, 60000)
+	assert.Equal(t, int64(-10000), status.RemainingCents)
+	assert.Equal(t, BudgetStateExceeded, status.State)
+}
+
+func TestNewBudgetStatus_IncludesRollover(t *testing.T) {
+	budget := &models.Budget{LimitAmountCents: 50000, RolloverAmountCents: 10000, AlertThreshold: 0.80}
+
+	status := NewBudgetStatus(budget, 50000)
+	assert.Equal(t, int64(60000), status.LimitCents)
+	assert.Equal(t, int64(10000), status.RemainingCents)
+	assert.Equal(t, BudgetStateWarning, status.State)
+}
+
+func TestIsValidBudgetPeriod(t *testing.T) {
+	assert.True(t, IsValidBudgetPeriod("weekly"))
+	assert.True(t, IsValidBudgetPeriod("monthly"))
+	assert.True(t, IsValidBudgetPeriod("quarterly"))
+	assert.True(t, IsValidBudgetPeriod("annual"))
+	assert.False(t, IsValidBudgetPeriod("daily"))
+}
+
+func TestBudgetPeriodBounds(t *testing.T) {
+	date := time.Date(2026, 8, 19, 15, 30, 0, 0, time.UTC) // Wednesday
+
+	tests := []struct {
+		period string
+		start  string
+		end    string
+	}{
+		{models.BudgetPeriodWeekly, "2026-08-17", "2026-08-23"},
+		{models.BudgetPeriodMonthly, "2026-08-01", "2026-08-31"},
+		{models.BudgetPeriodQuarterly, "2026-07-01", "2026-09-30"},
+		{models.BudgetPeriodAnnual, "2026-01-01", "2026-12-31"},
+	}
+
+	for _, tt := range tests {
+		start := BudgetPeriodStart(tt.period, date)
+		assert.Equal(t, tt.start, start.Format("2006-01-02"), tt.period)
+		assert.Equal(t, tt.end, BudgetPeriodEnd(tt.period, start).Format("2006-01-02"), tt.period)
+	}
+}
+
+func TestBudgetService_StatusRollsUpSubcategories(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&models.Category{}, &models.Transaction{}, &models.Budget{}))
+
+	food := &models.Category{Name: "Food & Dining", Type: models.CategoryTypeExpense}
+	db.Create(food)
+	restaurants := &models.Category{Name: "Restaurants", Type: models.CategoryTypeExpense, ParentID: &food.ID}
+	db.Create(restaurants)
+	fastFood := &models.Category{Name: "Fast Food", Type: models.CategoryTypeExpense, ParentID: &restaurants.ID}
+	db.Create(fastFood)
+	groceries := &models.Category{Name: "Groceries", Type: models.CategoryTypeExpense, ParentID: &food.ID}
+	db.Create(groceries)
+
+	day := time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)
+	for _, tx := range []*models.Transaction{
+		{AccountID: 1, Date: day, AmountCents: -1000, CategoryID: &food.ID, Type: models.TransactionTypeExpense},
+		{AccountID: 1, Date: day, AmountCents: -3000, CategoryID: &restaurants.ID, Type: models.TransactionTypeExpense},
+		{AccountID: 1, Date: day, AmountCents: -2000, CategoryID: &fastFood.ID, Type: models.TransactionTypeExpense},
+		{AccountID: 1, Date: day, AmountCents: -4000, CategoryID: &groceries.ID, Type: models.TransactionTypeExpense},
+	} {
+		assert.NoError(t, db.Create(tx).Error)
+	}
+
+	budget := &models.Budget{
+		Name:             "Food",
+		CategoryID:       &food.ID,
+		PeriodType:       models.BudgetPeriodMonthly,
+		PeriodStart:      time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:        time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC),
+		LimitAmountCents: 20000,
+		AlertThreshold:   0.80,
+	}
+
+	status, err := NewBudgetService(db).Status(budget)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10000), status.SpentCents)
+	assert.Len(t, status.Breakdown, 3)
+
+	byName := make(map[string]CategorySpending)
+	for _, b := range status.Breakdown {
+		byName[b.CategoryName] = b
+	}
+	assert.True(t, byName["Food & Dining"].Direct)
+	assert.Equal(t, int64(1000), byName["Food & Dining"].SpentCents)
+	assert.Equal(t, int64(5000), byName["Restaurants"].SpentCents)
+	assert.Equal(t, int64(4000), byName["Groceries"].SpentCents)
+	assert.InDelta(t, 0.25, byName["Restaurants"].PercentUsed, 0.0001)
+}
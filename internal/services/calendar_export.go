@@ -0,0 +1,211 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/fintrack/fintrack/internal/recurrence"
+)
+
+// icsProductID identifies FinTrack as the producer of exported calendars
+const icsProductID = "-//FinTrack//FinTrack Calendar//EN"
+
+// icsMaxLineOctets is the longest content line RFC 5545 allows before folding
+const icsMaxLineOctets = 75
+
+// icsPriorities maps reminder priorities to iCalendar PRIORITY values, where 1
+// is the highest
+var icsPriorities = map[string]int{
+	models.ReminderPriorityUrgent: 1,
+	models.ReminderPriorityHigh:   3,
+	models.ReminderPriorityNormal: 5,
+	models.ReminderPriorityLow:    9,
+}
+
+// ExportICS writes an iCalendar (RFC 5545) document to w with an all-day
+// repeating event for each active recurring item and an event for each
+// undismissed reminder. Recurring expenses carry an alarm ReminderDaysBefore
+// days ahead, which stands in for their bill reminders, so those aren't
+// exported separately. Event UIDs are derived from record IDs, so importing a
+// fresh export updates events rather than duplicating them.
+func (s *CalendarService) ExportICS(w io.Writer) error {
+	items, err := repositories.NewRecurringRepository(s.db).List(true)
+	if err != nil {
+		return fmt.Errorf("failed to list recurring items: %w", err)
+	}
+	reminders, err := repositories.NewReminderRepository(s.db).List(repositories.ReminderFilter{})
+	if err != nil {
+		return fmt.Errorf("failed to list reminders: %w", err)
+	}
+
+	ics := &icsBuilder{}
+	ics.line("BEGIN", "VCALENDAR")
+	ics.line("VERSION", "2.0")
+	ics.line("PRODID", icsProductID)
+	ics.line("CALSCALE", "GREGORIAN")
+	ics.line("X-WR-CALNAME", "FinTrack")
+	for _, item := range items {
+		s.writeRecurringEvent(ics, item)
+	}
+	for _, reminder := range reminders {
+		if reminder.Type == models.ReminderTypeBill {
+			continue
+		}
+		s.writeReminderEvent(ics, reminder)
+	}
+	ics.line("END", "VCALENDAR")
+
+	_, err = io.WriteString(w, ics.String())
+	return err
+}
+
+// writeRecurringEvent adds a repeating event for an item from its next due
+// date. A next date moved off the schedule is added as an extra date ahead of
+// the regular occurrences. Items whose schedule has ended are left out.
+func (s *CalendarService) writeRecurringEvent(ics *icsBuilder, item *models.RecurringItem) {
+	next := recurrence.Day(item.NextDate)
+	if item.EndDate != nil && next.After(recurrence.Day(*item.EndDate)) {
+		return
+	}
+
+	rule := recurrence.FromItem(item)
+	start, repeats := rule.OnOrAfter(next)
+	extra := repeats && !start.Equal(next)
+	if !repeats {
+		start = next
+	}
+
+	category := "Bill"
+	if item.AmountCents > 0 {
+		category = "Income"
+	}
+
+	ics.line("BEGIN", "VEVENT")
+	ics.line("UID", fmt.Sprintf("recurring-%d@fintrack", item.ID))
+	ics.line("DTSTAMP", icsTimestamp(item.UpdatedAt, s.now()))
+	ics.line("DTSTART;VALUE=DATE", start.Format("20060102"))
+	ics.line("DTEND;VALUE=DATE", start.AddDate(0, 0, 1).Format("20060102"))
+	if repeats {
+		ics.line("RRULE", rule.RRULE())
+	}
+	if extra {
+		ics.line("RDATE;VALUE=DATE", next.Format("20060102"))
+	}
+	ics.text("SUMMARY", fmt.Sprintf("%s (%s)", item.Name, icsAmount(item.AmountCents)))
+	if description := recurringDescription(item); description != "" {
+		ics.text("DESCRIPTION", description)
+	}
+	ics.line("CATEGORIES", category)
+	ics.line("TRANSP", "TRANSPARENT")
+	if item.AmountCents < 0 && item.ReminderDaysBefore > 0 {
+		ics.line("BEGIN", "VALARM")
+		ics.line("ACTION", "DISPLAY")
+		ics.text("DESCRIPTION", fmt.Sprintf("Bill due: %s", item.Name))
+		ics.line("TRIGGER", fmt.Sprintf("-P%dD", item.ReminderDaysBefore))
+		ics.line("END", "VALARM")
+	}
+	ics.line("END", "VEVENT")
+}
+
+// writeReminderEvent adds an event for a reminder: all day, or at its remind
+// time with an alarm when it has one
+func (s *CalendarService) writeReminderEvent(ics *icsBuilder, reminder *models.Reminder) {
+	day := recurrence.Day(reminder.RemindDate)
+
+	ics.line("BEGIN", "VEVENT")
+	ics.line("UID", fmt.Sprintf("reminder-%d@fintrack", reminder.ID))
+	ics.line("DTSTAMP", icsTimestamp(reminder.CreatedAt, s.now()))
+	if reminder.RemindTime != nil {
+		at := reminder.RemindTime
+		// Floating local time, so the alarm follows the calendar's time zone
+		ics.line("DTSTART", time.Date(day.Year(), day.Month(), day.Day(), at.Hour(), at.Minute(), 0, 0, time.UTC).Format("20060102T150405"))
+	} else {
+		ics.line("DTSTART;VALUE=DATE", day.Format("20060102"))
+		ics.line("DTEND;VALUE=DATE", day.AddDate(0, 0, 1).Format("20060102"))
+	}
+	ics.text("SUMMARY", reminder.Title)
+	if reminder.Message != "" {
+		ics.text("DESCRIPTION", reminder.Message)
+	}
+	ics.line("CATEGORIES", "Reminder")
+	if priority, ok := icsPriorities[reminder.Priority]; ok {
+		ics.line("PRIORITY", fmt.Sprintf("%d", priority))
+	}
+	ics.line("TRANSP", "TRANSPARENT")
+	if reminder.RemindTime != nil {
+		ics.line("BEGIN", "VALARM")
+		ics.line("ACTION", "DISPLAY")
+		ics.text("DESCRIPTION", reminder.Title)
+		ics.line("TRIGGER", "PT0S")
+		ics.line("END", "VALARM")
+	}
+	ics.line("END", "VEVENT")
+}
+
+// recurringDescription describes an item's account and notes
+func recurringDescription(item *models.RecurringItem) string {
+	var lines []string
+	if item.Account != nil {
+		lines = append(lines, "Account: "+item.Account.Name)
+	}
+	if item.Category != nil {
+		lines = append(lines, "Category: "+item.Category.Name)
+	}
+	if item.Description != "" {
+		lines = append(lines, item.Description)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// icsAmount formats a signed amount in dollars, such as "-$1500.00"
+func icsAmount(cents int64) string {
+	if cents < 0 {
+		return fmt.Sprintf("-$%.2f", models.CentsToDollars(-cents))
+	}
+	return fmt.Sprintf("+$%.2f", models.CentsToDollars(cents))
+}
+
+// icsTimestamp formats t as a UTC date-time, using fallback when t is unset.
+// Stamping events with when they last changed keeps repeated exports identical.
+func icsTimestamp(t, fallback time.Time) string {
+	if t.IsZero() {
+		t = fallback
+	}
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// icsBuilder accumulates iCalendar content lines, ending each with CRLF and
+// folding those longer than 75 octets
+type icsBuilder struct {
+	strings.Builder
+}
+
+// line adds a property whose value is already in iCalendar form
+func (b *icsBuilder) line(name, value string) {
+	line := name + ":" + value
+	limit := icsMaxLineOctets
+	for len(line) > limit {
+		// Fold on a character boundary; continuation lines start with a space
+		cut := limit
+		for !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		limit = icsMaxLineOctets - 1
+	}
+	b.WriteString(line + "\r\n")
+}
+
+// text adds a property with a free-text value, escaping it
+func (b *icsBuilder) text(name, value string) {
+	b.line(name, icsEscaper.Replace(value))
+}
+
+// icsEscaper escapes TEXT values as RFC 5545 requires
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"gorm.io/gorm"
+)
+
+// Calendar event kinds, each shown with its calendar.symbols entry
+const (
+	CalendarEventIncome      = "income"
+	CalendarEventExpense     = "expense"
+	CalendarEventTransfer    = "transfer"
+	CalendarEventBill        = "bill"
+	CalendarEventReminder    = "reminder"
+	CalendarEventBudgetAlert = "budget_alert"
+)
+
+// upcomingHorizonDays is how far ahead Upcoming looks for scheduled items
+const upcomingHorizonDays = 366
+
+// calendarEventRank orders the events of one day: money in, then money out,
+// then reminders
+var calendarEventRank = map[string]int{
+	CalendarEventIncome:      0,
+	CalendarEventBill:        1,
+	CalendarEventExpense:     2,
+	CalendarEventTransfer:    3,
+	CalendarEventBudgetAlert: 4,
+	CalendarEventReminder:    5,
+}
+
+// CalendarEvent is a posted transaction, an expected occurrence of a recurring
+// item, or a reminder on one day
+type CalendarEvent struct {
+	Date          time.Time `json:"date"`
+	Kind          string    `json:"kind"` // income, expense, transfer, bill, reminder, budget_alert
+	Title         string    `json:"title"`
+	AmountCents   *int64    `json:"amount_cents,omitempty"` // Signed; nil for reminders
+	Scheduled     bool      `json:"scheduled"`              // Expected from a recurring item, not posted yet
+	Account       string    `json:"account,omitempty"`
+	TransactionID *uint     `json:"transaction_id,omitempty"`
+	RecurringID   *uint     `json:"recurring_id,omitempty"`
+	ReminderID    *uint     `json:"reminder_id,omitempty"`
+}
+
+// CalendarDay holds the events of one day
+type CalendarDay struct {
+	Date     time.Time        `json:"date"`
+	NetCents int64            `json:"net_cents"` // Income less expenses, posted and scheduled; transfers excluded
+	Events   []*CalendarEvent `json:"events"`
+}
+
+// CalendarMonth holds every day of one calendar month
+type CalendarMonth struct {
+	Month         time.Time      `json:"month"` // First of the month
+	Days          []*CalendarDay `json:"days"`
+	IncomeCents   int64          `json:"income_cents"`
+	ExpensesCents int64          `json:"expenses_cents"` // Positive
+	NetCents      int64          `json:"net_cents"`
+}
+
+// CalendarService gathers posted transactions, scheduled recurring items and
+// reminders into calendar events
+type CalendarService struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+// NewCalendarService creates a new calendar service
+func NewCalendarService(db *gorm.DB) *CalendarService {
+	return &CalendarService{db: db, now: time.Now}
+}
+
+// Month returns the events of every day in the calendar month containing month:
+// posted transactions, occurrences of active recurring items from their next
+// due date, and undismissed reminders. A posted expense from a recurring item
+// is a bill, like its scheduled occurrences.
+func (s *CalendarService) Month(month time.Time) (*CalendarMonth, error) {
+	from := MonthStart(month)
+	to := from.AddDate(0, 1, -1)
+
+	events, err := s.postedEvents(from, to)
+	if err != nil {
+		return nil, err
+	}
+	scheduled, err := s.scheduledEvents(from, to)
+	if err != nil {
+		return nil, err
+	}
+	reminders, err := s.reminderEvents(from, to)
+	if err != nil {
+		return nil, err
+	}
+	events = append(append(events, scheduled...), reminders...)
+	sortCalendarEvents(events)
+
+	cal := &CalendarMonth{Month: from}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		cal.Days = append(cal.Days, &CalendarDay{Date: day, Events: []*CalendarEvent{}})
+	}
+	for _, event := range events {
+		day := cal.Days[event.Date.Day()-1]
+		day.Events = append(day.Events, event)
+		if event.AmountCents == nil || event.Kind == CalendarEventTransfer {
+			continue
+		}
+		day.NetCents += *event.AmountCents
+		if *event.AmountCents > 0 {
+			cal.IncomeCents += *event.AmountCents
+		} else {
+			cal.ExpensesCents -= *event.AmountCents
+		}
+	}
+	cal.NetCents = cal.IncomeCents - cal.ExpensesCents
+	return cal, nil
+}
+
+// Upcoming returns the next count scheduled recurring occurrences and
+// undismissed reminders from today on, soonest first
+func (s *CalendarService) Upcoming(count int) ([]*CalendarEvent, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("count must be positive")
+	}
+
+	today := startOfDayUTC(s.now())
+	through := today.AddDate(0, 0, upcomingHorizonDays)
+
+	events, err := s.scheduledEvents(today, through)
+	if err != nil {
+		return nil, err
+	}
+	reminders, err := s.reminderEvents(today, through)
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, reminders...)
+	sortCalendarEvents(events)
+
+	if len(events) > count {
+		events = events[:count]
+	}
+	return events, nil
+}
+
+// postedEvents lists the transactions dated between from and to
+func (s *CalendarService) postedEvents(from, to time.Time) ([]*CalendarEvent, error) {
+	transactions, err := repositories.NewTransactionRepository(s.db).List(repositories.TransactionFilter{
+		DateFrom: &from,
+		DateTo:   &to,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transactions: %w", err)
+	}
+
+	events := make([]*CalendarEvent, 0, len(transactions))
+	for _, tx := range transactions {
+		kind := tx.Type
+		if kind == models.TransactionTypeExpense && tx.RecurringID != nil {
+			kind = CalendarEventBill
+		}
+		id, amount := tx.ID, tx.AmountCents
+		event := &CalendarEvent{
+			Date:          startOfDayUTC(tx.Date),
+			Kind:          kind,
+			Title:         transactionTitle(tx),
+			AmountCents:   &amount,
+			TransactionID: &id,
+			RecurringID:   tx.RecurringID,
+		}
+		if tx.Account != nil {
+			event.Account = tx.Account.Name
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// scheduledEvents lists the expected occurrences of active recurring items
+// between from and through
+func (s *CalendarService) scheduledEvents(from, through time.Time) ([]*CalendarEvent, error) {
+	items, err := repositories.NewRecurringRepository(s.db).List(true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recurring items: %w", err)
+	}
+
+	var events []*CalendarEvent
+	for _, item := range items {
+		kind := CalendarEventBill
+		if item.AmountCents > 0 {
+			kind = CalendarEventIncome
+		}
+		for _, date := range itemOccurrences(item, through) {
+			if date.Before(from) {
+				continue
+			}
+			id, amount := item.ID, item.AmountCents
+			event := &CalendarEvent{
+				Date:        date,
+				Kind:        kind,
+				Title:       item.Name,
+				AmountCents: &amount,
+				Scheduled:   true,
+				RecurringID: &id,
+			}
+			if item.Account != nil {
+				event.Account = item.Account.Name
+			}
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// reminderEvents lists the undismissed reminders due between from and through
+func (s *CalendarService) reminderEvents(from, through time.Time) ([]*CalendarEvent, error) {
+	reminders, err := repositories.NewReminderRepository(s.db).List(repositories.ReminderFilter{
+		From:    &from,
+		Through: &through,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reminders: %w", err)
+	}
+
+	events := make([]*CalendarEvent, 0, len(reminders))
+	for _, reminder := range reminders {
+		kind := CalendarEventReminder
+		if reminder.Type == models.ReminderTypeBudget {
+			kind = CalendarEventBudgetAlert
+		}
+		id := reminder.ID
+		events = append(events, &CalendarEvent{
+			Date:       startOfDayUTC(reminder.RemindDate),
+			Kind:       kind,
+			Title:      reminder.Title,
+			ReminderID: &id,
+		})
+	}
+	return events, nil
+}
+
+// transactionTitle names a transaction by its payee, description or category
+func transactionTitle(tx *models.Transaction) string {
+	switch {
+	case tx.Payee != "":
+		return tx.Payee
+	case tx.Description != "":
+		return tx.Description
+	case tx.Category != nil:
+		return tx.Category.Name
+	case tx.Type != "":
+		return strings.ToUpper(tx.Type[:1]) + tx.Type[1:]
+	}
+	return "Transaction"
+}
+
+// sortCalendarEvents orders events by day, then kind, then title
+func sortCalendarEvents(events []*CalendarEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if !a.Date.Equal(b.Date) {
+			return a.Date.Before(b.Date)
+		}
+		if calendarEventRank[a.Kind] != calendarEventRank[b.Kind] {
+			return calendarEventRank[a.Kind] < calendarEventRank[b.Kind]
+		}
+		return a.Title < b.Title
+	})
+}
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// CalendarTestSuite is the test suite for the calendar service
+type CalendarTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	svc     *CalendarService
+	account *models.Account
+}
+
+// SetupTest runs before each test
+func (suite *CalendarTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), db.AutoMigrate(&models.Account{}, &models.Category{}, &models.Transaction{},
+		&models.RecurringItem{}, &models.Reminder{}))
+
+	suite.db = db
+	suite.svc = NewCalendarService(db)
+	suite.svc.now = func() time.Time { return time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC) }
+
+	suite.account = &models.Account{Name: "Checking", Type: models.AccountTypeChecking, Currency: "USD", IsActive: true}
+	assert.NoError(suite.T(), repositories.NewAccountRepository(db).Create(suite.account))
+
+	salary := suite.createItem("Salary", 300000, models.FrequencyBiweekly, "2026-10-23")
+	rent := suite.createItem("Rent", -150000, models.FrequencyMonthly, "2026-11-01")
+
+	suite.record("2026-10-01", -150000, models.TransactionTypeExpense, "Rent", &rent.ID)
+	suite.record("2026-10-05", -5000, models.TransactionTypeExpense, "Grocer", nil)
+	suite.record("2026-10-09", 300000, models.TransactionTypeIncome, "Salary", &salary.ID)
+	suite.record("2026-10-12", -20000, models.TransactionTypeTransfer, "", nil)
+
+	reminders := repositories.NewReminderRepository(db)
+	assert.NoError(suite.T(), reminders.Create(&models.Reminder{Type: models.ReminderTypeCustom, Title: "Renew card", RemindDate: suite.date("2026-10-20")}))
+	assert.NoError(suite.T(), reminders.Create(&models.Reminder{Type: models.ReminderTypeBudget, Title: "Dining at 90%", RemindDate: suite.date("2026-10-18")}))
+	dismissed := &models.Reminder{Type: models.ReminderTypeCustom, Title: "Old news", RemindDate: suite.date("2026-10-19")}
+	assert.NoError(suite.T(), reminders.Create(dismissed))
+	assert.NoError(suite.T(), reminders.Dismiss(dismissed.ID))
+}
+
+func (suite *CalendarTestSuite) date(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	assert.NoError(suite.T(), err)
+	return t
+}
+
+func (suite *CalendarTestSuite) createItem(name string, cents int64, frequency, next string) *models.RecurringItem {
+	item := &models.RecurringItem{
+		AccountID:         suite.account.ID,
+		Name:              name,
+		AmountCents:       cents,
+		Frequency:         frequency,
+		FrequencyInterval: 1,
+		StartDate:         suite.date(next),
+		NextDate:          suite.date(next),
+		IsActive:          true,
+	}
+	assert.NoError(suite.T(), repositories.NewRecurringRepository(suite.db).Create(item))
+	return item
+}
+
+func (suite *CalendarTestSuite) record(date string, cents int64, txType, payee string, recurringID *uint) {
+	tx := &models.Transaction{
+		AccountID:   suite.account.ID,
+		Date:        suite.date(date),
+		AmountCents: cents,
+		Type:        txType,
+		Payee:       payee,
+		RecurringID: recurringID,
+		Tags:        models.StringArray{},
+	}
+	assert.NoError(suite.T(), suite.db.Create(tx).Error)
+}
+
+func (suite *CalendarTestSuite) TestMonth() {
+	cal, err := suite.svc.Month(suite.date("2026-10-16"))
+	assert.NoError(suite.T(), err)
+
+	assert.Equal(suite.T(), "2026-10-01", cal.Month.Format("2006-01-02"))
+	assert.Len(suite.T(), cal.Days, 31)
+
+	// A posted recurring expense is a bill
+	first := cal.Days[0]
+	if assert.Len(suite.T(), first.Events, 1) {
+		assert.Equal(suite.T(), CalendarEventBill, first.Events[0].Kind)
+		assert.Equal(suite.T(), "Checking", first.Events[0].Account)
+		assert.False(suite.T(), first.Events[0].Scheduled)
+	}
+	assert.Equal(suite.T(), int64(-150000), first.NetCents)
+
+	// Transfers don't count toward the daily net
+	transfer := cal.Days[11]
+	assert.Equal(suite.T(), "Transfer", transfer.Events[0].Title)
+	assert.Zero(suite.T(), transfer.NetCents)
+
+	assert.Equal(suite.T(), CalendarEventBudgetAlert, cal.Days[17].Events[0].Kind)
+	assert.Empty(suite.T(), cal.Days[18].Events)
+	assert.Equal(suite.T(), CalendarEventReminder, cal.Days[19].Events[0].Kind)
+	assert.Nil(suite.T(), cal.Days[19].Events[0].AmountCents)
+
+	// The next paycheck is scheduled
+	payday := cal.Days[22]
+	if assert.Len(suite.T(), payday.Events, 1) {
+		assert.Equal(suite.T(), CalendarEventIncome, payday.Events[0].Kind)
+		assert.True(suite.T(), payday.Events[0].Scheduled)
+	}
+	assert.Equal(suite.T(), int64(300000), payday.NetCents)
+
+	assert.Equal(suite.T(), int64(600000), cal.IncomeCents)
+	assert.Equal(suite.T(), int64(155000), cal.ExpensesCents)
+	assert.Equal(suite.T(), int64(445000), cal.NetCents)
+}
+
+func (suite *CalendarTestSuite) TestMonthSchedulesAhead() {
+	cal, err := suite.svc.Month(suite.date("2026-12-01"))
+	assert.NoError(suite.T(), err)
+
+	var titles []string
+	for _, day := range cal.Days {
+		for _, event := range day.Events {
+			titles = append(titles, event.Date.Format("01-02")+" "+event.Title)
+		}
+	}
+	assert.Equal(suite.T(), []string{"12-01 Rent", "12-04 Salary", "12-18 Salary"}, titles)
+}
+
+func (suite *CalendarTestSuite) TestUpcoming() {
+	events, err := suite.svc.Upcoming(5)
+	assert.NoError(suite.T(), err)
+
+	var titles []string
+	for _, event := range events {
+		titles = append(titles, event.Date.Format("01-02")+" "+event.Title)
+	}
+	assert.Equal(suite.T(), []string{
+		"10-18 Dining at 90%",
+		"10-20 Renew card",
+		"10-23 Salary",
+		"11-01 Rent",
+		"11-06 Salary",
+	}, titles)
+
+	_, err = suite.svc.Upcoming(0)
+	assert.EqualError(suite.T(), err, "count must be positive")
+}
+
+func (suite *CalendarTestSuite) TestExportICS() {
+	// A bill reminder is covered by the alarm on its item
+	assert.NoError(suite.T(), repositories.NewReminderRepository(suite.db).Create(&models.Reminder{
+		Type: models.ReminderTypeBill, Title: "Bill due: Rent", RemindDate: suite.date("2026-10-29"),
+	}))
+
+	var out bytes.Buffer
+	assert.NoError(suite.T(), suite.svc.ExportICS(&out))
+	ics := out.String()
+
+	assert.True(suite.T(), strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(suite.T(), strings.HasSuffix(ics, "END:VEVENT\r\nEND:VCALENDAR\r\n"))
+	assert.Equal(suite.T(), 4, strings.Count(ics, "BEGIN:VEVENT"))
+	assert.NotContains(suite.T(), ics, "SUMMARY:Bill due")
+	assert.NotContains(suite.T(), ics, "Old news")
+
+	assert.Contains(suite.T(), ics, "UID:recurring-2@fintrack\r\n"+
+		"DTSTAMP:")
+	assert.Contains(suite.T(), ics, "DTSTART;VALUE=DATE:20261101\r\n"+
+		"DTEND;VALUE=DATE:20261102\r\n"+
+		"RRULE:FREQ=MONTHLY;BYMONTHDAY=1\r\n"+
+		"SUMMARY:Rent (-$1500.00)\r\n"+
+		"DESCRIPTION:Account: Checking\r\n"+
+		"CATEGORIES:Bill\r\n"+
+		"TRANSP:TRANSPARENT\r\n"+
+		"BEGIN:VALARM\r\n"+
+		"ACTION:DISPLAY\r\n"+
+		"DESCRIPTION:Bill due: Rent\r\n"+
+		"TRIGGER:-P3D\r\n"+
+		"END:VALARM\r\n")
+
+	// Paydays have no alarm
+	assert.Contains(suite.T(), ics, "RRULE:FREQ=WEEKLY;INTERVAL=2\r\nSUMMARY:Salary (+$3000.00)\r\n")
+	assert.Equal(suite.T(), 1, strings.Count(ics, "BEGIN:VALARM"))
+
+	assert.Contains(suite.T(), ics, "UID:reminder-1@fintrack\r\n")
+	assert.Contains(suite.T(), ics, "SUMMARY:Dining at 90%\r\nCATEGORIES:Reminder\r\nPRIORITY:5\r\n")
+
+	// Exporting again gives the same document
+	var again bytes.Buffer
+	assert.NoError(suite.T(), suite.svc.ExportICS(&again))
+	assert.Equal(suite.T(), ics, again.String())
+}
+
+func (suite *CalendarTestSuite) TestExportICS_MovedNextDate() {
+	var rent models.RecurringItem
+	assert.NoError(suite.T(), suite.db.Where("name = ?", "Rent").First(&rent).Error)
+	assert.NoError(suite.T(), suite.db.Model(&rent).Update("next_date", suite.date("2026-11-03")).Error)
+
+	var out bytes.Buffer
+	assert.NoError(suite.T(), suite.svc.ExportICS(&out))
+	assert.Contains(suite.T(), out.String(), "DTSTART;VALUE=DATE:20261201\r\n"+
+		"DTEND;VALUE=DATE:20261202\r\n"+
+		"RRULE:FREQ=MONTHLY;BYMONTHDAY=1\r\n"+
+		"RDATE;VALUE=DATE:20261103\r\n")
+}
+
+func TestICSBuilder_FoldsAndEscapes(t *testing.T) {
+	ics := &icsBuilder{}
+	ics.text("SUMMARY", "Rent; utilities, and \\ fees\nPaid by check")
+	assert.Equal(t, "SUMMARY:Rent\\; utilities\\, and \\\\ fees\\nPaid by check\r\n", ics.String())
+
+	ics = &icsBuilder{}
+	ics.line("DESCRIPTION", strings.Repeat("é", 60))
+	lines := strings.Split(strings.TrimSuffix(ics.String(), "\r\n"), "\r\n")
+	if assert.Len(t, lines, 2) {
+		assert.Len(t, lines[0], 74) // "DESCRIPTION:" and 31 two-octet characters
+		assert.True(t, strings.HasPrefix(lines[1], " é"))
+		assert.LessOrEqual(t, len(lines[1]), icsMaxLineOctets)
+	}
+}
+
+func TestCalendarTestSuite(t *testing.T) {
+	suite.Run(t, new(CalendarTestSuite))
+}
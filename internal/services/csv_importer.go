@@ -42,13 +42,15 @@ func DefaultColumnMapping() CSVColumnMapping {
 }
 
 type ImportResult struct {
-	TotalRecords    int
-	ImportedRecords int
-	SkippedRecords  int
-	FailedRecords   int
-	Transactions    []*models.Transaction
-	Errors          []ImportError
-	FileHash        string
+	TotalRecords     int
+	ImportedRecords  int
+	SkippedRecords   int
+	FailedRecords    int
+	MatchedRecurring int // Rows linked to a recurring item's expected payment
+	Transactions     []*models.Transaction
+	Errors           []ImportError
+	OverdueBills     []OverdueBill
+	FileHash         string
 }
 
 type ImportError struct {
@@ -62,6 +64,7 @@ type CSVImporter struct {
 	txRepo      *repositories.TransactionRepository
 	historyRepo *repositories.ImportHistoryRepository
 	accountRepo *repositories.AccountRepository
+	now         func() time.Time
 }
 
 func NewCSVImporter(db *gorm.DB) *CSVImporter {
@@ -70,6 +73,7 @@ func NewCSVImporter(db *gorm.DB) *CSVImporter {
 		txRepo:      repositories.NewTransactionRepository(db),
 		historyRepo: repositories.NewImportHistoryRepository(db),
 		accountRepo: repositories.NewAccountRepository(db),
+		now:         time.Now,
 	}
 }
 
@@ -79,6 +83,15 @@ type ImportOptions struct {
 	DryRun         bool
 	SkipDuplicates bool
 	BatchSize      int
+
+	// MatchRecurring links rows to the expected payments of the account's
+	// recurring items: same direction, a similar payee, an amount within
+	// MatchTolerance (default 0.10) and a date within MatchWindowDays (default 5)
+	// of a due date or a generated transaction. Matched items advance past the
+	// payment, and generated transactions are replaced by the imported rows.
+	MatchRecurring  bool
+	MatchWindowDays int
+	MatchTolerance  float64
 }
 
 func (i *CSVImporter) Import(filePath string, opts ImportOptions) (*ImportResult, error) {
@@ -118,6 +131,17 @@ func (i *CSVImporter) Import(filePath string, opts ImportOptions) (*ImportResult
 		return nil, err
 	}
 	result.FileHash = hash
+	result.OverdueBills = []OverdueBill{}
+
+	var matcher *recurringMatcher
+	if opts.MatchRecurring {
+		matcher, err = newRecurringMatcher(i.db, account.ID, result.Transactions, opts, i.now())
+		if err != nil {
+			return nil, err
+		}
+		result.MatchedRecurring = matcher.match(result.Transactions)
+		result.OverdueBills = matcher.overdue()
+	}
 
 	if opts.DryRun {
 		return result, nil
@@ -155,6 +179,12 @@ func (i *CSVImporter) Import(filePath string, opts ImportOptions) (*ImportResult
 				return fmt.Errorf("failed to create transactions: %w", err)
 			}
 		}
+
+		if matcher != nil {
+			if err := matcher.apply(tx); err != nil {
+				return fmt.Errorf("failed to update recurring items: %w", err)
+			}
+		}
 		return nil
 	})
 
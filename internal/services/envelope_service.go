@@ -0,0 +1,234 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"gorm.io/gorm"
+)
+
+// envelopeAccountTypes are the account types whose money is budgeted in envelopes.
+// Investment and loan accounts are tracked but stay off-budget.
+var envelopeAccountTypes = map[string]bool{
+	models.AccountTypeChecking: true,
+	models.AccountTypeSavings:  true,
+	models.AccountTypeCash:     true,
+	models.AccountTypeCredit:   true,
+}
+
+// EnvelopeBalance is the state of one category envelope for a month
+type EnvelopeBalance struct {
+	CategoryID     uint   `json:"category_id"`
+	CategoryName   string `json:"category_name"`
+	AssignedCents  int64  `json:"assigned_cents"`  // Assigned or moved in during the month
+	ActivityCents  int64  `json:"activity_cents"`  // Signed transaction total during the month
+	AvailableCents int64  `json:"available_cents"` // Cumulative balance at month end
+}
+
+// AccountReconciliation compares an account's stored balance with its transaction ledger
+type AccountReconciliation struct {
+	AccountID       uint   `json:"account_id"`
+	AccountName     string `json:"account_name"`
+	LedgerCents     int64  `json:"ledger_cents"` // Initial balance plus all transactions
+	CurrentCents    int64  `json:"current_cents"`
+	DifferenceCents int64  `json:"difference_cents"`
+}
+
+// EnvelopeState is the zero-based budget for a month. Funds always equal the sum
+// of envelope balances plus ToBeBudgetedCents.
+type EnvelopeState struct {
+	Month             time.Time               `json:"month"`
+	FundsCents        int64                   `json:"funds_cents"` // On-budget account balances at month end
+	ToBeBudgetedCents int64                   `json:"to_be_budgeted_cents"`
+	Envelopes         []EnvelopeBalance       `json:"envelopes"`
+	Accounts          []AccountReconciliation `json:"accounts"`
+	Reconciled        bool                    `json:"reconciled"` // Every ledger matches its stored balance
+}
+
+// Envelope returns the balance of a single category envelope, or nil if absent
+func (s *EnvelopeState) Envelope(categoryID uint) *EnvelopeBalance {
+	for i := range s.Envelopes {
+		if s.Envelopes[i].CategoryID == categoryID {
+			return &s.Envelopes[i]
+		}
+	}
+	return nil
+}
+
+// EnvelopeService implements envelope (zero-based) budgeting alongside limit budgets
+type EnvelopeService struct {
+	db           *gorm.DB
+	envelopeRepo *repositories.EnvelopeRepository
+	accountRepo  *repositories.AccountRepository
+	categoryRepo *repositories.CategoryRepository
+	txRepo       *repositories.TransactionRepository
+}
+
+// NewEnvelopeService creates a new envelope service
+func NewEnvelopeService(db *gorm.DB) *EnvelopeService {
+	return &EnvelopeService{
+		db:           db,
+		envelopeRepo: repositories.NewEnvelopeRepository(db),
+		accountRepo:  repositories.NewAccountRepository(db),
+		categoryRepo: repositories.NewCategoryRepository(db),
+		txRepo:       repositories.NewTransactionRepository(db),
+	}
+}
+
+// Available computes the envelope state for the month containing month
+func (s *EnvelopeService) Available(month time.Time) (*EnvelopeState, error) {
+	start := MonthStart(month)
+	end := start.AddDate(0, 1, -1)
+
+	accounts, err := s.accountRepo.List(true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+
+	var accountIDs []uint
+	var budgetAccounts []*models.Account
+	for _, a := range accounts {
+		if envelopeAccountTypes[a.Type] {
+			accountIDs = append(accountIDs, a.ID)
+			budgetAccounts = append(budgetAccounts, a)
+		}
+	}
+
+	state := &EnvelopeState{Month: start, Envelopes: []EnvelopeBalance{}, Accounts: []AccountReconciliation{}, Reconciled: true}
+
+	// Funds at month end, and reconciliation of the full ledger against stored balances
+	var fundsActivity, ledgerActivity map[uint]int64
+	var cumulativeActivity, monthActivity map[uint]int64
+	if len(accountIDs) > 0 {
+		if fundsActivity, err = s.txRepo.GetActivityByAccount(accountIDs, &end); err != nil {
+			return nil, fmt.Errorf("failed to calculate account activity: %w", err)
+		}
+		if ledgerActivity, err = s.txRepo.GetActivityByAccount(accountIDs, nil); err != nil {
+			return nil, fmt.Errorf("failed to calculate account activity: %w", err)
+		}
+		if cumulativeActivity, err = s.txRepo.GetActivityByCategory(accountIDs, nil, end); err != nil {
+			return nil, fmt.Errorf("failed to calculate category activity: %w", err)
+		}
+		if monthActivity, err = s.txRepo.GetActivityByCategory(accountIDs, &start, end); err != nil {
+			return nil, fmt.Errorf("failed to calculate category activity: %w", err)
+		}
+	}
+
+	for _, a := range budgetAccounts {
+		state.FundsCents += a.InitialBalanceCents + fundsActivity[a.ID]
+
+		ledger := a.InitialBalanceCents + ledgerActivity[a.ID]
+		rec := AccountReconciliation{
+			AccountID:       a.ID,
+			AccountName:     a.Name,
+			LedgerCents:     ledger,
+			CurrentCents:    a.CurrentBalanceCents,
+			DifferenceCents: a.CurrentBalanceCents - ledger,
+		}
+		if rec.DifferenceCents != 0 {
+			state.Reconciled = false
+		}
+		state.Accounts = append(state.Accounts, rec)
+	}
+
+	cumulativeAssigned, err := s.envelopeRepo.SumByCategory(nil, start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum allocations: %w", err)
+	}
+	monthAssigned, err := s.envelopeRepo.SumByCategory(&start, start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum allocations: %w", err)
+	}
+
+	categories, err := s.categoryRepo.List("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+
+	// Envelopes are every category with money assigned plus every expense category
+	// with activity; other activity (income, uncategorized) flows to be budgeted
+	var envelopeTotal int64
+	for _, c := range categories {
+		_, assigned := cumulativeAssigned[c.ID]
+		_, spent := cumulativeActivity[c.ID]
+		if !assigned && !(spent && c.Type == models.CategoryTypeExpense) {
+			continue
+		}
+
+		balance := EnvelopeBalance{
+			CategoryID:     c.ID,
+			CategoryName:   c.Name,
+			AssignedCents:  monthAssigned[c.ID],
+			ActivityCents:  monthActivity[c.ID],
+			AvailableCents: cumulativeAssigned[c.ID] + cumulativeActivity[c.ID],
+		}
+		envelopeTotal += balance.AvailableCents
+		state.Envelopes = append(state.Envelopes, balance)
+	}
+
+	sort.Slice(state.Envelopes, func(i, j int) bool {
+		return state.Envelopes[i].CategoryName < state.Envelopes[j].CategoryName
+	})
+
+	state.ToBeBudgetedCents = state.FundsCents - envelopeTotal
+	return state, nil
+}
+
+// Assign moves money from to-be-budgeted into a category envelope. A negative
+// amount returns money from the envelope to to-be-budgeted.
+func (s *EnvelopeService) Assign(categoryID uint, month time.Time, amountCents int64, note string) (*EnvelopeState, error) {
+	if amountCents == 0 {
+		return nil, fmt.Errorf("amount cannot be zero")
+	}
+
+	allocation := &models.EnvelopeAllocation{
+		Month:       MonthStart(month),
+		CategoryID:  categoryID,
+		AmountCents: amountCents,
+		Kind:        models.AllocationKindAssign,
+		Note:        note,
+	}
+	if err := s.envelopeRepo.Create(allocation); err != nil {
+		return nil, fmt.Errorf("failed to assign money: %w", err)
+	}
+
+	return s.Available(month)
+}
+
+// Move transfers money between two category envelopes. The source envelope must
+// have at least amountCents available at the end of the month.
+func (s *EnvelopeService) Move(fromCategoryID, toCategoryID uint, month time.Time, amountCents int64, note string) (*EnvelopeState, error) {
+	if amountCents <= 0 {
+		return nil, fmt.Errorf("amount must be greater than zero")
+	}
+	if fromCategoryID == toCategoryID {
+		return nil, fmt.Errorf("cannot move money to the same envelope")
+	}
+
+	state, err := s.Available(month)
+	if err != nil {
+		return nil, err
+	}
+
+	var available int64
+	if from := state.Envelope(fromCategoryID); from != nil {
+		available = from.AvailableCents
+	}
+	if available < amountCents {
+		return nil, fmt.Errorf("insufficient funds in envelope: %.2f available", models.CentsToDollars(available))
+	}
+
+	if err := s.envelopeRepo.CreateMove(fromCategoryID, toCategoryID, MonthStart(month), amountCents, note); err != nil {
+		return nil, fmt.Errorf("failed to move money: %w", err)
+	}
+
+	return s.Available(month)
+}
+
+// MonthStart returns the first day of the month containing t, in UTC
+func MonthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// EnvelopeServiceTestSuite is the test suite for envelope budgeting
+type EnvelopeServiceTestSuite struct {
+	suite.Suite
+	db        *gorm.DB
+	svc       *EnvelopeService
+	checking  *models.Account
+	groceries *models.Category
+	dining    *models.Category
+	salary    *models.Category
+}
+
+// SetupTest runs before each test
+func (suite *EnvelopeServiceTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), db.AutoMigrate(&models.Account{}, &models.Category{}, &models.Transaction{}, &models.EnvelopeAllocation{}))
+
+	suite.db = db
+	suite.svc = NewEnvelopeService(db)
+
+	suite.checking = &models.Account{Name: "Checking", Type: models.AccountTypeChecking, Currency: "USD", InitialBalanceCents: 100000, IsActive: true}
+	assert.NoError(suite.T(), repositories.NewAccountRepository(db).Create(suite.checking))
+
+	loan := &models.Account{Name: "Mortgage", Type: models.AccountTypeLoan, Currency: "USD", InitialBalanceCents: -5000000, IsActive: true}
+	assert.NoError(suite.T(), repositories.NewAccountRepository(db).Create(loan))
+
+	suite.groceries = &models.Category{Name: "Groceries", Type: models.CategoryTypeExpense}
+	suite.dining = &models.Category{Name: "Dining", Type: models.CategoryTypeExpense}
+	suite.salary = &models.Category{Name: "Salary", Type: models.CategoryTypeIncome}
+	for _, c := range []*models.Category{suite.groceries, suite.dining, suite.salary} {
+		assert.NoError(suite.T(), db.Create(c).Error)
+	}
+}
+
+func (suite *EnvelopeServiceTestSuite) record(date time.Time, cents int64, category *models.Category) {
+	txType := models.TransactionTypeExpense
+	if cents > 0 {
+		txType = models.TransactionTypeIncome
+	}
+	tx := &models.Transaction{
+		AccountID:   suite.checking.ID,
+		Date:        date,
+		AmountCents: cents,
+		CategoryID:  &category.ID,
+		Type:        txType,
+	}
+	assert.NoError(suite.T(), repositories.NewTransactionRepository(suite.db).Create(tx))
+}
+
+func (suite *EnvelopeServiceTestSuite) TestAvailable_NothingAssigned() {
+	state, err := suite.svc.Available(time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC))
+	assert.NoError(suite.T(), err)
+
+	// Loan accounts are off-budget
+	assert.Equal(suite.T(), int64(100000), state.FundsCents)
+	assert.Equal(suite.T(), int64(100000), state.ToBeBudgetedCents)
+	assert.Empty(suite.T(), state.Envelopes)
+	assert.True(suite.T(), state.Reconciled)
+}
+
+func (suite *EnvelopeServiceTestSuite) TestAssign_ReducesToBeBudgeted() {
+	october := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	state, err := suite.svc.Assign(suite.groceries.ID, october, 40000, "")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(60000), state.ToBeBudgetedCents)
+
+	env := state.Envelope(suite.groceries.ID)
+	assert.NotNil(suite.T(), env)
+	assert.Equal(suite.T(), int64(40000), env.AssignedCents)
+	assert.Equal(suite.T(), int64(40000), env.AvailableCents)
+}
+
+func (suite *EnvelopeServiceTestSuite) TestAvailable_SpendingAndIncome() {
+	october := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	_, err := suite.svc.Assign(suite.groceries.ID, october, 40000, "")
+	assert.NoError(suite.T(), err)
+
+	suite.record(time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC), -15000, suite.groceries)
+	suite.record(time.Date(2026, 10, 6, 0, 0, 0, 0, time.UTC), 250000, suite.salary)
+
+	state, err := suite.svc.Available(october)
+	assert.NoError(suite.T(), err)
+
+	env := state.Envelope(suite.groceries.ID)
+	assert.Equal(suite.T(), int64(-15000), env.ActivityCents)
+	assert.Equal(suite.T(), int64(25000), env.AvailableCents)
+
+	// Income is not an envelope; it flows to be budgeted
+	assert.Nil(suite.T(), state.Envelope(suite.salary.ID))
+	assert.Equal(suite.T(), int64(335000), state.FundsCents)
+	assert.Equal(suite.T(), int64(310000), state.ToBeBudgetedCents)
+	assert.True(suite.T(), state.Reconciled)
+}
+
+func (suite *EnvelopeServiceTestSuite) TestAvailable_CarriesToNextMonth() {
+	_, err := suite.svc.Assign(suite.groceries.ID, time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), 40000, "")
+	assert.NoError(suite.T(), err)
+	suite.record(time.Date(2026, 9, 20, 0, 0, 0, 0, time.UTC), -30000, suite.groceries)
+
+	state, err := suite.svc.Available(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(suite.T(), err)
+
+	env := state.Envelope(suite.groceries.ID)
+	assert.Equal(suite.T(), int64(0), env.AssignedCents)
+	assert.Equal(suite.T(), int64(0), env.ActivityCents)
+	assert.Equal(suite.T(), int64(10000), env.AvailableCents)
+	assert.Equal(suite.T(), int64(60000), state.ToBeBudgetedCents)
+}
+
+func (suite *EnvelopeServiceTestSuite) TestAvailable_OverspentEnvelopeWithoutAssignment() {
+	suite.record(time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC), -2500, suite.dining)
+
+	state, err := suite.svc.Available(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(suite.T(), err)
+
+	env := state.Envelope(suite.dining.ID)
+	assert.NotNil(suite.T(), env)
+	assert.Equal(suite.T(), int64(-2500), env.AvailableCents)
+	assert.Equal(suite.T(), int64(100000), state.ToBeBudgetedCents)
+}
+
+func (suite *EnvelopeServiceTestSuite) TestMove() {
+	october := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	_, err := suite.svc.Assign(suite.dining.ID, october, 20000, "")
+	assert.NoError(suite.T(), err)
+
+	state, err := suite.svc.Move(suite.dining.ID, suite.groceries.ID, october, 5000, "")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(15000), state.Envelope(suite.dining.ID).AvailableCents)
+	assert.Equal(suite.T(), int64(5000), state.Envelope(suite.groceries.ID).AvailableCents)
+	assert.Equal(suite.T(), int64(80000), state.ToBeBudgetedCents)
+}
+
+func (suite *EnvelopeServiceTestSuite) TestMove_InsufficientFunds() {
+	october := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	_, err := suite.svc.Assign(suite.dining.ID, october, 2000, "")
+	assert.NoError(suite.T(), err)
+
+	_, err = suite.svc.Move(suite.dining.ID, suite.groceries.ID, october, 5000, "")
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "insufficient funds")
+
+	_, err = suite.svc.Move(suite.dining.ID, suite.dining.ID, october, 1000, "")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *EnvelopeServiceTestSuite) TestAvailable_DetectsUnreconciledBalance() {
+	assert.NoError(suite.T(), suite.db.Model(suite.checking).Update("current_balance", 99000).Error)
+
+	state, err := suite.svc.Available(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), state.Reconciled)
+	assert.Equal(suite.T(), int64(-1000), state.Accounts[0].DifferenceCents)
+}
+
+func TestEnvelopeServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(EnvelopeServiceTestSuite))
+}
+
+func TestMonthStart(t *testing.T) {
+	got := MonthStart(time.Date(2026, 2, 28, 15, 4, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), got)
+}
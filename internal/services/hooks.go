@@ -0,0 +1,43 @@
+package services
+
+import (
+	"github.com/fintrack/fintrack/internal/config"
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"gorm.io/gorm"
+)
+
+// Names of the transaction hooks registered by RegisterHooks
+const (
+	HookBudgetAlerts  = "budget_alerts"
+	HookLowBalance    = "low_balance"
+	HookBillReminders = "bill_reminders"
+)
+
+// RegisterHooks registers the services that react to transaction and balance
+// changes with the repositories package. It is safe to call more than once.
+func RegisterHooks() {
+	repositories.RegisterTransactionHook(HookBudgetAlerts, budgetAlertHook)
+	repositories.RegisterBalanceHook(HookLowBalance, lowBalanceHook)
+	repositories.RegisterTransactionCreateHook(HookBillReminders, DismissBillReminders)
+}
+
+// budgetAlertHook creates budget reminders for changed transactions when alerts are enabled
+func budgetAlertHook(tx *gorm.DB, transactions []*models.Transaction) error {
+	if !config.Get().Alerts.Enabled {
+		return nil
+	}
+	_, err := NewBudgetAlertService(tx).Check(transactions)
+	return err
+}
+
+// lowBalanceHook creates low balance reminders for changed accounts when low
+// balance warnings are enabled
+func lowBalanceHook(tx *gorm.DB, changes map[uint]int64) error {
+	cfg := config.Get().Alerts.LowBalance
+	if !cfg.Enabled {
+		return nil
+	}
+	_, err := NewLowBalanceService(tx, cfg).Check(changes)
+	return err
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/config"
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"gorm.io/gorm"
+)
+
+// LowBalanceThreshold returns the balance (in cents) below which an account is
+// low: the account's own override if set, otherwise its type's threshold. Config
+// keys are matched case-insensitively because the config loader lower-cases them.
+// It returns false if the account has no threshold.
+func LowBalanceThreshold(cfg config.LowBalanceConfig, account *models.Account) (int64, bool) {
+	for name, dollars := range cfg.Accounts {
+		if strings.EqualFold(name, account.Name) {
+			return models.DollarsToCents(dollars), true
+		}
+	}
+	for accountType, dollars := range cfg.Thresholds {
+		if strings.EqualFold(accountType, account.Type) {
+			return models.DollarsToCents(dollars), true
+		}
+	}
+	return 0, false
+}
+
+// LowBalanceService creates reminders when account balances fall below their
+// low balance thresholds
+type LowBalanceService struct {
+	accountRepo  *repositories.AccountRepository
+	reminderRepo *repositories.ReminderRepository
+	cfg          config.LowBalanceConfig
+	now          func() time.Time
+}
+
+// NewLowBalanceService creates a new low balance service using the given thresholds
+func NewLowBalanceService(db *gorm.DB, cfg config.LowBalanceConfig) *LowBalanceService {
+	return &LowBalanceService{
+		accountRepo:  repositories.NewAccountRepository(db),
+		reminderRepo: repositories.NewReminderRepository(db),
+		cfg:          cfg,
+		now:          time.Now,
+	}
+}
+
+// Check looks at each changed account's balance. An account that has just dropped
+// below its threshold gets a low balance reminder, so staying low doesn't repeat
+// it. An account that has just recovered has its open low balance reminders
+// dismissed. changes maps account IDs to how much their balance moved, in cents.
+func (s *LowBalanceService) Check(changes map[uint]int64) ([]*models.Reminder, error) {
+	ids := make([]uint, 0, len(changes))
+	for id := range changes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var created []*models.Reminder
+	for _, id := range ids {
+		account, err := s.accountRepo.GetByID(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load account %d: %w", id, err)
+		}
+		threshold, ok := LowBalanceThreshold(s.cfg, account)
+		if !ok || !account.IsActive {
+			continue
+		}
+
+		balance := account.CurrentBalanceCents
+		previous := balance - changes[id]
+		switch {
+		case balance < threshold && previous >= threshold:
+			reminder := newLowBalanceReminder(account, threshold, s.now())
+			if err := s.reminderRepo.Create(reminder); err != nil {
+				return nil, fmt.Errorf("failed to create low balance reminder: %w", err)
+			}
+			created = append(created, reminder)
+		case balance >= threshold && previous < threshold:
+			if err := s.dismissOpen(account.ID); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return created, nil
+}
+
+// dismissOpen dismisses an account's undismissed low balance reminders
+func (s *LowBalanceService) dismissOpen(accountID uint) error {
+	reminders, err := s.reminderRepo.ListByRelated(models.ReminderTypeLowBalance, accountID)
+	if err != nil {
+		return fmt.Errorf("failed to list low balance reminders: %w", err)
+	}
+	for _, r := range reminders {
+		if r.IsDismissed {
+			continue
+		}
+		if err := s.reminderRepo.Dismiss(r.ID); err != nil {
+			return fmt.Errorf("failed to dismiss low balance reminder: %w", err)
+		}
+	}
+	return nil
+}
+
+// newLowBalanceReminder builds the reminder for an account below its threshold.
+// An overdrawn account is urgent.
+func newLowBalanceReminder(account *models.Account, threshold int64, now time.Time) *models.Reminder {
+	priority := models.ReminderPriorityHigh
+	if account.CurrentBalanceCents < 0 {
+		priority = models.ReminderPriorityUrgent
+	}
+
+	accountID := account.ID
+	return &models.Reminder{
+		Type:      models.ReminderTypeLowBalance,
+		RelatedID: &accountID,
+		Title:     fmt.Sprintf("Low balance: %s", account.Name),
+		Message: fmt.Sprintf("%s balance is $%.2f, below the $%.2f threshold",
+			account.Name,
+			models.CentsToDollars(account.CurrentBalanceCents),
+			models.CentsToDollars(threshold)),
+		RemindDate: startOfDayUTC(now),
+		Priority:   priority,
+	}
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/config"
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestLowBalanceThreshold(t *testing.T) {
+	cfg := config.LowBalanceConfig{
+		Thresholds: map[string]float64{"checking": 500, "savings": 1000},
+		Accounts:   map[string]float64{"joint checking": 1500},
+	}
+
+	threshold, ok := LowBalanceThreshold(cfg, &models.Account{Name: "Everyday", Type: models.AccountTypeChecking})
+	assert.True(t, ok)
+	assert.Equal(t, int64(50000), threshold)
+
+	threshold, ok = LowBalanceThreshold(cfg, &models.Account{Name: "Joint Checking", Type: models.AccountTypeChecking})
+	assert.True(t, ok)
+	assert.Equal(t, int64(150000), threshold)
+
+	_, ok = LowBalanceThreshold(cfg, &models.Account{Name: "Visa", Type: models.AccountTypeCredit})
+	assert.False(t, ok)
+}
+
+// LowBalanceTestSuite is the test suite for low balance reminders
+type LowBalanceTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	txRepo  *repositories.TransactionRepository
+	account *models.Account
+}
+
+// SetupTest runs before each test
+func (suite *LowBalanceTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), db.AutoMigrate(&models.Account{}, &models.Transaction{}, &models.Reminder{}))
+
+	suite.db = db
+	suite.txRepo = repositories.NewTransactionRepository(db)
+	suite.account = &models.Account{Name: "Checking", Type: models.AccountTypeChecking, Currency: "USD", InitialBalanceCents: 80000, IsActive: true}
+	assert.NoError(suite.T(), repositories.NewAccountRepository(db).Create(suite.account))
+
+	cfg := config.LowBalanceConfig{Enabled: true, Thresholds: map[string]float64{"checking": 500}}
+	repositories.RegisterBalanceHook(HookLowBalance, func(tx *gorm.DB, changes map[uint]int64) error {
+		svc := NewLowBalanceService(tx, cfg)
+		svc.now = func() time.Time { return time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC) }
+		_, err := svc.Check(changes)
+		return err
+	})
+}
+
+// TearDownTest runs after each test
+func (suite *LowBalanceTestSuite) TearDownTest() {
+	repositories.UnregisterBalanceHook(HookLowBalance)
+}
+
+func (suite *LowBalanceTestSuite) record(cents int64) *models.Transaction {
+	txType := models.TransactionTypeExpense
+	if cents > 0 {
+		txType = models.TransactionTypeIncome
+	}
+	tx := &models.Transaction{
+		AccountID:   suite.account.ID,
+		Date:        time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+		AmountCents: cents,
+		Type:        txType,
+		Tags:        models.StringArray{},
+	}
+	assert.NoError(suite.T(), suite.txRepo.Create(tx))
+	return tx
+}
+
+func (suite *LowBalanceTestSuite) reminders() []*models.Reminder {
+	reminders, err := repositories.NewReminderRepository(suite.db).ListByRelated(models.ReminderTypeLowBalance, suite.account.ID)
+	assert.NoError(suite.T(), err)
+	return reminders
+}
+
+func (suite *LowBalanceTestSuite) TestCrossingBelowThresholdCreatesReminder() {
+	// Given $800 in checking with a $500 threshold
+	suite.record(-20000)
+	assert.Empty(suite.T(), suite.reminders())
+
+	// When a payment takes it to $450
+	suite.record(-15000)
+
+	// Then one high priority reminder is created
+	reminders := suite.reminders()
+	assert.Len(suite.T(), reminders, 1)
+	assert.Equal(suite.T(), "Low balance: Checking", reminders[0].Title)
+	assert.Equal(suite.T(), models.ReminderPriorityHigh, reminders[0].Priority)
+	assert.Contains(suite.T(), reminders[0].Message, "$450.00")
+	assert.Equal(suite.T(), "2026-10-16", reminders[0].RemindDate.Format("2006-01-02"))
+
+	// And staying low doesn't repeat it
+	suite.record(-5000)
+	assert.Len(suite.T(), suite.reminders(), 1)
+}
+
+func (suite *LowBalanceTestSuite) TestRecoveryDismissesAndRearms() {
+	suite.record(-40000)
+	assert.Len(suite.T(), suite.reminders(), 1)
+
+	// Payday brings it back above the threshold
+	suite.record(100000)
+	reminders := suite.reminders()
+	assert.Len(suite.T(), reminders, 1)
+	assert.True(suite.T(), reminders[0].IsDismissed)
+
+	// Dropping below again warns again
+	suite.record(-150000)
+	reminders = suite.reminders()
+	assert.Len(suite.T(), reminders, 2)
+	assert.False(suite.T(), reminders[1].IsDismissed)
+	assert.Equal(suite.T(), models.ReminderPriorityUrgent, reminders[1].Priority)
+}
+
+func (suite *LowBalanceTestSuite) TestDeletingIncomeIsChecked() {
+	income := suite.record(10000)
+	suite.record(-35000)
+	assert.Empty(suite.T(), suite.reminders())
+
+	// Removing the income leaves $450
+	assert.NoError(suite.T(), suite.txRepo.Delete(income.ID))
+	assert.Len(suite.T(), suite.reminders(), 1)
+}
+
+func (suite *LowBalanceTestSuite) TestAccountOverride() {
+	repositories.RegisterBalanceHook(HookLowBalance, func(tx *gorm.DB, changes map[uint]int64) error {
+		cfg := config.LowBalanceConfig{
+			Enabled:    true,
+			Thresholds: map[string]float64{"checking": 500},
+			Accounts:   map[string]float64{"checking": 100},
+		}
+		_, err := NewLowBalanceService(tx, cfg).Check(changes)
+		return err
+	})
+
+	suite.record(-40000)
+	assert.Empty(suite.T(), suite.reminders())
+
+	suite.record(-35000)
+	assert.Len(suite.T(), suite.reminders(), 1)
+}
+
+func TestLowBalanceTestSuite(t *testing.T) {
+	suite.Run(t, new(LowBalanceTestSuite))
+}
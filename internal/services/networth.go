@@ -0,0 +1,207 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/models"
+)
+
+// Net worth series intervals
+const (
+	NetWorthIntervalDay   = "day"
+	NetWorthIntervalMonth = "month"
+)
+
+// NetWorthOptions controls a net worth report
+type NetWorthOptions struct {
+	From        time.Time
+	To          time.Time // Inclusive
+	Interval    string    // day, month
+	FromInitial bool      // Replay transactions forward from initial balances
+}
+
+// NetWorthPoint is the combined balance of all accounts at the end of one day
+type NetWorthPoint struct {
+	Date             time.Time `json:"date"`
+	AssetsCents      int64     `json:"assets_cents"`
+	LiabilitiesCents int64     `json:"liabilities_cents"` // Amount owed on credit and loan accounts
+	NetWorthCents    int64     `json:"net_worth_cents"`
+	ChangeCents      int64     `json:"change_cents"` // Since the previous point, or the day before From
+}
+
+// AccountBalanceHistory holds one account's reconstructed balance at each point
+type AccountBalanceHistory struct {
+	AccountID     uint      `json:"account_id"`
+	Name          string    `json:"name"`
+	Type          string    `json:"type"`
+	IsActive      bool      `json:"is_active"`
+	Liability     bool      `json:"liability"`
+	OpenedOn      time.Time `json:"opened_on"`      // Creation date or first transaction, whichever is earlier
+	BalancesCents []int64   `json:"balances_cents"` // One per point; zero before OpenedOn
+}
+
+// NetWorthReport is a net worth series reconstructed from transactions
+type NetWorthReport struct {
+	From        time.Time                `json:"from"`
+	To          time.Time                `json:"to"`
+	Interval    string                   `json:"interval"`
+	FromInitial bool                     `json:"from_initial"`
+	Points      []*NetWorthPoint         `json:"points"`
+	Accounts    []*AccountBalanceHistory `json:"accounts"`
+}
+
+// NetWorth reconstructs end-of-day balances of every account, including closed
+// ones, at the end of each day or month between opts.From and opts.To (the last
+// point is opts.To itself), and sums them into assets, liabilities and net
+// worth. Balances are worked back from each account's current balance by
+// undoing later transactions, or with FromInitial worked forward from its
+// initial balance; the two differ only when a balance was adjusted without a
+// transaction. Credit and loan accounts are liabilities. An account counts from
+// the day it was created or its first transaction, whichever is earlier.
+func (s *ReportService) NetWorth(opts NetWorthOptions) (*NetWorthReport, error) {
+	from, to := startOfDayUTC(opts.From), startOfDayUTC(opts.To)
+	if to.Before(from) {
+		return nil, fmt.Errorf("end date must not be before start date")
+	}
+	dates, err := netWorthDates(opts.Interval, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := s.accountRepo.List(false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].ID < accounts[j].ID })
+
+	ids := make([]uint, 0, len(accounts))
+	for _, account := range accounts {
+		ids = append(ids, account.ID)
+	}
+	transactions, err := s.txRepo.ListBalanceChanges(ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transactions: %w", err)
+	}
+	changes := make(map[uint][]*models.Transaction, len(accounts))
+	for _, tx := range transactions {
+		changes[tx.AccountID] = append(changes[tx.AccountID], tx)
+	}
+
+	report := &NetWorthReport{
+		From:        from,
+		To:          to,
+		Interval:    opts.Interval,
+		FromInitial: opts.FromInitial,
+		Points:      make([]*NetWorthPoint, 0, len(dates)),
+		Accounts:    make([]*AccountBalanceHistory, 0, len(accounts)),
+	}
+
+	// The balance the day before From is the baseline for the first change
+	days := append([]time.Time{from.AddDate(0, 0, -1)}, dates...)
+	points := make([]*NetWorthPoint, len(days))
+	for i, day := range days {
+		points[i] = &NetWorthPoint{Date: day}
+	}
+
+	for _, account := range accounts {
+		history := &AccountBalanceHistory{
+			AccountID:     account.ID,
+			Name:          account.Name,
+			Type:          account.Type,
+			IsActive:      account.IsActive,
+			Liability:     IsLiabilityAccount(account.Type),
+			OpenedOn:      accountOpenedOn(account, changes[account.ID]),
+			BalancesCents: make([]int64, 0, len(dates)),
+		}
+
+		balances := reconstructBalances(account, changes[account.ID], days, opts.FromInitial)
+		for i, day := range days {
+			if day.Before(history.OpenedOn) {
+				balances[i] = 0
+			}
+			if history.Liability {
+				points[i].LiabilitiesCents -= balances[i]
+			} else {
+				points[i].AssetsCents += balances[i]
+			}
+		}
+		history.BalancesCents = append(history.BalancesCents, balances[1:]...)
+		report.Accounts = append(report.Accounts, history)
+	}
+
+	for i, point := range points {
+		point.NetWorthCents = point.AssetsCents - point.LiabilitiesCents
+		if i > 0 {
+			point.ChangeCents = point.NetWorthCents - points[i-1].NetWorthCents
+			report.Points = append(report.Points, point)
+		}
+	}
+	return report, nil
+}
+
+// netWorthDates lists the days of a net worth series: every day from from to
+// to, or the last day of every month, ending with to itself
+func netWorthDates(interval string, from, to time.Time) ([]time.Time, error) {
+	var dates []time.Time
+	switch interval {
+	case NetWorthIntervalDay:
+		for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+			dates = append(dates, day)
+		}
+	case NetWorthIntervalMonth:
+		for month := MonthStart(from); !month.After(to); month = month.AddDate(0, 1, 0) {
+			end := month.AddDate(0, 1, -1)
+			if end.After(to) {
+				end = to
+			}
+			dates = append(dates, end)
+		}
+	default:
+		return nil, fmt.Errorf("invalid interval: %s (valid: day, month)", interval)
+	}
+	return dates, nil
+}
+
+// accountOpenedOn returns the day an account was created, or the day of its
+// first transaction if that is earlier (imported history often predates the
+// account). It is the zero time if neither is known.
+func accountOpenedOn(account *models.Account, changes []*models.Transaction) time.Time {
+	var opened time.Time
+	if !account.CreatedAt.IsZero() {
+		opened = startOfDayUTC(account.CreatedAt)
+	}
+	if len(changes) > 0 {
+		first := startOfDayUTC(changes[0].Date)
+		if opened.IsZero() || first.Before(opened) {
+			opened = first
+		}
+	}
+	return opened
+}
+
+// reconstructBalances returns an account's balance at the end of each of the
+// ascending days, given its transactions oldest first
+func reconstructBalances(account *models.Account, changes []*models.Transaction, days []time.Time, fromInitial bool) []int64 {
+	var total int64
+	for _, tx := range changes {
+		total += tx.AmountCents
+	}
+
+	balances := make([]int64, len(days))
+	var applied int64 // Sum of the transactions on or before the current day
+	next := 0
+	for i, day := range days {
+		for next < len(changes) && !startOfDayUTC(changes[next].Date).After(day) {
+			applied += changes[next].AmountCents
+			next++
+		}
+		if fromInitial {
+			balances[i] = account.InitialBalanceCents + applied
+		} else {
+			balances[i] = account.CurrentBalanceCents - (total - applied)
+		}
+	}
+	return balances
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestNetWorthDates(t *testing.T) {
+	from := time.Date(2026, 8, 15, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	dates, err := netWorthDates(NetWorthIntervalMonth, from, to)
+	assert.NoError(t, err)
+	assert.Len(t, dates, 3)
+	assert.Equal(t, "2026-08-31", dates[0].Format("2006-01-02"))
+	assert.Equal(t, "2026-09-30", dates[1].Format("2006-01-02"))
+	assert.Equal(t, "2026-10-16", dates[2].Format("2006-01-02"))
+
+	dates, err = netWorthDates(NetWorthIntervalDay, to.AddDate(0, 0, -2), to)
+	assert.NoError(t, err)
+	assert.Len(t, dates, 3)
+
+	_, err = netWorthDates("year", from, to)
+	assert.EqualError(t, err, "invalid interval: year (valid: day, month)")
+}
+
+// NetWorthTestSuite is the test suite for net worth reports
+type NetWorthTestSuite struct {
+	suite.Suite
+	db  *gorm.DB
+	svc *ReportService
+}
+
+// SetupTest runs before each test
+func (suite *NetWorthTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), db.AutoMigrate(&models.Account{}, &models.Category{}, &models.Transaction{}))
+
+	suite.db = db
+	suite.svc = NewReportService(db)
+
+	// Checking was adjusted up $100 without a transaction: 100000 + 50000 + 10000
+	checking := suite.createAccount("Checking", models.AccountTypeChecking, 100000, 160000, "2026-01-01", true)
+	suite.record(checking, "2026-09-05", 80000)
+	suite.record(checking, "2026-10-10", -30000)
+
+	card := suite.createAccount("Visa", models.AccountTypeCredit, 0, -30000, "2026-09-20", true)
+	suite.record(card, "2026-10-02", -30000)
+
+	// Emptied in August and closed since
+	savings := suite.createAccount("Old Savings", models.AccountTypeSavings, 50000, 0, "2025-12-01", false)
+	suite.record(savings, "2026-08-15", -50000)
+}
+
+func (suite *NetWorthTestSuite) createAccount(name, accountType string, initial, current int64, created string, active bool) *models.Account {
+	account := &models.Account{
+		Name:                name,
+		Type:                accountType,
+		Currency:            "USD",
+		InitialBalanceCents: initial,
+		CurrentBalanceCents: current,
+		IsActive:            true,
+		CreatedAt:           suite.date(created),
+	}
+	assert.NoError(suite.T(), repositories.NewAccountRepository(suite.db).Create(account))
+	// Create treats a zero current balance as unset
+	assert.NoError(suite.T(), suite.db.Model(account).Update("current_balance", current).Error)
+	if !active {
+		assert.NoError(suite.T(), repositories.NewAccountRepository(suite.db).Delete(account.ID))
+	}
+	return account
+}
+
+func (suite *NetWorthTestSuite) record(account *models.Account, date string, cents int64) {
+	tx := &models.Transaction{
+		AccountID:   account.ID,
+		Date:        suite.date(date),
+		AmountCents: cents,
+		Type:        models.TransactionTypeExpense,
+		Tags:        models.StringArray{},
+	}
+	assert.NoError(suite.T(), suite.db.Create(tx).Error)
+}
+
+func (suite *NetWorthTestSuite) date(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	assert.NoError(suite.T(), err)
+	return t
+}
+
+func (suite *NetWorthTestSuite) netWorth(fromInitial bool) *NetWorthReport {
+	report, err := suite.svc.NetWorth(NetWorthOptions{
+		From:        suite.date("2026-08-01"),
+		To:          suite.date("2026-10-16"),
+		Interval:    NetWorthIntervalMonth,
+		FromInitial: fromInitial,
+	})
+	assert.NoError(suite.T(), err)
+	return report
+}
+
+func (suite *NetWorthTestSuite) TestWalksBackFromCurrentBalances() {
+	report := suite.netWorth(false)
+
+	if assert.Len(suite.T(), report.Points, 3) {
+		// July 31st had $1,100 checking and $500 savings
+		august, september, october := report.Points[0], report.Points[1], report.Points[2]
+		assert.Equal(suite.T(), "2026-08-31", august.Date.Format("2006-01-02"))
+		assert.Equal(suite.T(), int64(110000), august.NetWorthCents)
+		assert.Equal(suite.T(), int64(-50000), august.ChangeCents)
+
+		assert.Equal(suite.T(), int64(190000), september.AssetsCents)
+		assert.Zero(suite.T(), september.LiabilitiesCents)
+		assert.Equal(suite.T(), int64(80000), september.ChangeCents)
+
+		assert.Equal(suite.T(), "2026-10-16", october.Date.Format("2006-01-02"))
+		assert.Equal(suite.T(), int64(160000), october.AssetsCents)
+		assert.Equal(suite.T(), int64(30000), october.LiabilitiesCents)
+		assert.Equal(suite.T(), int64(130000), october.NetWorthCents)
+	}
+
+	// The closed account is included
+	assert.Len(suite.T(), report.Accounts, 3)
+	savings := report.Accounts[2]
+	assert.Equal(suite.T(), "Old Savings", savings.Name)
+	assert.False(suite.T(), savings.IsActive)
+	assert.Equal(suite.T(), []int64{0, 0, 0}, savings.BalancesCents)
+
+	// The card didn't exist at the end of August
+	card := report.Accounts[1]
+	assert.True(suite.T(), card.Liability)
+	assert.Equal(suite.T(), "2026-09-20", card.OpenedOn.Format("2006-01-02"))
+	assert.Equal(suite.T(), []int64{0, 0, -30000}, card.BalancesCents)
+}
+
+func (suite *NetWorthTestSuite) TestForwardFromInitialBalances() {
+	report := suite.netWorth(true)
+
+	// Without the $100 adjustment
+	assert.Equal(suite.T(), []int64{100000, 180000, 150000}, report.Accounts[0].BalancesCents)
+	assert.Equal(suite.T(), int64(120000), report.Points[2].NetWorthCents)
+	assert.Equal(suite.T(), int64(-50000), report.Points[0].ChangeCents)
+}
+
+func (suite *NetWorthTestSuite) TestImportedHistoryPredatesAccount() {
+	checking, err := repositories.NewAccountRepository(suite.db).GetByName("Checking")
+	assert.NoError(suite.T(), err)
+	suite.record(checking, "2025-11-20", 0)
+
+	report, err := suite.svc.NetWorth(NetWorthOptions{
+		From:     suite.date("2025-11-01"),
+		To:       suite.date("2025-11-30"),
+		Interval: NetWorthIntervalMonth,
+	})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "2025-11-20", report.Accounts[0].OpenedOn.Format("2006-01-02"))
+	assert.Equal(suite.T(), int64(110000), report.Accounts[0].BalancesCents[0])
+
+	_, err = suite.svc.NetWorth(NetWorthOptions{From: suite.date("2026-01-02"), To: suite.date("2026-01-01"), Interval: NetWorthIntervalDay})
+	assert.EqualError(suite.T(), err, "end date must not be before start date")
+}
+
+func TestNetWorthTestSuite(t *testing.T) {
+	suite.Run(t, new(NetWorthTestSuite))
+}
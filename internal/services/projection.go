@@ -0,0 +1,519 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/fintrack/fintrack/internal/recurrence"
+	"gorm.io/gorm"
+)
+
+// Projection scenarios
+const (
+	ScenarioConservative = "conservative"
+	ScenarioModerate     = "moderate"
+	ScenarioOptimistic   = "optimistic"
+)
+
+// DefaultScenarioPercentiles are the percentiles of historical discretionary
+// spending each scenario projects when none are configured
+var DefaultScenarioPercentiles = map[string]float64{
+	ScenarioConservative: 0.75,
+	ScenarioModerate:     0.50,
+	ScenarioOptimistic:   0.25,
+}
+
+const (
+	defaultProjectionDays   = 90
+	defaultHistoricalWindow = 90
+	confidenceDecayDays     = 90.0
+	weeklyBucketDays        = 7
+	completenessWeight      = 0.4
+	consistencyWeight       = 0.3
+	timeDecayWeight         = 0.3
+)
+
+// ScenarioPercentile returns the spending percentile of a scenario, matched
+// case-insensitively. The defaults are used when scenarios is empty.
+func ScenarioPercentile(scenarios map[string]float64, name string) (float64, error) {
+	if len(scenarios) == 0 {
+		scenarios = DefaultScenarioPercentiles
+	}
+	for scenario, percentile := range scenarios {
+		if strings.EqualFold(scenario, name) {
+			if percentile < 0 || percentile > 1 {
+				return 0, fmt.Errorf("scenario %s percentile must be between 0 and 1", scenario)
+			}
+			return percentile, nil
+		}
+	}
+
+	names := make([]string, 0, len(scenarios))
+	for scenario := range scenarios {
+		names = append(names, scenario)
+	}
+	sort.Strings(names)
+	return 0, fmt.Errorf("invalid scenario: %s (valid: %s)", name, strings.Join(names, ", "))
+}
+
+// IsStoredScenario reports whether projections of a scenario can be saved. Only
+// the built-in scenarios fit the projection_type column; custom scenarios from
+// projection.scenarios are projected but not stored.
+func IsStoredScenario(name string) bool {
+	_, ok := DefaultScenarioPercentiles[strings.ToLower(name)]
+	return ok
+}
+
+// ProjectionOptions controls a cash flow projection
+type ProjectionOptions struct {
+	Days                 int
+	AccountID            *uint // nil projects all active accounts
+	Scenario             string
+	Percentile           float64 // Percentile of weekly discretionary spending to project
+	HistoricalWindowDays int
+}
+
+// ProjectionDay is the projected cash flow for one day. Expenses are negative.
+type ProjectionDay struct {
+	Date          time.Time `json:"date"`
+	IncomeCents   int64     `json:"income_cents"`
+	ExpensesCents int64     `json:"expenses_cents"`
+	BalanceCents  int64     `json:"balance_cents"`
+	Confidence    float64   `json:"confidence"`
+	Events        []string  `json:"events,omitempty"`
+}
+
+// AccountProjection summarizes the projection of one account
+type AccountProjection struct {
+	AccountID          uint      `json:"account_id"`
+	Name               string    `json:"name"`
+	Type               string    `json:"type"`
+	StartBalanceCents  int64     `json:"start_balance_cents"`
+	EndingBalanceCents int64     `json:"ending_balance_cents"`
+	LowestBalanceCents int64     `json:"lowest_balance_cents"`
+	LowestBalanceDate  time.Time `json:"lowest_balance_date"`
+	DailySpendCents    int64     `json:"daily_spend_cents"`
+}
+
+// NegativeBalance is the first day an account is projected to be overdrawn
+type NegativeBalance struct {
+	AccountID    uint      `json:"account_id"`
+	AccountName  string    `json:"account_name"`
+	Date         time.Time `json:"date"`
+	BalanceCents int64     `json:"balance_cents"`
+}
+
+// Projection is a day-by-day cash flow projection
+type Projection struct {
+	Scenario               string               `json:"scenario"`
+	Percentile             float64              `json:"percentile"`
+	AccountID              *uint                `json:"account_id,omitempty"`
+	StartDate              time.Time            `json:"start_date"`
+	HistoryDays            int                  `json:"history_days"`
+	StartBalanceCents      int64                `json:"start_balance_cents"`
+	ScheduledIncomeCents   int64                `json:"scheduled_income_cents"`
+	ScheduledExpensesCents int64                `json:"scheduled_expenses_cents"`
+	DiscretionaryCents     int64                `json:"discretionary_cents"`
+	EndingBalanceCents     int64                `json:"ending_balance_cents"`
+	Confidence             float64              `json:"confidence"`
+	FirstNegative          *NegativeBalance     `json:"first_negative,omitempty"`
+	Accounts               []*AccountProjection `json:"accounts"`
+	Days                   []*ProjectionDay     `json:"days"`
+	GeneratedAt            time.Time            `json:"generated_at"`
+}
+
+// ProjectionService projects account balances from scheduled recurring items and
+// historical discretionary spending
+type ProjectionService struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+// NewProjectionService creates a new projection service
+func NewProjectionService(db *gorm.DB) *ProjectionService {
+	return &ProjectionService{db: db, now: time.Now}
+}
+
+// Project projects balances for each day after today. Each account starts from
+// its current balance, gains or loses the amount of every scheduled recurring
+// occurrence on its date, and spends the scenario's percentile of its weekly
+// unscheduled expenses over the historical window, spread evenly across the days.
+// Transfers and transactions generated from recurring items don't count as
+// discretionary spending.
+func (s *ProjectionService) Project(opts ProjectionOptions) (*Projection, error) {
+	model, err := s.load(opts)
+	if err != nil {
+		return nil, err
+	}
+	projection := model.project(opts.Percentile)
+	projection.Scenario = opts.Scenario
+	projection.AccountID = opts.AccountID
+	projection.GeneratedAt = s.now()
+	return projection, nil
+}
+
+// Save stores a projection's daily balances, replacing the earlier projection of
+// the same scenario and account
+func (s *ProjectionService) Save(projection *Projection) error {
+	if !IsStoredScenario(projection.Scenario) {
+		return fmt.Errorf("cannot save a projection of custom scenario %s; only %s, %s and %s are stored",
+			projection.Scenario, ScenarioConservative, ScenarioModerate, ScenarioOptimistic)
+	}
+	rows := make([]*models.CashFlowProjection, 0, len(projection.Days))
+	for _, day := range projection.Days {
+		rows = append(rows, &models.CashFlowProjection{
+			AccountID:              projection.AccountID,
+			ProjectionDate:         day.Date,
+			ProjectedBalanceCents:  day.BalanceCents,
+			ProjectedIncomeCents:   day.IncomeCents,
+			ProjectedExpensesCents: day.ExpensesCents,
+			ConfidenceLevel:        day.Confidence,
+			ProjectionType:         projection.Scenario,
+			GeneratedAt:            projection.GeneratedAt,
+		})
+	}
+	if err := repositories.NewProjectionRepository(s.db).Replace(projection.AccountID, projection.Scenario, rows); err != nil {
+		return fmt.Errorf("failed to save projection: %w", err)
+	}
+	return nil
+}
+
+// projectionModel holds everything a projection is computed from
+type projectionModel struct {
+	today       time.Time
+	days        int
+	accounts    []*models.Account
+	items       []*models.RecurringItem
+	oneOffs     []oneOff
+	historyDays int
+	window      int
+	bucketDays  int
+	daily       map[uint][]int64     // Unscheduled spending per account per history day, newest first
+	spend       map[uint][]int64     // The same spending per history bucket, newest first
+	removed     map[string]time.Time // Items a what-if removes, by whatIfItemKey, from the date given
+}
+
+// scheduledFlow is the scheduled income and expenses of one account on one day
+type scheduledFlow struct {
+	incomeCents   int64
+	expensesCents int64
+	events        []string
+}
+
+// load reads the accounts, recurring items and spending history a projection needs
+func (s *ProjectionService) load(opts ProjectionOptions) (*projectionModel, error) {
+	accountRepo := repositories.NewAccountRepository(s.db)
+	recurringRepo := repositories.NewRecurringRepository(s.db)
+	txRepo := repositories.NewTransactionRepository(s.db)
+
+	m := &projectionModel{
+		today:  startOfDayUTC(s.now()),
+		days:   opts.Days,
+		window: opts.HistoricalWindowDays,
+		daily:  make(map[uint][]int64),
+		spend:  make(map[uint][]int64),
+	}
+	if m.days <= 0 {
+		m.days = defaultProjectionDays
+	}
+	if m.window <= 0 {
+		m.window = defaultHistoricalWindow
+	}
+
+	if opts.AccountID != nil {
+		account, err := accountRepo.GetByID(*opts.AccountID)
+		if err != nil {
+			return nil, err
+		}
+		m.accounts = []*models.Account{account}
+	} else {
+		accounts, err := accountRepo.List(true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list accounts: %w", err)
+		}
+		m.accounts = accounts
+	}
+
+	accountIDs := make([]uint, 0, len(m.accounts))
+	inScope := make(map[uint]bool)
+	for _, account := range m.accounts {
+		accountIDs = append(accountIDs, account.ID)
+		inScope[account.ID] = true
+	}
+
+	items, err := recurringRepo.List(true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recurring items: %w", err)
+	}
+	for _, item := range items {
+		if inScope[item.AccountID] {
+			m.items = append(m.items, item)
+		}
+	}
+
+	earliest, err := txRepo.EarliestDate(accountIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transaction history: %w", err)
+	}
+	if earliest != nil {
+		m.historyDays = daysBetween(startOfDayUTC(*earliest), m.today) + 1
+		if m.historyDays > m.window {
+			m.historyDays = m.window
+		}
+	}
+
+	for _, id := range accountIDs {
+		m.daily[id] = make([]int64, m.historyDays)
+	}
+	if m.historyDays > 0 {
+		from := m.today.AddDate(0, 0, -(m.historyDays - 1))
+		expenses, err := txRepo.ListUnscheduledExpenses(accountIDs, from, m.today)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list spending history: %w", err)
+		}
+		for _, tx := range expenses {
+			day := daysBetween(startOfDayUTC(tx.Date), m.today)
+			if day >= 0 && day < m.historyDays {
+				m.daily[tx.AccountID][day] += -tx.AmountCents
+			}
+		}
+	}
+
+	buckets := m.historyDays / weeklyBucketDays
+	m.bucketDays = weeklyBucketDays
+	if buckets == 0 && m.historyDays > 0 {
+		buckets, m.bucketDays = 1, m.historyDays
+	}
+	for _, id := range accountIDs {
+		m.spend[id] = make([]int64, buckets)
+		for day := 0; day < buckets*m.bucketDays; day++ {
+			m.spend[id][day/m.bucketDays] += m.daily[id][day]
+		}
+	}
+	return m, nil
+}
+
+// scheduledFlows returns each account's scheduled income and expenses by day
+// offset (1 is tomorrow) within the projection, from recurring items and one-offs
+func (m *projectionModel) scheduledFlows() map[uint]map[int]*scheduledFlow {
+	end := m.today.AddDate(0, 0, m.days)
+	flows := make(map[uint]map[int]*scheduledFlow)
+	add := func(accountID uint, date time.Time, cents int64, name string) {
+		offset := daysBetween(m.today, recurrence.Day(date))
+		if offset < 1 || offset > m.days {
+			return
+		}
+		if flows[accountID] == nil {
+			flows[accountID] = make(map[int]*scheduledFlow)
+		}
+		flow := flows[accountID][offset]
+		if flow == nil {
+			flow = &scheduledFlow{}
+			flows[accountID][offset] = flow
+		}
+		if cents > 0 {
+			flow.incomeCents += cents
+		} else {
+			flow.expensesCents += cents
+		}
+		flow.events = append(flow.events, name)
+	}
+
+	for _, item := range m.items {
+		for _, date := range itemOccurrences(item, end) {
+			add(item.AccountID, date, item.AmountCents, item.Name)
+		}
+	}
+	for _, o := range m.oneOffs {
+		add(o.accountID, o.date, o.amountCents, o.name)
+	}
+	return flows
+}
+
+// dailySpend returns an account's projected discretionary spending per day at
+// the given percentile of its historical buckets
+func (m *projectionModel) dailySpend(accountID uint, p float64) float64 {
+	buckets := m.spend[accountID]
+	if len(buckets) == 0 {
+		return 0
+	}
+	values := make([]float64, len(buckets))
+	for i, cents := range buckets {
+		values[i] = float64(cents)
+	}
+	return percentile(values, p) / float64(m.bucketDays)
+}
+
+// baseConfidence scores how well the spending history supports a projection,
+// before time decay: 0.4 for a full historical window plus 0.3 for consistent
+// spending from bucket to bucket
+func (m *projectionModel) baseConfidence() float64 {
+	if m.historyDays == 0 {
+		return 0
+	}
+	completeness := math.Min(1, float64(m.historyDays)/float64(m.window))
+
+	var totals []float64
+	for _, buckets := range m.spend {
+		for i, cents := range buckets {
+			if i >= len(totals) {
+				totals = append(totals, 0)
+			}
+			totals[i] += float64(cents)
+		}
+	}
+	consistency := 1.0
+	if mean := meanFloat(totals); mean > 0 {
+		consistency = math.Max(0, 1-stdDevFloat(totals, mean)/mean)
+	}
+	return completenessWeight*completeness + consistencyWeight*consistency
+}
+
+// dayConfidence is the confidence of the projection offset days ahead
+func dayConfidence(base float64, offset int) float64 {
+	return base + timeDecayWeight*math.Exp(-float64(offset)/confidenceDecayDays)
+}
+
+// project runs the projection at the given spending percentile
+func (m *projectionModel) project(p float64) *Projection {
+	flows := m.scheduledFlows()
+	base := m.baseConfidence()
+
+	projection := &Projection{
+		Percentile:  p,
+		StartDate:   m.today,
+		HistoryDays: m.historyDays,
+		Days:        make([]*ProjectionDay, 0, m.days),
+	}
+
+	balances := make(map[uint]int64, len(m.accounts))
+	rates := make(map[uint]float64, len(m.accounts))
+	for _, account := range m.accounts {
+		balances[account.ID] = account.CurrentBalanceCents
+		rates[account.ID] = m.dailySpend(account.ID, p)
+		projection.StartBalanceCents += account.CurrentBalanceCents
+		projection.Accounts = append(projection.Accounts, &AccountProjection{
+			AccountID:          account.ID,
+			Name:               account.Name,
+			Type:               account.Type,
+			StartBalanceCents:  account.CurrentBalanceCents,
+			LowestBalanceCents: account.CurrentBalanceCents,
+			LowestBalanceDate:  m.today,
+			DailySpendCents:    int64(math.Round(rates[account.ID])),
+		})
+	}
+
+	var totalConfidence float64
+	for offset := 1; offset <= m.days; offset++ {
+		day := &ProjectionDay{
+			Date:       m.today.AddDate(0, 0, offset),
+			Confidence: dayConfidence(base, offset),
+		}
+		for i, account := range m.accounts {
+			if flow := flows[account.ID][offset]; flow != nil {
+				day.IncomeCents += flow.incomeCents
+				day.ExpensesCents += flow.expensesCents
+				day.Events = append(day.Events, flow.events...)
+				balances[account.ID] += flow.incomeCents + flow.expensesCents
+				projection.ScheduledIncomeCents += flow.incomeCents
+				projection.ScheduledExpensesCents += flow.expensesCents
+			}
+
+			spend := spreadCents(rates[account.ID], offset)
+			day.ExpensesCents -= spend
+			balances[account.ID] -= spend
+			projection.DiscretionaryCents -= spend
+
+			balance := balances[account.ID]
+			day.BalanceCents += balance
+			summary := projection.Accounts[i]
+			if balance < summary.LowestBalanceCents {
+				summary.LowestBalanceCents = balance
+				summary.LowestBalanceDate = day.Date
+			}
+			if balance < 0 && !IsLiabilityAccount(account.Type) && projection.FirstNegative == nil {
+				projection.FirstNegative = &NegativeBalance{
+					AccountID:    account.ID,
+					AccountName:  account.Name,
+					Date:         day.Date,
+					BalanceCents: balance,
+				}
+			}
+		}
+		totalConfidence += day.Confidence
+		projection.Days = append(projection.Days, day)
+	}
+
+	for _, summary := range projection.Accounts {
+		summary.EndingBalanceCents = balances[summary.AccountID]
+		projection.EndingBalanceCents += summary.EndingBalanceCents
+	}
+	if m.days > 0 {
+		projection.Confidence = totalConfidence / float64(m.days)
+	}
+	return projection
+}
+
+// spreadCents returns the whole cents of a fractional daily amount that fall on
+// the given day, so the running total never drifts from rate * days
+func spreadCents(rate float64, offset int) int64 {
+	return int64(math.Round(rate*float64(offset))) - int64(math.Round(rate*float64(offset-1)))
+}
+
+// IsLiabilityAccount reports whether an account type normally carries a
+// negative balance
+func IsLiabilityAccount(accountType string) bool {
+	return accountType == models.AccountTypeCredit || accountType == models.AccountTypeLoan
+}
+
+// percentile returns the p-th percentile (0-1) of values, interpolating linearly
+// between the closest ranks
+func percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return sorted[lower]
+	}
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
+// meanFloat returns the mean of values, or 0 if there are none
+func meanFloat(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// stdDevFloat returns the population standard deviation of values around mean
+func stdDevFloat(values []float64, mean float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(sum / float64(len(values)))
+}
+
+// daysBetween returns the whole days from one midnight to another
+func daysBetween(from, to time.Time) int {
+	return int(math.Round(to.Sub(from).Hours() / 24))
+}
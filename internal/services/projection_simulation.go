@@ -0,0 +1,228 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"gorm.io/gorm"
+)
+
+// Balance percentiles reported by a simulation
+const (
+	simulationLow    = 0.10
+	simulationMedian = 0.50
+	simulationHigh   = 0.90
+)
+
+// MaxSimulationRuns caps the number of simulations in one run
+const MaxSimulationRuns = 100000
+
+// SimulationOptions controls a Monte Carlo cash flow simulation
+type SimulationOptions struct {
+	Days                 int
+	AccountID            *uint // nil simulates all active accounts
+	HistoricalWindowDays int
+	Runs                 int
+	Seed                 int64
+}
+
+// SimulationDay holds the balance bands across all simulations for one day
+type SimulationDay struct {
+	Date       time.Time `json:"date"`
+	P10Cents   int64     `json:"p10_cents"`
+	P50Cents   int64     `json:"p50_cents"`
+	P90Cents   int64     `json:"p90_cents"`
+	Confidence float64   `json:"confidence"`
+}
+
+// AccountSimulation summarizes the simulations of one account. The overdraft
+// probability is the share of simulations in which the balance went below zero
+// on any day; it is always zero for credit and loan accounts.
+type AccountSimulation struct {
+	AccountID            uint    `json:"account_id"`
+	Name                 string  `json:"name"`
+	Type                 string  `json:"type"`
+	StartBalanceCents    int64   `json:"start_balance_cents"`
+	P10EndingCents       int64   `json:"p10_ending_cents"`
+	P50EndingCents       int64   `json:"p50_ending_cents"`
+	P90EndingCents       int64   `json:"p90_ending_cents"`
+	OverdraftProbability float64 `json:"overdraft_probability"`
+}
+
+// Simulation is the result of a Monte Carlo cash flow simulation
+type Simulation struct {
+	Runs              int                  `json:"runs"`
+	Seed              int64                `json:"seed"`
+	AccountID         *uint                `json:"account_id,omitempty"`
+	StartDate         time.Time            `json:"start_date"`
+	HistoryDays       int                  `json:"history_days"`
+	StartBalanceCents int64                `json:"start_balance_cents"`
+	Accounts          []*AccountSimulation `json:"accounts"`
+	Days              []*SimulationDay     `json:"days"`
+	GeneratedAt       time.Time            `json:"generated_at"`
+}
+
+// Simulate runs opts.Runs simulations of the coming days. Every simulation
+// applies the same scheduled recurring flows as Project, and for each day draws
+// one day at random from the historical window, applying each account's
+// unscheduled spending on that day, so accounts that are spent from together
+// stay correlated. The same seed always gives the same result.
+func (s *ProjectionService) Simulate(opts SimulationOptions) (*Simulation, error) {
+	if opts.Runs <= 0 {
+		return nil, fmt.Errorf("number of simulations must be positive")
+	}
+	if opts.Runs > MaxSimulationRuns {
+		return nil, fmt.Errorf("number of simulations cannot exceed %d", MaxSimulationRuns)
+	}
+
+	model, err := s.load(ProjectionOptions{
+		Days:                 opts.Days,
+		AccountID:            opts.AccountID,
+		HistoricalWindowDays: opts.HistoricalWindowDays,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sim := model.simulate(opts.Runs, rand.New(rand.NewSource(opts.Seed)))
+	sim.Seed = opts.Seed
+	sim.AccountID = opts.AccountID
+	sim.GeneratedAt = s.now()
+	return sim, nil
+}
+
+// SaveSimulation stores a simulation's balance bands as projections: the P50 path
+// as the moderate projection, P10 as conservative and P90 as optimistic, each
+// replacing the earlier projection of that type for the same account. The three
+// are replaced together, so a failure leaves the earlier projections in place.
+func (s *ProjectionService) SaveSimulation(sim *Simulation) error {
+	bands := []struct {
+		projectionType string
+		balance        func(day *SimulationDay) int64
+	}{
+		{ScenarioConservative, func(day *SimulationDay) int64 { return day.P10Cents }},
+		{ScenarioModerate, func(day *SimulationDay) int64 { return day.P50Cents }},
+		{ScenarioOptimistic, func(day *SimulationDay) int64 { return day.P90Cents }},
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		repo := repositories.NewProjectionRepository(tx)
+		for _, band := range bands {
+			rows := make([]*models.CashFlowProjection, 0, len(sim.Days))
+			for _, day := range sim.Days {
+				rows = append(rows, &models.CashFlowProjection{
+					AccountID:             sim.AccountID,
+					ProjectionDate:        day.Date,
+					ProjectedBalanceCents: band.balance(day),
+					ConfidenceLevel:       day.Confidence,
+					ProjectionType:        band.projectionType,
+					GeneratedAt:           sim.GeneratedAt,
+				})
+			}
+			if err := repo.Replace(sim.AccountID, band.projectionType, rows); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save simulation: %w", err)
+	}
+	return nil
+}
+
+// simulate runs the given number of simulations, drawing history days from rng
+func (m *projectionModel) simulate(runs int, rng *rand.Rand) *Simulation {
+	flows := m.scheduledFlows()
+	base := m.baseConfidence()
+
+	sim := &Simulation{
+		Runs:        runs,
+		StartDate:   m.today,
+		HistoryDays: m.historyDays,
+		Days:        make([]*SimulationDay, 0, m.days),
+	}
+
+	totals := make([][]float64, m.days) // Combined balance per day per run
+	for offset := range totals {
+		totals[offset] = make([]float64, runs)
+	}
+	endings := make([][]float64, len(m.accounts)) // Ending balance per account per run
+	overdrafts := make([]int, len(m.accounts))
+	for i, account := range m.accounts {
+		endings[i] = make([]float64, runs)
+		sim.StartBalanceCents += account.CurrentBalanceCents
+	}
+
+	balances := make([]int64, len(m.accounts))
+	overdrawn := make([]bool, len(m.accounts))
+	for run := 0; run < runs; run++ {
+		for i, account := range m.accounts {
+			balances[i] = account.CurrentBalanceCents
+			overdrawn[i] = false
+		}
+
+		for offset := 1; offset <= m.days; offset++ {
+			historyDay := -1
+			if m.historyDays > 0 {
+				historyDay = rng.Intn(m.historyDays)
+			}
+
+			var total int64
+			for i, account := range m.accounts {
+				if flow := flows[account.ID][offset]; flow != nil {
+					balances[i] += flow.incomeCents + flow.expensesCents
+				}
+				if historyDay >= 0 {
+					balances[i] -= m.daily[account.ID][historyDay]
+				}
+				if balances[i] < 0 && !IsLiabilityAccount(account.Type) {
+					overdrawn[i] = true
+				}
+				total += balances[i]
+			}
+			totals[offset-1][run] = float64(total)
+		}
+
+		for i := range m.accounts {
+			endings[i][run] = float64(balances[i])
+			if overdrawn[i] {
+				overdrafts[i]++
+			}
+		}
+	}
+
+	for offset := 1; offset <= m.days; offset++ {
+		values := totals[offset-1]
+		sim.Days = append(sim.Days, &SimulationDay{
+			Date:       m.today.AddDate(0, 0, offset),
+			P10Cents:   roundCents(percentile(values, simulationLow)),
+			P50Cents:   roundCents(percentile(values, simulationMedian)),
+			P90Cents:   roundCents(percentile(values, simulationHigh)),
+			Confidence: dayConfidence(base, offset),
+		})
+	}
+
+	for i, account := range m.accounts {
+		sim.Accounts = append(sim.Accounts, &AccountSimulation{
+			AccountID:            account.ID,
+			Name:                 account.Name,
+			Type:                 account.Type,
+			StartBalanceCents:    account.CurrentBalanceCents,
+			P10EndingCents:       roundCents(percentile(endings[i], simulationLow)),
+			P50EndingCents:       roundCents(percentile(endings[i], simulationMedian)),
+			P90EndingCents:       roundCents(percentile(endings[i], simulationHigh)),
+			OverdraftProbability: float64(overdrafts[i]) / float64(runs),
+		})
+	}
+	return sim
+}
+
+// roundCents rounds a fractional amount to whole cents
+func roundCents(cents float64) int64 {
+	return int64(math.Round(cents))
+}
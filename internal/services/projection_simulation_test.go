@@ -0,0 +1,97 @@
+package services
+
+import (
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *ProjectionTestSuite) simulate(runs int, seed int64) *Simulation {
+	sim, err := suite.svc.Simulate(SimulationOptions{Days: 30, HistoricalWindowDays: 28, Runs: runs, Seed: seed})
+	assert.NoError(suite.T(), err)
+	return sim
+}
+
+func (suite *ProjectionTestSuite) TestSimulateIsDeterministic() {
+	first := suite.simulate(200, 42)
+	again := suite.simulate(200, 42)
+	other := suite.simulate(200, 7)
+
+	assert.Equal(suite.T(), first.Days, again.Days)
+	assert.Equal(suite.T(), first.Accounts, again.Accounts)
+	assert.NotEqual(suite.T(), first.Days, other.Days)
+}
+
+func (suite *ProjectionTestSuite) TestSimulateBands() {
+	savings := suite.createAccount("Savings", models.AccountTypeSavings, 500000)
+
+	sim := suite.simulate(500, 1)
+
+	assert.Equal(suite.T(), 500, sim.Runs)
+	assert.Equal(suite.T(), 28, sim.HistoryDays)
+	assert.Len(suite.T(), sim.Days, 30)
+	assert.Equal(suite.T(), int64(600000), sim.StartBalanceCents)
+	for _, day := range sim.Days {
+		assert.LessOrEqual(suite.T(), day.P10Cents, day.P50Cents)
+		assert.LessOrEqual(suite.T(), day.P50Cents, day.P90Cents)
+	}
+
+	// The last day's spread comes only from spending: rent is the same in every run
+	last := sim.Days[29]
+	assert.Less(suite.T(), last.P10Cents, last.P90Cents)
+	assert.LessOrEqual(suite.T(), last.P90Cents, int64(600000-150000))
+
+	// Rent overdraws checking in every run; savings is never touched
+	assert.Len(suite.T(), sim.Accounts, 2)
+	for _, account := range sim.Accounts {
+		switch account.AccountID {
+		case suite.account.ID:
+			assert.Equal(suite.T(), 1.0, account.OverdraftProbability)
+		case savings.ID:
+			assert.Equal(suite.T(), 0.0, account.OverdraftProbability)
+			assert.Equal(suite.T(), int64(500000), account.P50EndingCents)
+		}
+	}
+}
+
+func (suite *ProjectionTestSuite) TestSimulateWithSteadySpending() {
+	// Given $10 of spending every day of the last four weeks and nothing else
+	assert.NoError(suite.T(), suite.db.Where("1 = 1").Delete(&models.Transaction{}).Error)
+	for day := 0; day < 28; day++ {
+		suite.spend(day, -1000, nil, models.TransactionTypeExpense)
+	}
+
+	// Then every run is the same, and matches the single-path projection
+	sim := suite.simulate(50, 3)
+	projection := suite.project(ScenarioModerate, 30)
+	for i, day := range sim.Days {
+		assert.Equal(suite.T(), day.P10Cents, day.P90Cents)
+		assert.Equal(suite.T(), projection.Days[i].BalanceCents, day.P50Cents)
+	}
+}
+
+func (suite *ProjectionTestSuite) TestSaveSimulation() {
+	sim := suite.simulate(100, 9)
+	assert.NoError(suite.T(), suite.svc.SaveSimulation(sim))
+
+	repo := repositories.NewProjectionRepository(suite.db)
+	moderate, err := repo.List(nil, ScenarioModerate)
+	assert.NoError(suite.T(), err)
+	if assert.Len(suite.T(), moderate, 30) {
+		assert.Equal(suite.T(), sim.Days[0].P50Cents, moderate[0].ProjectedBalanceCents)
+	}
+
+	conservative, err := repo.List(nil, ScenarioConservative)
+	assert.NoError(suite.T(), err)
+	if assert.Len(suite.T(), conservative, 30) {
+		assert.Equal(suite.T(), sim.Days[29].P10Cents, conservative[29].ProjectedBalanceCents)
+	}
+}
+
+func (suite *ProjectionTestSuite) TestSimulateValidatesRuns() {
+	_, err := suite.svc.Simulate(SimulationOptions{Days: 30})
+	assert.EqualError(suite.T(), err, "number of simulations must be positive")
+
+	_, err = suite.svc.Simulate(SimulationOptions{Days: 30, Runs: MaxSimulationRuns + 1})
+	assert.Error(suite.T(), err)
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestScenarioPercentile(t *testing.T) {
+	p, err := ScenarioPercentile(nil, "Conservative")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.75, p)
+
+	p, err = ScenarioPercentile(map[string]float64{"stress": 0.9}, "stress")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.9, p)
+
+	_, err = ScenarioPercentile(nil, "wild")
+	assert.EqualError(t, err, "invalid scenario: wild (valid: conservative, moderate, optimistic)")
+
+	_, err = ScenarioPercentile(map[string]float64{"broken": 1.5}, "broken")
+	assert.Error(t, err)
+}
+
+func TestPercentile(t *testing.T) {
+	values := []float64{280, 70, 210, 140}
+	assert.Equal(t, 70.0, percentile(values, 0))
+	assert.Equal(t, 175.0, percentile(values, 0.5))
+	assert.Equal(t, 227.5, percentile(values, 0.75))
+	assert.Equal(t, 280.0, percentile(values, 1))
+	assert.Equal(t, 0.0, percentile(nil, 0.5))
+}
+
+func TestSpreadCents(t *testing.T) {
+	var total int64
+	for day := 1; day <= 30; day++ {
+		total += spreadCents(1234.5, day)
+	}
+	assert.Equal(t, int64(37035), total)
+}
+
+// ProjectionTestSuite is the test suite for cash flow projections
+type ProjectionTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	svc     *ProjectionService
+	account *models.Account
+	today   time.Time
+}
+
+// SetupTest runs before each test
+func (suite *ProjectionTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), db.AutoMigrate(&models.Account{}, &models.Transaction{}, &models.RecurringItem{}, &models.CashFlowProjection{}))
+
+	suite.db = db
+	suite.today = time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	suite.svc = NewProjectionService(db)
+	suite.svc.now = func() time.Time { return suite.today.Add(14 * time.Hour) }
+
+	suite.account = suite.createAccount("Checking", models.AccountTypeChecking, 100000)
+
+	// Four weeks of unscheduled spending: $70, $140, $210 and $280 a week
+	suite.spend(1, -7000, nil, models.TransactionTypeExpense)
+	suite.spend(8, -14000, nil, models.TransactionTypeExpense)
+	suite.spend(15, -21000, nil, models.TransactionTypeExpense)
+	suite.spend(27, -28000, nil, models.TransactionTypeExpense)
+
+	// None of these are discretionary spending
+	recurringID := uint(99)
+	suite.spend(3, -150000, &recurringID, models.TransactionTypeExpense)
+	suite.spend(4, 320000, nil, models.TransactionTypeIncome)
+	suite.spend(5, -50000, nil, models.TransactionTypeTransfer)
+
+	rent := &models.RecurringItem{
+		AccountID:         suite.account.ID,
+		Name:              "Rent",
+		AmountCents:       -150000,
+		Frequency:         models.FrequencyMonthly,
+		FrequencyInterval: 1,
+		StartDate:         time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		NextDate:          time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+		IsActive:          true,
+	}
+	assert.NoError(suite.T(), repositories.NewRecurringRepository(db).Create(rent))
+}
+
+func (suite *ProjectionTestSuite) createAccount(name, accountType string, cents int64) *models.Account {
+	account := &models.Account{Name: name, Type: accountType, Currency: "USD", InitialBalanceCents: cents, IsActive: true}
+	assert.NoError(suite.T(), repositories.NewAccountRepository(suite.db).Create(account))
+	return account
+}
+
+// spend records a transaction daysAgo days before today without touching the balance
+func (suite *ProjectionTestSuite) spend(daysAgo int, cents int64, recurringID *uint, txType string) {
+	tx := &models.Transaction{
+		AccountID:   suite.account.ID,
+		Date:        suite.today.AddDate(0, 0, -daysAgo),
+		AmountCents: cents,
+		Type:        txType,
+		RecurringID: recurringID,
+		Tags:        models.StringArray{},
+	}
+	assert.NoError(suite.T(), suite.db.Create(tx).Error)
+}
+
+func (suite *ProjectionTestSuite) project(scenario string, days int) *Projection {
+	p, err := ScenarioPercentile(nil, scenario)
+	assert.NoError(suite.T(), err)
+	projection, err := suite.svc.Project(ProjectionOptions{
+		Days:                 days,
+		Scenario:             scenario,
+		Percentile:           p,
+		HistoricalWindowDays: 28,
+	})
+	assert.NoError(suite.T(), err)
+	return projection
+}
+
+func (suite *ProjectionTestSuite) TestModerateProjection() {
+	// When projecting 30 days at the median of $70-$280 weekly spending ($25 a day)
+	projection := suite.project(ScenarioModerate, 30)
+
+	// Then every day spends $25 and rent lands on the 1st
+	assert.Len(suite.T(), projection.Days, 30)
+	first := projection.Days[0]
+	assert.Equal(suite.T(), "2026-10-17", first.Date.Format("2006-01-02"))
+	assert.Equal(suite.T(), int64(-2500), first.ExpensesCents)
+	assert.Equal(suite.T(), int64(97500), first.BalanceCents)
+
+	rentDay := projection.Days[15]
+	assert.Equal(suite.T(), "2026-11-01", rentDay.Date.Format("2006-01-02"))
+	assert.Equal(suite.T(), []string{"Rent"}, rentDay.Events)
+	assert.Equal(suite.T(), int64(-152500), rentDay.ExpensesCents)
+	assert.Equal(suite.T(), int64(100000-16*2500-150000), rentDay.BalanceCents)
+
+	assert.Equal(suite.T(), 28, projection.HistoryDays)
+	assert.Equal(suite.T(), int64(-150000), projection.ScheduledExpensesCents)
+	assert.Equal(suite.T(), int64(-75000), projection.DiscretionaryCents)
+	assert.Equal(suite.T(), int64(100000-75000-150000), projection.EndingBalanceCents)
+
+	// And the overdraft on rent day is flagged
+	if assert.NotNil(suite.T(), projection.FirstNegative) {
+		assert.Equal(suite.T(), "Checking", projection.FirstNegative.AccountName)
+		assert.Equal(suite.T(), "2026-11-01", projection.FirstNegative.Date.Format("2006-01-02"))
+	}
+
+	// And confidence decays with distance
+	assert.Greater(suite.T(), first.Confidence, projection.Days[29].Confidence)
+	assert.LessOrEqual(suite.T(), first.Confidence, 1.0)
+}
+
+func (suite *ProjectionTestSuite) TestScenariosDiffer() {
+	conservative := suite.project(ScenarioConservative, 14)
+	optimistic := suite.project(ScenarioOptimistic, 14)
+
+	// $227.50 and $122.50 a week
+	assert.Equal(suite.T(), int64(-45500), conservative.DiscretionaryCents)
+	assert.Equal(suite.T(), int64(-24500), optimistic.DiscretionaryCents)
+	assert.Nil(suite.T(), conservative.FirstNegative)
+}
+
+func (suite *ProjectionTestSuite) TestCreditAccountsAreNotOverdrafts() {
+	card := suite.createAccount("Visa", models.AccountTypeCredit, -50000)
+
+	projection, err := suite.svc.Project(ProjectionOptions{Days: 10, AccountID: &card.ID, Scenario: ScenarioModerate, Percentile: 0.5})
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), projection.FirstNegative)
+	assert.Len(suite.T(), projection.Accounts, 1)
+	assert.Equal(suite.T(), int64(-50000), projection.EndingBalanceCents)
+	assert.Zero(suite.T(), projection.HistoryDays)
+	assert.Less(suite.T(), projection.Confidence, 0.3)
+}
+
+func (suite *ProjectionTestSuite) TestSaveReplacesEarlierProjection() {
+	repo := repositories.NewProjectionRepository(suite.db)
+
+	assert.NoError(suite.T(), suite.svc.Save(suite.project(ScenarioModerate, 30)))
+	assert.NoError(suite.T(), suite.svc.Save(suite.project(ScenarioModerate, 10)))
+	assert.NoError(suite.T(), suite.svc.Save(suite.project(ScenarioConservative, 5)))
+
+	rows, err := repo.List(nil, ScenarioModerate)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), rows, 10)
+	assert.Equal(suite.T(), int64(97500), rows[0].ProjectedBalanceCents)
+	assert.Greater(suite.T(), rows[0].ConfidenceLevel, 0.0)
+
+	rows, err = repo.List(&suite.account.ID, ScenarioModerate)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), rows)
+}
+
+func (suite *ProjectionTestSuite) TestSaveRejectsCustomScenario() {
+	projection, err := suite.svc.Project(ProjectionOptions{Days: 10, Scenario: "cautious", Percentile: 0.6, HistoricalWindowDays: 28})
+	assert.NoError(suite.T(), err)
+
+	err = suite.svc.Save(projection)
+	assert.EqualError(suite.T(), err, "cannot save a projection of custom scenario cautious; only conservative, moderate and optimistic are stored")
+
+	rows, err := repositories.NewProjectionRepository(suite.db).List(nil, "cautious")
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), rows)
+}
+
+func TestIsStoredScenario(t *testing.T) {
+	assert.True(t, IsStoredScenario(ScenarioModerate))
+	assert.True(t, IsStoredScenario("Conservative"))
+	assert.False(t, IsStoredScenario("cautious"))
+}
+
+func TestProjectionTestSuite(t *testing.T) {
+	suite.Run(t, new(ProjectionTestSuite))
+}
@@ -0,0 +1,359 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/fintrack/fintrack/internal/recurrence"
+	"gorm.io/gorm"
+)
+
+// DetectOptions controls recurring transaction detection
+type DetectOptions struct {
+	Since           time.Time // Analyse transactions on or after this day
+	MinOccurrences  int       // Fewest matching transactions for a candidate (default 3)
+	AmountTolerance float64   // Allowed deviation from the typical amount, as a fraction (default 0.10)
+	MinConfidence   float64   // Drop candidates scoring below this (0.0-1.0)
+}
+
+// RecurringCandidate is a detected recurring payment that could become a RecurringItem
+type RecurringCandidate struct {
+	Name            string    `json:"name"`
+	NormalizedPayee string    `json:"normalized_payee"`
+	AccountID       uint      `json:"account_id"`
+	AccountName     string    `json:"account_name,omitempty"`
+	CategoryID      *uint     `json:"category_id,omitempty"`
+	AmountCents     int64     `json:"amount_cents"` // Median of the matched transactions
+	Frequency       string    `json:"frequency"`
+	DayOfMonth      *int      `json:"day_of_month,omitempty"`
+	DayOfWeek       *int      `json:"day_of_week,omitempty"`
+	FirstDate       time.Time `json:"first_date"`
+	LastDate        time.Time `json:"last_date"`
+	NextDate        time.Time `json:"next_date"`
+	Occurrences     int       `json:"occurrences"`
+	Confidence      float64   `json:"confidence"` // 0.0-1.0
+	TransactionIDs  []uint    `json:"transaction_ids"`
+}
+
+// frequencyBand is the range of days between payments accepted for a frequency
+type frequencyBand struct {
+	frequency string
+	days      float64 // Typical days between payments
+	min, max  float64
+}
+
+// detectableFrequencies lists the frequencies detection recognises. Daily
+// payments are too noisy to tell apart from ordinary repeat shopping.
+var detectableFrequencies = []frequencyBand{
+	{models.FrequencyWeekly, 7, 6, 8},
+	{models.FrequencyBiweekly, 14, 12, 16},
+	{models.FrequencyMonthly, 30.44, 26, 35},
+	{models.FrequencyQuarterly, 91.31, 84, 98},
+	{models.FrequencyAnnual, 365.25, 350, 380},
+}
+
+var (
+	payeeNoise      = regexp.MustCompile(`[#*]?\d[\d\-/.:]*|[^\p{L}\s&]`)
+	payeePrefixes   = []string{"pos ", "debit ", "ach ", "purchase ", "recurring ", "autopay ", "sq ", "tst ", "paypal ", "pp "}
+	payeeWhitespace = regexp.MustCompile(`\s+`)
+)
+
+// NormalizePayee reduces a bank payee string to a stable key by lower-casing it and
+// dropping reference numbers, punctuation and common card-processor prefixes, so
+// "NETFLIX.COM 866-579-7172" and "Netflix.com #1234" group together
+func NormalizePayee(payee string) string {
+	s := strings.ToLower(payee)
+	s = payeeNoise.ReplaceAllString(s, " ")
+	s = strings.TrimSpace(payeeWhitespace.ReplaceAllString(s, " "))
+
+	for trimmed := true; trimmed; {
+		trimmed = false
+		for _, prefix := range payeePrefixes {
+			if strings.HasPrefix(s, prefix) {
+				s = strings.TrimSpace(strings.TrimPrefix(s, prefix))
+				trimmed = true
+			}
+		}
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(s, " com"), " inc")
+}
+
+// RecurringDetector finds recurring payments in transaction history
+type RecurringDetector struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+// NewRecurringDetector creates a new recurring transaction detector
+func NewRecurringDetector(db *gorm.DB) *RecurringDetector {
+	return &RecurringDetector{db: db, now: time.Now}
+}
+
+// Detect analyses transactions since opts.Since, grouping them by account,
+// normalised payee and direction, and proposes a candidate for every group with
+// enough payments of a similar amount at regular intervals. Payees that already
+// have a recurring item, and transactions already linked to one, are skipped.
+// Candidates are ordered by confidence, highest first.
+func (d *RecurringDetector) Detect(opts DetectOptions) ([]*RecurringCandidate, error) {
+	if opts.MinOccurrences < 2 {
+		opts.MinOccurrences = 3
+	}
+	if opts.AmountTolerance <= 0 {
+		opts.AmountTolerance = 0.10
+	}
+
+	since := recurrence.Day(opts.Since)
+	transactions, err := repositories.NewTransactionRepository(d.db).List(repositories.TransactionFilter{DateFrom: &since})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transactions: %w", err)
+	}
+
+	items, err := repositories.NewRecurringRepository(d.db).List(false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recurring items: %w", err)
+	}
+	scheduled := make(map[string]bool, len(items))
+	for _, item := range items {
+		scheduled[fmt.Sprintf("%d|%s", item.AccountID, NormalizePayee(item.Name))] = true
+	}
+
+	groups := make(map[string][]*models.Transaction)
+	var keys []string
+	for _, tx := range transactions {
+		if tx.RecurringID != nil || tx.Type == models.TransactionTypeTransfer || tx.AmountCents == 0 {
+			continue
+		}
+		payee := NormalizePayee(tx.Payee)
+		if payee == "" || scheduled[fmt.Sprintf("%d|%s", tx.AccountID, payee)] {
+			continue
+		}
+		key := fmt.Sprintf("%d|%s|%t", tx.AccountID, payee, tx.AmountCents > 0)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], tx)
+	}
+
+	today := recurrence.Day(d.now())
+	var candidates []*RecurringCandidate
+	for _, key := range keys {
+		c := detectCandidate(groups[key], opts, today)
+		if c != nil && c.Confidence >= opts.MinConfidence {
+			candidates = append(candidates, c)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Confidence != candidates[j].Confidence {
+			return candidates[i].Confidence > candidates[j].Confidence
+		}
+		return candidates[i].NormalizedPayee < candidates[j].NormalizedPayee
+	})
+	return candidates, nil
+}
+
+// detectCandidate scores one payee group, returning nil if it isn't recurring
+func detectCandidate(group []*models.Transaction, opts DetectOptions, today time.Time) *RecurringCandidate {
+	if len(group) < opts.MinOccurrences {
+		return nil
+	}
+
+	// Keep the payments close to the typical amount
+	median := medianCents(group)
+	var matched []*models.Transaction
+	var deviation float64
+	for _, tx := range group {
+		diff := math.Abs(float64(tx.AmountCents-median)) / math.Abs(float64(median))
+		if diff <= opts.AmountTolerance {
+			matched = append(matched, tx)
+			deviation += diff
+		}
+	}
+	if len(matched) < opts.MinOccurrences {
+		return nil
+	}
+
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Date.Before(matched[j].Date) })
+
+	// Several payments on one day count once
+	var dates []time.Time
+	for _, tx := range matched {
+		day := recurrence.Day(tx.Date)
+		if len(dates) == 0 || !day.Equal(dates[len(dates)-1]) {
+			dates = append(dates, day)
+		}
+	}
+	if len(dates) < opts.MinOccurrences {
+		return nil
+	}
+
+	intervals := make([]float64, 0, len(dates)-1)
+	for i := 1; i < len(dates); i++ {
+		intervals = append(intervals, dates[i].Sub(dates[i-1]).Hours()/24)
+	}
+	band, ok := classifyInterval(medianFloat(intervals))
+	if !ok {
+		return nil
+	}
+
+	regular := 0
+	for _, days := range intervals {
+		if days >= band.min && days <= band.max {
+			regular++
+		}
+	}
+
+	intervalScore := float64(regular) / float64(len(intervals))
+	amountScore := math.Max(0, 1-(deviation/float64(len(matched)))/opts.AmountTolerance)
+	countScore := 1 - 1/float64(len(dates))
+	confidence := 0.5*intervalScore + 0.3*amountScore + 0.2*countScore
+
+	// A payment that stopped well over a period ago was probably cancelled
+	last := dates[len(dates)-1]
+	if today.Sub(last).Hours()/24 > 2*band.days {
+		confidence *= 0.5
+	}
+
+	latest := matched[len(matched)-1]
+	c := &RecurringCandidate{
+		Name:            strings.TrimSpace(latest.Payee),
+		NormalizedPayee: NormalizePayee(latest.Payee),
+		AccountID:       latest.AccountID,
+		CategoryID:      latest.CategoryID,
+		AmountCents:     median,
+		Frequency:       band.frequency,
+		FirstDate:       dates[0],
+		LastDate:        last,
+		Occurrences:     len(dates),
+		Confidence:      math.Round(confidence*100) / 100,
+	}
+	if latest.Account != nil {
+		c.AccountName = latest.Account.Name
+	}
+	for _, tx := range matched {
+		c.TransactionIDs = append(c.TransactionIDs, tx.ID)
+	}
+
+	switch band.frequency {
+	case models.FrequencyWeekly, models.FrequencyBiweekly:
+		wd := modeInt(dates, func(t time.Time) int { return int(t.Weekday()) })
+		c.DayOfWeek = &wd
+	default:
+		dom := modeInt(dates, func(t time.Time) int { return t.Day() })
+		c.DayOfMonth = &dom
+	}
+
+	rule := recurrence.FromItem(c.recurringItem())
+	next, _ := rule.Next(last)
+	if next.Before(today) {
+		next, _ = rule.OnOrAfter(today)
+	}
+	c.NextDate = next
+
+	return c
+}
+
+// recurringItem builds the recurring item a candidate proposes
+func (c *RecurringCandidate) recurringItem() *models.RecurringItem {
+	return &models.RecurringItem{
+		AccountID:         c.AccountID,
+		Name:              c.Name,
+		AmountCents:       c.AmountCents,
+		CategoryID:        c.CategoryID,
+		Frequency:         c.Frequency,
+		FrequencyInterval: 1,
+		DayOfMonth:        c.DayOfMonth,
+		DayOfWeek:         c.DayOfWeek,
+		StartDate:         c.FirstDate,
+		NextDate:          c.NextDate,
+		LastGeneratedDate: &c.LastDate,
+		IsActive:          true,
+	}
+}
+
+// Accept creates a recurring item for each candidate and links the candidate's
+// transactions to it, all in one database transaction
+func (d *RecurringDetector) Accept(candidates []*RecurringCandidate, reminderDaysBefore int) ([]*models.RecurringItem, error) {
+	var items []*models.RecurringItem
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		recurringRepo := repositories.NewRecurringRepository(tx)
+		txRepo := repositories.NewTransactionRepository(tx)
+
+		for _, c := range candidates {
+			item := c.recurringItem()
+			item.ReminderDaysBefore = reminderDaysBefore
+			if err := ValidateRecurringItem(item); err != nil {
+				return fmt.Errorf("%s: %w", c.Name, err)
+			}
+			if err := recurringRepo.Create(item); err != nil {
+				return fmt.Errorf("failed to create recurring item %s: %w", c.Name, err)
+			}
+			if err := txRepo.LinkRecurring(c.TransactionIDs, item.ID); err != nil {
+				return fmt.Errorf("failed to link transactions to %s: %w", c.Name, err)
+			}
+			items = append(items, item)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// classifyInterval maps a typical number of days between payments to a frequency
+func classifyInterval(days float64) (frequencyBand, bool) {
+	for _, band := range detectableFrequencies {
+		if days >= band.min && days <= band.max {
+			return band, true
+		}
+	}
+	return frequencyBand{}, false
+}
+
+func medianCents(txs []*models.Transaction) int64 {
+	amounts := make([]int64, len(txs))
+	for i, tx := range txs {
+		amounts[i] = tx.AmountCents
+	}
+	sort.Slice(amounts, func(i, j int) bool { return amounts[i] < amounts[j] })
+	n := len(amounts)
+	if n%2 == 1 {
+		return amounts[n/2]
+	}
+	return (amounts[n/2-1] + amounts[n/2]) / 2
+}
+
+func medianFloat(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n == 0 {
+		return 0
+	}
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// modeInt returns the most common value of key over dates, preferring the larger
+// value on ties (so month-end payments settle on the later day)
+func modeInt(dates []time.Time, key func(time.Time) int) int {
+	counts := make(map[int]int)
+	best, bestCount := 0, 0
+	for _, d := range dates {
+		k := key(d)
+		counts[k]++
+		if counts[k] > bestCount || (counts[k] == bestCount && k > best) {
+			best, bestCount = k, counts[k]
+		}
+	}
+	return best
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestNormalizePayee(t *testing.T) {
+	tests := []struct {
+		payee string
+		want  string
+	}{
+		{"NETFLIX.COM 866-579-7172", "netflix"},
+		{"Netflix.com #1234", "netflix"},
+		{"POS DEBIT Spotify USA", "spotify usa"},
+		{"SQ *BLUE BOTTLE COFFEE", "blue bottle coffee"},
+		{"ACME Insurance, Inc.", "acme insurance"},
+		{"Rent 10/01/2026", "rent"},
+		{"12345", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.payee, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizePayee(tt.payee))
+		})
+	}
+}
+
+// RecurringDetectorTestSuite is the test suite for recurring transaction detection
+type RecurringDetectorTestSuite struct {
+	suite.Suite
+	db       *gorm.DB
+	detector *RecurringDetector
+	account  *models.Account
+}
+
+// SetupTest runs before each test
+func (suite *RecurringDetectorTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), db.AutoMigrate(&models.Account{}, &models.Category{}, &models.Transaction{}, &models.RecurringItem{}))
+
+	suite.db = db
+	suite.detector = NewRecurringDetector(db)
+	suite.detector.now = func() time.Time { return time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC) }
+
+	suite.account = &models.Account{Name: "Checking", Type: models.AccountTypeChecking, Currency: "USD", InitialBalanceCents: 500000, IsActive: true}
+	assert.NoError(suite.T(), repositories.NewAccountRepository(db).Create(suite.account))
+}
+
+func (suite *RecurringDetectorTestSuite) createTx(payee string, amount int64, date time.Time) *models.Transaction {
+	txType := models.TransactionTypeExpense
+	if amount > 0 {
+		txType = models.TransactionTypeIncome
+	}
+	tx := &models.Transaction{
+		AccountID:   suite.account.ID,
+		Date:        date,
+		AmountCents: amount,
+		Payee:       payee,
+		Type:        txType,
+		Tags:        models.StringArray{},
+	}
+	assert.NoError(suite.T(), suite.db.Create(tx).Error)
+	return tx
+}
+
+// createMonthly adds one payment per month from start for n months
+func (suite *RecurringDetectorTestSuite) createMonthly(payee string, amounts []int64, start time.Time) {
+	for i, amount := range amounts {
+		suite.createTx(payee, amount, start.AddDate(0, i, 0))
+	}
+}
+
+func (suite *RecurringDetectorTestSuite) TestDetect_Monthly() {
+	// Given six monthly streaming charges with changing reference numbers
+	start := time.Date(2026, 5, 3, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 6; i++ {
+		suite.createTx(fmt.Sprintf("NETFLIX.COM #%d", 1040+i), -1599, start.AddDate(0, i, 0))
+	}
+
+	// When detecting
+	candidates, err := suite.detector.Detect(DetectOptions{Since: time.Date(2025, 10, 16, 0, 0, 0, 0, time.UTC)})
+
+	// Then one confident monthly candidate is proposed for next month
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), candidates, 1)
+
+	c := candidates[0]
+	assert.Equal(suite.T(), "netflix", c.NormalizedPayee)
+	assert.Equal(suite.T(), models.FrequencyMonthly, c.Frequency)
+	assert.Equal(suite.T(), int64(-1599), c.AmountCents)
+	assert.Equal(suite.T(), 3, *c.DayOfMonth)
+	assert.Equal(suite.T(), 6, c.Occurrences)
+	assert.Len(suite.T(), c.TransactionIDs, 6)
+	assert.Equal(suite.T(), "2026-10-03", c.LastDate.Format("2006-01-02"))
+	assert.Equal(suite.T(), "2026-11-03", c.NextDate.Format("2006-01-02"))
+	assert.Greater(suite.T(), c.Confidence, 0.9)
+}
+
+func (suite *RecurringDetectorTestSuite) TestDetect_Weekly() {
+	start := time.Date(2026, 8, 7, 0, 0, 0, 0, time.UTC) // Friday
+	for i := 0; i < 10; i++ {
+		suite.createTx("Payroll ACH", 120000, start.AddDate(0, 0, 7*i))
+	}
+
+	candidates, err := suite.detector.Detect(DetectOptions{Since: start})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), candidates, 1)
+	assert.Equal(suite.T(), models.FrequencyWeekly, candidates[0].Frequency)
+	assert.Equal(suite.T(), int(time.Friday), *candidates[0].DayOfWeek)
+	assert.Nil(suite.T(), candidates[0].DayOfMonth)
+}
+
+func (suite *RecurringDetectorTestSuite) TestDetect_AmountTolerance() {
+	// Utility bill varying within 5%, plus one outlier
+	start := time.Date(2026, 4, 20, 0, 0, 0, 0, time.UTC)
+	suite.createMonthly("City Power", []int64{-10000, -10400, -9800, -10200, -30000, -10100}, start)
+
+	candidates, err := suite.detector.Detect(DetectOptions{Since: start})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), candidates, 1)
+	assert.Equal(suite.T(), 5, candidates[0].Occurrences)
+	assert.Less(suite.T(), candidates[0].Confidence, 0.95)
+
+	candidates, err = suite.detector.Detect(DetectOptions{Since: start, AmountTolerance: 0.01})
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), candidates)
+}
+
+func (suite *RecurringDetectorTestSuite) TestDetect_IgnoresIrregularAndSparse() {
+	// Irregular grocery runs
+	for _, day := range []int{1, 3, 11, 12, 29, 40, 41, 77} {
+		suite.createTx("Corner Grocer", -4500, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, day))
+	}
+	// Only two payments
+	suite.createMonthly("Magazine", []int64{-999, -999}, time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC))
+
+	candidates, err := suite.detector.Detect(DetectOptions{Since: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), MinConfidence: 0.5})
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), candidates)
+}
+
+func (suite *RecurringDetectorTestSuite) TestDetect_StalePaymentLowersConfidence() {
+	suite.createMonthly("Old Gym", []int64{-4000, -4000, -4000, -4000}, time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC))
+
+	candidates, err := suite.detector.Detect(DetectOptions{Since: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), candidates, 1)
+	assert.Less(suite.T(), candidates[0].Confidence, 0.5)
+	assert.False(suite.T(), candidates[0].NextDate.Before(time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)))
+}
+
+func (suite *RecurringDetectorTestSuite) TestDetect_SkipsScheduledPayees() {
+	start := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	suite.createMonthly("Rent", []int64{-150000, -150000, -150000, -150000}, start)
+
+	day := 1
+	assert.NoError(suite.T(), suite.db.Create(&models.RecurringItem{
+		AccountID: suite.account.ID, Name: "RENT", AmountCents: -150000,
+		Frequency: models.FrequencyMonthly, FrequencyInterval: 1, DayOfMonth: &day,
+		StartDate: start, NextDate: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), IsActive: true,
+	}).Error)
+
+	candidates, err := suite.detector.Detect(DetectOptions{Since: start})
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), candidates)
+}
+
+func (suite *RecurringDetectorTestSuite) TestAccept() {
+	start := time.Date(2026, 5, 15, 0, 0, 0, 0, time.UTC)
+	suite.createMonthly("Spotify", []int64{-1199, -1199, -1199, -1199, -1199, -1199}, start)
+
+	candidates, err := suite.detector.Detect(DetectOptions{Since: start})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), candidates, 1)
+
+	items, err := suite.detector.Accept(candidates, 2)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), items, 1)
+
+	item, err := repositories.NewRecurringRepository(suite.db).GetByID(items[0].ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Spotify", item.Name)
+	assert.Equal(suite.T(), int64(-1199), item.AmountCents)
+	assert.Equal(suite.T(), 2, item.ReminderDaysBefore)
+	assert.Equal(suite.T(), "2026-11-15", item.NextDate.Format("2006-01-02"))
+	assert.Equal(suite.T(), "2026-10-15", item.LastGeneratedDate.Format("2006-01-02"))
+
+	var linked int64
+	assert.NoError(suite.T(), suite.db.Model(&models.Transaction{}).Where("recurring_id = ?", item.ID).Count(&linked).Error)
+	assert.Equal(suite.T(), int64(6), linked)
+
+	// The payee is no longer proposed
+	candidates, err = suite.detector.Detect(DetectOptions{Since: start})
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), candidates)
+}
+
+func TestRecurringDetectorTestSuite(t *testing.T) {
+	suite.Run(t, new(RecurringDetectorTestSuite))
+}
@@ -0,0 +1,271 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/fintrack/fintrack/internal/recurrence"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultMatchWindowDays = 5
+	defaultMatchTolerance  = 0.10
+
+	// minPayeeSimilarity is the share of payee words an imported row must have
+	// in common with a recurring item's name to match it
+	minPayeeSimilarity = 0.5
+)
+
+// OverdueBill is an expected recurring payment with no matching imported row
+type OverdueBill struct {
+	RecurringID uint
+	Name        string
+	DueDate     time.Time
+	AmountCents int64
+	DaysOverdue int
+}
+
+// expectedOccurrence is a recurring item payment an imported row may settle:
+// either a due date not yet generated, or a generated placeholder transaction
+type expectedOccurrence struct {
+	item        *models.RecurringItem
+	date        time.Time
+	placeholder *models.Transaction
+	matched     bool
+}
+
+// recurringMatcher links imported rows to the recurring items they pay
+type recurringMatcher struct {
+	windowDays int
+	tolerance  float64
+	expected   []*expectedOccurrence
+	from, to   time.Time // Days covered by the import
+	today      time.Time
+}
+
+// newRecurringMatcher loads the occurrences of an account's active recurring items
+// that the given imported rows could settle
+func newRecurringMatcher(db *gorm.DB, accountID uint, txns []*models.Transaction, opts ImportOptions, today time.Time) (*recurringMatcher, error) {
+	m := &recurringMatcher{
+		windowDays: opts.MatchWindowDays,
+		tolerance:  opts.MatchTolerance,
+		today:      recurrence.Day(today),
+	}
+	if m.windowDays <= 0 {
+		m.windowDays = defaultMatchWindowDays
+	}
+	if m.tolerance <= 0 {
+		m.tolerance = defaultMatchTolerance
+	}
+	if len(txns) == 0 {
+		return m, nil
+	}
+
+	m.from, m.to = recurrence.Day(txns[0].Date), recurrence.Day(txns[0].Date)
+	for _, txn := range txns[1:] {
+		day := recurrence.Day(txn.Date)
+		if day.Before(m.from) {
+			m.from = day
+		}
+		if day.After(m.to) {
+			m.to = day
+		}
+	}
+
+	items, err := repositories.NewRecurringRepository(db).ListByAccount(accountID, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recurring items: %w", err)
+	}
+	if len(items) == 0 {
+		return m, nil
+	}
+
+	through := m.to.AddDate(0, 0, m.windowDays)
+	byID := make(map[uint]*models.RecurringItem, len(items))
+	ids := make([]uint, 0, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+		ids = append(ids, item.ID)
+		for _, date := range itemOccurrences(item, through) {
+			m.expected = append(m.expected, &expectedOccurrence{item: item, date: date})
+		}
+	}
+
+	generated, err := repositories.NewTransactionRepository(db).
+		ListGenerated(ids, m.from.AddDate(0, 0, -m.windowDays), through)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list generated transactions: %w", err)
+	}
+	for _, txn := range generated {
+		m.expected = append(m.expected, &expectedOccurrence{
+			item:        byID[*txn.RecurringID],
+			date:        recurrence.Day(txn.Date),
+			placeholder: txn,
+		})
+	}
+
+	return m, nil
+}
+
+// match links each imported row to the closest unmatched occurrence of a recurring
+// item with a similar payee and amount within the date window, setting its
+// RecurringID, and returns the number of rows matched
+func (m *recurringMatcher) match(txns []*models.Transaction) int {
+	if len(m.expected) == 0 {
+		return 0
+	}
+
+	sorted := append([]*models.Transaction(nil), txns...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Date.Before(sorted[j].Date) })
+
+	matched := 0
+	for _, txn := range sorted {
+		if txn.RecurringID != nil || txn.Type == models.TransactionTypeTransfer {
+			continue
+		}
+		payee := NormalizePayee(txn.Payee)
+		if payee == "" {
+			payee = NormalizePayee(txn.Description)
+		}
+		day := recurrence.Day(txn.Date)
+
+		var best *expectedOccurrence
+		bestDistance := m.windowDays + 1
+		for _, occ := range m.expected {
+			if occ.matched || !amountWithin(txn.AmountCents, occ.item.AmountCents, m.tolerance) {
+				continue
+			}
+			if payeeSimilarity(payee, NormalizePayee(occ.item.Name)) < minPayeeSimilarity {
+				continue
+			}
+			distance := int(math.Abs(day.Sub(occ.date).Hours() / 24))
+			if distance > m.windowDays {
+				continue
+			}
+			// Prefer settling a generated placeholder over a later due date
+			if distance < bestDistance || (distance == bestDistance && occ.placeholder != nil && best.placeholder == nil) {
+				best, bestDistance = occ, distance
+			}
+		}
+		if best == nil {
+			continue
+		}
+
+		best.matched = true
+		itemID := best.item.ID
+		txn.RecurringID = &itemID
+		matched++
+	}
+	return matched
+}
+
+// overdue lists the recurring bills due within the imported period, allowing for
+// the match window, that no imported row paid
+func (m *recurringMatcher) overdue() []OverdueBill {
+	bills := []OverdueBill{}
+	lastVerifiable := m.to.AddDate(0, 0, -m.windowDays)
+	for _, occ := range m.expected {
+		if occ.matched || occ.placeholder != nil || occ.item.AmountCents >= 0 {
+			continue
+		}
+		if occ.date.Before(m.from) || occ.date.After(lastVerifiable) {
+			continue
+		}
+		bills = append(bills, OverdueBill{
+			RecurringID: occ.item.ID,
+			Name:        occ.item.Name,
+			DueDate:     occ.date,
+			AmountCents: occ.item.AmountCents,
+			DaysOverdue: int(m.today.Sub(occ.date).Hours() / 24),
+		})
+	}
+	sort.SliceStable(bills, func(i, j int) bool { return bills[i].DueDate.Before(bills[j].DueDate) })
+	return bills
+}
+
+// apply replaces matched placeholders with their imported rows and advances each
+// item past its latest matched due date. Items whose next date moved since
+// matching are left alone.
+func (m *recurringMatcher) apply(tx *gorm.DB) error {
+	txRepo := repositories.NewTransactionRepository(tx)
+	recurringRepo := repositories.NewRecurringRepository(tx)
+
+	latest := make(map[uint]time.Time)
+	for _, occ := range m.expected {
+		if !occ.matched {
+			continue
+		}
+		if occ.placeholder != nil {
+			if err := txRepo.Delete(occ.placeholder.ID); err != nil {
+				return fmt.Errorf("failed to replace generated transaction for %s: %w", occ.item.Name, err)
+			}
+			continue
+		}
+		if occ.date.After(latest[occ.item.ID]) {
+			latest[occ.item.ID] = occ.date
+		}
+	}
+
+	for _, occ := range m.expected {
+		date, ok := latest[occ.item.ID]
+		if !ok {
+			continue
+		}
+		delete(latest, occ.item.ID)
+
+		item, err := recurringRepo.GetByID(occ.item.ID)
+		if err != nil {
+			return err
+		}
+		if !recurrence.Day(item.NextDate).Equal(recurrence.Day(occ.item.NextDate)) {
+			continue
+		}
+		item.LastGeneratedDate = &date
+		item.NextDate = nextAfter(item, date)
+		if err := recurringRepo.Update(item); err != nil {
+			return fmt.Errorf("failed to advance %s: %w", item.Name, err)
+		}
+	}
+	return nil
+}
+
+// amountWithin reports whether amount has the same sign as expected and is within
+// tolerance (a fraction) of it
+func amountWithin(amount, expected int64, tolerance float64) bool {
+	if expected == 0 || (amount < 0) != (expected < 0) {
+		return false
+	}
+	return math.Abs(float64(amount-expected))/math.Abs(float64(expected)) <= tolerance
+}
+
+// payeeSimilarity scores how alike two normalised payees are from 0 to 1: 1 if
+// one contains the other word for word, otherwise the share of words in common
+func payeeSimilarity(a, b string) float64 {
+	if a == "" || b == "" {
+		return 0
+	}
+	if strings.Contains(" "+a+" ", " "+b+" ") || strings.Contains(" "+b+" ", " "+a+" ") {
+		return 1
+	}
+
+	words := make(map[string]bool)
+	for _, w := range strings.Fields(a) {
+		words[w] = true
+	}
+	shared, total := 0, len(words)
+	for _, w := range strings.Fields(b) {
+		if words[w] {
+			shared++
+			delete(words, w)
+		} else {
+			total++
+		}
+	}
+	return float64(shared) / float64(total)
+}
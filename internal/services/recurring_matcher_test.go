@@ -0,0 +1,222 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestPayeeSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, payeeSimilarity("netflix", "netflix"))
+	assert.Equal(t, 1.0, payeeSimilarity("city power bill", "city power"))
+	assert.Equal(t, 0.5, payeeSimilarity("acme insurance", "acme auto insurance co"))
+	assert.Equal(t, 0.0, payeeSimilarity("netflix", "spotify"))
+	assert.Equal(t, 0.0, payeeSimilarity("", "rent"))
+	assert.Equal(t, 0.0, payeeSimilarity("net", "netflix"))
+}
+
+func TestAmountWithin(t *testing.T) {
+	assert.True(t, amountWithin(-10500, -10000, 0.10))
+	assert.True(t, amountWithin(-11000, -10000, 0.10))
+	assert.False(t, amountWithin(-11100, -10000, 0.10))
+	assert.False(t, amountWithin(10000, -10000, 0.10))
+	assert.False(t, amountWithin(-100, 0, 0.10))
+}
+
+// RecurringMatchTestSuite is the test suite for matching imported rows to recurring items
+type RecurringMatchTestSuite struct {
+	suite.Suite
+	db       *gorm.DB
+	importer *CSVImporter
+	account  *models.Account
+	dir      string
+}
+
+// SetupTest runs before each test
+func (suite *RecurringMatchTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), db.AutoMigrate(&models.Account{}, &models.Category{}, &models.Transaction{},
+		&models.RecurringItem{}, &models.ImportHistory{}))
+
+	suite.db = db
+	suite.importer = NewCSVImporter(db)
+	suite.importer.now = func() time.Time { return time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC) }
+	suite.dir = suite.T().TempDir()
+
+	suite.account = &models.Account{Name: "Checking", Type: models.AccountTypeChecking, Currency: "USD", InitialBalanceCents: 500000, IsActive: true}
+	assert.NoError(suite.T(), repositories.NewAccountRepository(db).Create(suite.account))
+}
+
+func (suite *RecurringMatchTestSuite) createItem(name string, amount int64, next time.Time) *models.RecurringItem {
+	day := next.Day()
+	item := &models.RecurringItem{
+		AccountID:         suite.account.ID,
+		Name:              name,
+		AmountCents:       amount,
+		Frequency:         models.FrequencyMonthly,
+		FrequencyInterval: 1,
+		DayOfMonth:        &day,
+		StartDate:         next.AddDate(0, -6, 0),
+		NextDate:          next,
+		IsActive:          true,
+	}
+	assert.NoError(suite.T(), suite.db.Create(item).Error)
+	return item
+}
+
+func (suite *RecurringMatchTestSuite) importCSV(content string, dryRun bool) *ImportResult {
+	path := filepath.Join(suite.dir, "statement.csv")
+	assert.NoError(suite.T(), os.WriteFile(path, []byte(content), 0o600))
+
+	result, err := suite.importer.Import(path, ImportOptions{
+		AccountID:      suite.account.ID,
+		Mapping:        DefaultColumnMapping(),
+		DryRun:         dryRun,
+		MatchRecurring: true,
+	})
+	assert.NoError(suite.T(), err)
+	return result
+}
+
+func (suite *RecurringMatchTestSuite) reload(item *models.RecurringItem) *models.RecurringItem {
+	reloaded, err := repositories.NewRecurringRepository(suite.db).GetByID(item.ID)
+	assert.NoError(suite.T(), err)
+	return reloaded
+}
+
+func (suite *RecurringMatchTestSuite) countLinked(item *models.RecurringItem) int64 {
+	var count int64
+	assert.NoError(suite.T(), suite.db.Model(&models.Transaction{}).Where("recurring_id = ?", item.ID).Count(&count).Error)
+	return count
+}
+
+func (suite *RecurringMatchTestSuite) TestImport_MatchesExpectedOccurrence() {
+	// Given a power bill due on the 3rd
+	power := suite.createItem("City Power", -10000, time.Date(2026, 10, 3, 0, 0, 0, 0, time.UTC))
+
+	// When the statement shows it paid two days late at a slightly different amount
+	result := suite.importCSV(`date,amount,description
+2026-10-01,-42.10,Corner Grocer
+2026-10-05,-104.37,CITY POWER #88123
+2026-10-12,-18.00,Lunch
+`, false)
+
+	// Then the row is linked and the item moves to next month
+	assert.Equal(suite.T(), 3, result.ImportedRecords)
+	assert.Equal(suite.T(), 1, result.MatchedRecurring)
+	assert.Empty(suite.T(), result.OverdueBills)
+	assert.Equal(suite.T(), int64(1), suite.countLinked(power))
+
+	reloaded := suite.reload(power)
+	assert.Equal(suite.T(), "2026-11-03", reloaded.NextDate.Format("2006-01-02"))
+	assert.Equal(suite.T(), "2026-10-03", reloaded.LastGeneratedDate.Format("2006-01-02"))
+}
+
+func (suite *RecurringMatchTestSuite) TestImport_ReplacesGeneratedPlaceholder() {
+	rent := suite.createItem("Rent", -150000, time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC))
+	rentID := rent.ID
+	placeholder := &models.Transaction{
+		AccountID:   suite.account.ID,
+		Date:        time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		AmountCents: -150000,
+		Payee:       "Rent",
+		Type:        models.TransactionTypeExpense,
+		RecurringID: &rentID,
+		Tags:        models.StringArray{},
+	}
+	assert.NoError(suite.T(), repositories.NewTransactionRepository(suite.db).Create(placeholder))
+
+	result := suite.importCSV(`date,amount,description
+2026-10-02,-1500.00,RENT PAYMENT OCT
+`, false)
+
+	assert.Equal(suite.T(), 1, result.MatchedRecurring)
+	assert.Equal(suite.T(), int64(1), suite.countLinked(rent))
+
+	var remaining int64
+	assert.NoError(suite.T(), suite.db.Model(&models.Transaction{}).Where("id = ?", placeholder.ID).Count(&remaining).Error)
+	assert.Equal(suite.T(), int64(0), remaining)
+
+	// The placeholder's amount is backed out of the balance, so the rent counts once
+	account, err := repositories.NewAccountRepository(suite.db).GetByID(suite.account.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(350000), account.CurrentBalanceCents)
+
+	// The item was already past October
+	assert.Equal(suite.T(), "2026-11-01", suite.reload(rent).NextDate.Format("2006-01-02"))
+}
+
+func (suite *RecurringMatchTestSuite) TestImport_ReportsOverdueBills() {
+	insurance := suite.createItem("Acme Insurance", -12000, time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC))
+	salary := suite.createItem("Payroll", 300000, time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC))
+	suite.createItem("Gym", -4000, time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC))
+
+	result := suite.importCSV(`date,amount,description
+2026-10-01,-42.10,Corner Grocer
+2026-10-06,-55.00,Hardware Store
+2026-10-15,-18.00,Lunch
+`, false)
+
+	assert.Equal(suite.T(), 0, result.MatchedRecurring)
+
+	// Income isn't a bill, and the gym is still within the match window
+	assert.Len(suite.T(), result.OverdueBills, 1)
+	bill := result.OverdueBills[0]
+	assert.Equal(suite.T(), insurance.ID, bill.RecurringID)
+	assert.Equal(suite.T(), "2026-10-02", bill.DueDate.Format("2006-01-02"))
+	assert.Equal(suite.T(), 14, bill.DaysOverdue)
+
+	assert.Equal(suite.T(), "2026-10-05", suite.reload(salary).NextDate.Format("2006-01-02"))
+}
+
+func (suite *RecurringMatchTestSuite) TestImport_IgnoresDifferentAmountOrPayee() {
+	power := suite.createItem("City Power", -10000, time.Date(2026, 10, 3, 0, 0, 0, 0, time.UTC))
+
+	result := suite.importCSV(`date,amount,description
+2026-10-03,-250.00,CITY POWER
+2026-10-04,-100.00,Water Utility
+`, false)
+
+	assert.Equal(suite.T(), 0, result.MatchedRecurring)
+	assert.Equal(suite.T(), int64(0), suite.countLinked(power))
+	assert.Equal(suite.T(), "2026-10-03", suite.reload(power).NextDate.Format("2006-01-02"))
+}
+
+func (suite *RecurringMatchTestSuite) TestImport_DryRunLeavesItems() {
+	power := suite.createItem("City Power", -10000, time.Date(2026, 10, 3, 0, 0, 0, 0, time.UTC))
+
+	result := suite.importCSV(`date,amount,description
+2026-10-03,-100.00,City Power
+`, true)
+
+	assert.Equal(suite.T(), 1, result.MatchedRecurring)
+	assert.Equal(suite.T(), int64(0), suite.countLinked(power))
+	assert.Equal(suite.T(), "2026-10-03", suite.reload(power).NextDate.Format("2006-01-02"))
+}
+
+func (suite *RecurringMatchTestSuite) TestImport_MatchesSeveralMonths() {
+	gym := suite.createItem("Gym", -4000, time.Date(2026, 8, 14, 0, 0, 0, 0, time.UTC))
+
+	result := suite.importCSV(`date,amount,description
+2026-08-14,-40.00,GYM MEMBERSHIP
+2026-09-15,-40.00,GYM MEMBERSHIP
+2026-10-14,-40.00,GYM MEMBERSHIP
+`, false)
+
+	assert.Equal(suite.T(), 3, result.MatchedRecurring)
+	assert.Equal(suite.T(), int64(3), suite.countLinked(gym))
+	assert.Equal(suite.T(), "2026-11-14", suite.reload(gym).NextDate.Format("2006-01-02"))
+}
+
+func TestRecurringMatchTestSuite(t *testing.T) {
+	suite.Run(t, new(RecurringMatchTestSuite))
+}
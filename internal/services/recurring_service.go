@@ -0,0 +1,83 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/fintrack/fintrack/internal/recurrence"
+)
+
+// IsValidFrequency reports whether frequency is a supported recurring item frequency
+func IsValidFrequency(frequency string) bool {
+	switch frequency {
+	case models.FrequencyDaily, models.FrequencyWeekly, models.FrequencyBiweekly,
+		models.FrequencyMonthly, models.FrequencyQuarterly, models.FrequencyAnnual:
+		return true
+	}
+	return false
+}
+
+// ValidateRecurringItem checks a recurring item against the constraints of the
+// recurring_items table, so invalid schedules are rejected before reaching the database
+func ValidateRecurringItem(item *models.RecurringItem) error {
+	if strings.TrimSpace(item.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if item.AccountID == 0 {
+		return fmt.Errorf("account is required")
+	}
+	if item.AmountCents == 0 {
+		return fmt.Errorf("amount cannot be zero")
+	}
+	if !IsValidFrequency(item.Frequency) {
+		return fmt.Errorf("invalid frequency: %s (valid: daily, weekly, biweekly, monthly, quarterly, annual)", item.Frequency)
+	}
+	if item.FrequencyInterval <= 0 {
+		return fmt.Errorf("interval must be greater than zero")
+	}
+
+	if item.DayOfMonth != nil {
+		if *item.DayOfMonth < 1 || *item.DayOfMonth > 31 {
+			return fmt.Errorf("day of month must be between 1 and 31")
+		}
+		switch item.Frequency {
+		case models.FrequencyMonthly, models.FrequencyQuarterly, models.FrequencyAnnual:
+		default:
+			return fmt.Errorf("day of month only applies to monthly, quarterly and annual schedules")
+		}
+	}
+	if item.DayOfWeek != nil {
+		if *item.DayOfWeek < 0 || *item.DayOfWeek > 6 {
+			return fmt.Errorf("day of week must be between 0 (Sunday) and 6 (Saturday)")
+		}
+		switch item.Frequency {
+		case models.FrequencyWeekly, models.FrequencyBiweekly:
+		default:
+			return fmt.Errorf("day of week only applies to weekly and biweekly schedules")
+		}
+	}
+
+	if item.ReminderDaysBefore < 0 {
+		return fmt.Errorf("reminder days cannot be negative")
+	}
+	if item.StartDate.IsZero() {
+		return fmt.Errorf("start date is required")
+	}
+	if item.EndDate != nil && item.EndDate.Before(item.StartDate) {
+		return fmt.Errorf("end date cannot be before start date")
+	}
+	return nil
+}
+
+// NextRecurringDate returns when a recurring item is next due: its first occurrence
+// after the last generated one, or its first occurrence if none was generated yet.
+// It returns false if the schedule has no further occurrences.
+func NextRecurringDate(item *models.RecurringItem) (time.Time, bool) {
+	rule := recurrence.FromItem(item)
+	if item.LastGeneratedDate != nil {
+		return rule.Next(*item.LastGeneratedDate)
+	}
+	return rule.First()
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func validRecurringItem() *models.RecurringItem {
+	return &models.RecurringItem{
+		Name:              "Rent",
+		AccountID:         1,
+		AmountCents:       -150000,
+		Frequency:         models.FrequencyMonthly,
+		FrequencyInterval: 1,
+		StartDate:         time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestValidateRecurringItem(t *testing.T) {
+	intPtr := func(n int) *int { return &n }
+	endBeforeStart := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		mutate  func(item *models.RecurringItem)
+		wantErr string
+	}{
+		{"valid", func(item *models.RecurringItem) {}, ""},
+		{"valid day of month", func(item *models.RecurringItem) { item.DayOfMonth = intPtr(31) }, ""},
+		{"valid weekday", func(item *models.RecurringItem) {
+			item.Frequency = models.FrequencyWeekly
+			item.DayOfWeek = intPtr(0)
+		}, ""},
+		{"missing name", func(item *models.RecurringItem) { item.Name = " " }, "name is required"},
+		{"missing account", func(item *models.RecurringItem) { item.AccountID = 0 }, "account is required"},
+		{"zero amount", func(item *models.RecurringItem) { item.AmountCents = 0 }, "amount cannot be zero"},
+		{"bad frequency", func(item *models.RecurringItem) { item.Frequency = "fortnightly" }, "invalid frequency"},
+		{"zero interval", func(item *models.RecurringItem) { item.FrequencyInterval = 0 }, "interval must be greater than zero"},
+		{"day of month too low", func(item *models.RecurringItem) { item.DayOfMonth = intPtr(0) }, "between 1 and 31"},
+		{"day of month too high", func(item *models.RecurringItem) { item.DayOfMonth = intPtr(32) }, "between 1 and 31"},
+		{"day of month on weekly", func(item *models.RecurringItem) {
+			item.Frequency = models.FrequencyWeekly
+			item.DayOfMonth = intPtr(5)
+		}, "only applies to monthly"},
+		{"day of week too high", func(item *models.RecurringItem) {
+			item.Frequency = models.FrequencyWeekly
+			item.DayOfWeek = intPtr(7)
+		}, "between 0 (Sunday) and 6"},
+		{"day of week on monthly", func(item *models.RecurringItem) { item.DayOfWeek = intPtr(1) }, "only applies to weekly"},
+		{"negative reminder", func(item *models.RecurringItem) { item.ReminderDaysBefore = -1 }, "cannot be negative"},
+		{"end before start", func(item *models.RecurringItem) { item.EndDate = &endBeforeStart }, "end date cannot be before start date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := validRecurringItem()
+			tt.mutate(item)
+
+			err := ValidateRecurringItem(item)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestIsValidFrequency(t *testing.T) {
+	for _, f := range []string{"daily", "weekly", "biweekly", "monthly", "quarterly", "annual"} {
+		assert.True(t, IsValidFrequency(f), f)
+	}
+	assert.False(t, IsValidFrequency("yearly"))
+	assert.False(t, IsValidFrequency(""))
+}
+
+func TestNextRecurringDate(t *testing.T) {
+	day := 31
+	item := validRecurringItem()
+	item.StartDate = time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	item.DayOfMonth = &day
+
+	next, ok := NextRecurringDate(item)
+	assert.True(t, ok)
+	assert.Equal(t, "2026-01-31", next.Format("2006-01-02"))
+
+	generated := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	item.LastGeneratedDate = &generated
+	next, ok = NextRecurringDate(item)
+	assert.True(t, ok)
+	assert.Equal(t, "2026-02-28", next.Format("2006-01-02"))
+
+	end := time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC)
+	item.EndDate = &end
+	_, ok = NextRecurringDate(item)
+	assert.False(t, ok)
+}
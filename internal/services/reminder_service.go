@@ -0,0 +1,97 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"gorm.io/gorm"
+)
+
+// reminderPriorityRank orders reminder priorities from least to most pressing
+var reminderPriorityRank = map[string]int{
+	models.ReminderPriorityLow:    0,
+	models.ReminderPriorityNormal: 1,
+	models.ReminderPriorityHigh:   2,
+	models.ReminderPriorityUrgent: 3,
+}
+
+// IsValidReminderPriority reports whether priority is a supported reminder priority
+func IsValidReminderPriority(priority string) bool {
+	_, ok := reminderPriorityRank[priority]
+	return ok
+}
+
+// PriorityAtLeast reports whether priority is at or above min. Unknown priorities
+// are treated as normal.
+func PriorityAtLeast(priority, min string) bool {
+	return priorityRank(priority) >= priorityRank(min)
+}
+
+func priorityRank(priority string) int {
+	if rank, ok := reminderPriorityRank[priority]; ok {
+		return rank
+	}
+	return reminderPriorityRank[models.ReminderPriorityNormal]
+}
+
+// ReminderService answers questions about pending reminders
+type ReminderService struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+// NewReminderService creates a new reminder service
+func NewReminderService(db *gorm.DB) *ReminderService {
+	return &ReminderService{db: db, now: time.Now}
+}
+
+// Due returns undismissed reminders due today or earlier at or above minPriority,
+// most pressing first and then oldest first
+func (s *ReminderService) Due(minPriority string) ([]*models.Reminder, error) {
+	today := startOfDayUTC(s.now())
+	reminders, err := repositories.NewReminderRepository(s.db).List(repositories.ReminderFilter{Through: &today})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reminders: %w", err)
+	}
+
+	due := []*models.Reminder{}
+	for _, r := range reminders {
+		if PriorityAtLeast(r.Priority, minPriority) {
+			due = append(due, r)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		return priorityRank(due[i].Priority) > priorityRank(due[j].Priority)
+	})
+	return due, nil
+}
+
+// Overdue returns undismissed reminders that were due before today
+func (s *ReminderService) Overdue() ([]*models.Reminder, error) {
+	today := startOfDayUTC(s.now())
+	reminders, err := repositories.NewReminderRepository(s.db).List(repositories.ReminderFilter{Before: &today})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reminders: %w", err)
+	}
+	return reminders, nil
+}
+
+// ReminderStatus describes where a reminder stands relative to today:
+// dismissed, overdue, due or upcoming
+func ReminderStatus(r *models.Reminder, now time.Time) string {
+	today := startOfDayUTC(now)
+	day := startOfDayUTC(r.RemindDate)
+	switch {
+	case r.IsDismissed:
+		return "dismissed"
+	case day.Before(today):
+		return "overdue"
+	case day.Equal(today):
+		return "due"
+	default:
+		return "upcoming"
+	}
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestPriorityAtLeast(t *testing.T) {
+	assert.True(t, PriorityAtLeast(models.ReminderPriorityUrgent, models.ReminderPriorityHigh))
+	assert.True(t, PriorityAtLeast(models.ReminderPriorityNormal, models.ReminderPriorityNormal))
+	assert.False(t, PriorityAtLeast(models.ReminderPriorityLow, models.ReminderPriorityNormal))
+	assert.True(t, PriorityAtLeast("", models.ReminderPriorityNormal))
+	assert.False(t, PriorityAtLeast("", models.ReminderPriorityHigh))
+}
+
+func TestIsValidReminderPriority(t *testing.T) {
+	for _, p := range []string{"low", "normal", "high", "urgent"} {
+		assert.True(t, IsValidReminderPriority(p), p)
+	}
+	assert.False(t, IsValidReminderPriority("critical"))
+	assert.False(t, IsValidReminderPriority(""))
+}
+
+func TestReminderStatus(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "overdue", ReminderStatus(&models.Reminder{RemindDate: now.AddDate(0, 0, -1)}, now))
+	assert.Equal(t, "due", ReminderStatus(&models.Reminder{RemindDate: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)}, now))
+	assert.Equal(t, "upcoming", ReminderStatus(&models.Reminder{RemindDate: now.AddDate(0, 0, 1)}, now))
+	assert.Equal(t, "dismissed", ReminderStatus(&models.Reminder{RemindDate: now, IsDismissed: true}, now))
+}
+
+func TestReminderService_DueAndOverdue(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&models.Reminder{}))
+
+	today := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	reminders := []*models.Reminder{
+		{Type: models.ReminderTypeCustom, Title: "Old low", RemindDate: today.AddDate(0, 0, -3), Priority: models.ReminderPriorityLow},
+		{Type: models.ReminderTypeBill, Title: "Rent", RemindDate: today.AddDate(0, 0, -1), Priority: models.ReminderPriorityNormal},
+		{Type: models.ReminderTypeBudget, Title: "Dining exceeded", RemindDate: today, Priority: models.ReminderPriorityUrgent},
+		{Type: models.ReminderTypeCustom, Title: "Next week", RemindDate: today.AddDate(0, 0, 7), Priority: models.ReminderPriorityUrgent},
+		{Type: models.ReminderTypeCustom, Title: "Dismissed", RemindDate: today, Priority: models.ReminderPriorityHigh, IsDismissed: true},
+	}
+	for _, r := range reminders {
+		assert.NoError(t, db.Create(r).Error)
+	}
+
+	service := NewReminderService(db)
+	service.now = func() time.Time { return today.Add(10 * time.Hour) }
+
+	due, err := service.Due(models.ReminderPriorityNormal)
+	assert.NoError(t, err)
+	assert.Len(t, due, 2)
+	assert.Equal(t, "Dining exceeded", due[0].Title)
+	assert.Equal(t, "Rent", due[1].Title)
+
+	due, err = service.Due(models.ReminderPriorityLow)
+	assert.NoError(t, err)
+	assert.Len(t, due, 3)
+
+	overdue, err := service.Overdue()
+	assert.NoError(t, err)
+	assert.Len(t, overdue, 2)
+	assert.Equal(t, "Old low", overdue[0].Title)
+}
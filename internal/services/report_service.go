@@ -0,0 +1,372 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"gorm.io/gorm"
+)
+
+// Report periods (reports.default_period)
+const (
+	ReportPeriodWeek    = "week"
+	ReportPeriodMonth   = "month"
+	ReportPeriodQuarter = "quarter"
+	ReportPeriodYear    = "year"
+)
+
+// Category sort orders for reports (reports.category_sort)
+const (
+	ReportSortName   = "name"
+	ReportSortAmount = "amount"
+	ReportSortCount  = "count"
+)
+
+// uncategorizedName labels transactions without a category in reports
+const uncategorizedName = "Uncategorized"
+
+// ReportOptions controls which transactions a category report covers and how
+// its lines are grouped and ordered
+type ReportOptions struct {
+	From      time.Time
+	To        time.Time // Inclusive
+	AccountID *uint     // nil covers all accounts
+	Rollup    bool      // Fold subcategories into their top-level category
+	Sort      string    // name, amount, count
+}
+
+// CategoryLine is one category's total in a report
+type CategoryLine struct {
+	CategoryID  *uint   `json:"category_id,omitempty"` // nil for uncategorized transactions
+	Name        string  `json:"name"`                  // "Parent > Child" for subcategories unless rolled up
+	AmountCents int64   `json:"amount_cents"`          // Positive for money in the section's direction
+	Count       int     `json:"count"`
+	Share       float64 `json:"share"` // Fraction of the section total
+}
+
+// ReportSection groups the category lines of one category type
+type ReportSection struct {
+	Type       string          `json:"type"` // income, expense
+	Lines      []*CategoryLine `json:"lines"`
+	TotalCents int64           `json:"total_cents"`
+	Count      int             `json:"count"`
+}
+
+// IncomeStatement compares income and expenses over a date range
+type IncomeStatement struct {
+	From        time.Time               `json:"from"`
+	To          time.Time               `json:"to"`
+	AccountID   *uint                   `json:"account_id,omitempty"`
+	Income      *ReportSection          `json:"income"`
+	Expenses    *ReportSection          `json:"expenses"`
+	NetCents    int64                   `json:"net_cents"`
+	SavingsRate float64                 `json:"savings_rate"` // Net as a fraction of income; zero without income
+	Months      []*IncomeStatementMonth `json:"months"`
+}
+
+// IncomeStatementMonth totals the part of an income statement in one calendar month
+type IncomeStatementMonth struct {
+	Month         time.Time `json:"month"` // First of the month
+	IncomeCents   int64     `json:"income_cents"`
+	ExpensesCents int64     `json:"expenses_cents"`
+	NetCents      int64     `json:"net_cents"`
+}
+
+// SpendingReport breaks down the expenses of one period by category
+type SpendingReport struct {
+	Period            string         `json:"period"`
+	From              time.Time      `json:"from"`
+	To                time.Time      `json:"to"`
+	AccountID         *uint          `json:"account_id,omitempty"`
+	Expenses          *ReportSection `json:"expenses"`
+	DailyAverageCents int64          `json:"daily_average_cents"`
+}
+
+// ReportService aggregates transactions and balances into reports
+type ReportService struct {
+	accountRepo  *repositories.AccountRepository
+	categoryRepo *repositories.CategoryRepository
+	txRepo       *repositories.TransactionRepository
+}
+
+// NewReportService creates a new report service
+func NewReportService(db *gorm.DB) *ReportService {
+	return &ReportService{
+		accountRepo:  repositories.NewAccountRepository(db),
+		categoryRepo: repositories.NewCategoryRepository(db),
+		txRepo:       repositories.NewTransactionRepository(db),
+	}
+}
+
+// IsValidReportPeriod reports whether period is a supported report period
+func IsValidReportPeriod(period string) bool {
+	switch period {
+	case ReportPeriodWeek, ReportPeriodMonth, ReportPeriodQuarter, ReportPeriodYear:
+		return true
+	}
+	return false
+}
+
+// IsValidReportSort reports whether order is a supported category sort order
+func IsValidReportSort(order string) bool {
+	switch order {
+	case ReportSortName, ReportSortAmount, ReportSortCount:
+		return true
+	}
+	return false
+}
+
+// ReportPeriodRange returns the first and last day of the report period
+// containing date. Weeks start on Monday, like budget periods.
+func ReportPeriodRange(period string, date time.Time) (time.Time, time.Time, error) {
+	var periodType string
+	switch period {
+	case ReportPeriodWeek:
+		periodType = models.BudgetPeriodWeekly
+	case ReportPeriodMonth:
+		periodType = models.BudgetPeriodMonthly
+	case ReportPeriodQuarter:
+		periodType = models.BudgetPeriodQuarterly
+	case ReportPeriodYear:
+		periodType = models.BudgetPeriodAnnual
+	default:
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid period: %s (valid: week, month, quarter, year)", period)
+	}
+	start := BudgetPeriodStart(periodType, date)
+	return start, BudgetPeriodEnd(periodType, start), nil
+}
+
+// IncomeStatement totals income and expenses by category between opts.From and
+// opts.To. A transaction is placed by its category's type, or by its own type
+// when it has no category, so a refund booked as income against an expense
+// category reduces that category's spending. Transfers are left out.
+func (s *ReportService) IncomeStatement(opts ReportOptions) (*IncomeStatement, error) {
+	if err := validateReportOptions(&opts); err != nil {
+		return nil, err
+	}
+	byID, err := s.categoriesByID()
+	if err != nil {
+		return nil, err
+	}
+	sections, err := s.sections(opts, byID)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &IncomeStatement{
+		From:      startOfDayUTC(opts.From),
+		To:        startOfDayUTC(opts.To),
+		AccountID: opts.AccountID,
+		Income:    sections[models.CategoryTypeIncome],
+		Expenses:  sections[models.CategoryTypeExpense],
+	}
+	statement.NetCents = statement.Income.TotalCents - statement.Expenses.TotalCents
+	if statement.Income.TotalCents > 0 {
+		statement.SavingsRate = float64(statement.NetCents) / float64(statement.Income.TotalCents)
+	}
+
+	for month := MonthStart(statement.From); !month.After(statement.To); month = month.AddDate(0, 1, 0) {
+		monthOpts := opts
+		monthOpts.From, monthOpts.To = month, month.AddDate(0, 1, -1)
+		if monthOpts.From.Before(statement.From) {
+			monthOpts.From = statement.From
+		}
+		if monthOpts.To.After(statement.To) {
+			monthOpts.To = statement.To
+		}
+		monthSections, err := s.sections(monthOpts, byID)
+		if err != nil {
+			return nil, err
+		}
+		income, expenses := monthSections[models.CategoryTypeIncome].TotalCents, monthSections[models.CategoryTypeExpense].TotalCents
+		statement.Months = append(statement.Months, &IncomeStatementMonth{
+			Month:         month,
+			IncomeCents:   income,
+			ExpensesCents: expenses,
+			NetCents:      income - expenses,
+		})
+	}
+	return statement, nil
+}
+
+// Spending totals expenses by category over the given period containing date
+func (s *ReportService) Spending(period string, date time.Time, opts ReportOptions) (*SpendingReport, error) {
+	from, to, err := ReportPeriodRange(period, date)
+	if err != nil {
+		return nil, err
+	}
+	opts.From, opts.To = from, to
+
+	if err := validateReportOptions(&opts); err != nil {
+		return nil, err
+	}
+	byID, err := s.categoriesByID()
+	if err != nil {
+		return nil, err
+	}
+	sections, err := s.sections(opts, byID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SpendingReport{
+		Period:    period,
+		From:      from,
+		To:        to,
+		AccountID: opts.AccountID,
+		Expenses:  sections[models.CategoryTypeExpense],
+	}
+	report.DailyAverageCents = report.Expenses.TotalCents / int64(daysBetween(from, to)+1)
+	return report, nil
+}
+
+// validateReportOptions checks the sort order and date range, defaulting to
+// sorting by amount
+func validateReportOptions(opts *ReportOptions) error {
+	if opts.Sort == "" {
+		opts.Sort = ReportSortAmount
+	}
+	if !IsValidReportSort(opts.Sort) {
+		return fmt.Errorf("invalid sort: %s (valid: name, amount, count)", opts.Sort)
+	}
+	if startOfDayUTC(opts.To).Before(startOfDayUTC(opts.From)) {
+		return fmt.Errorf("end date must not be before start date")
+	}
+	return nil
+}
+
+// categoriesByID loads every category keyed by ID
+func (s *ReportService) categoriesByID() (map[uint]*models.Category, error) {
+	categories, err := s.categoryRepo.List("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+	byID := make(map[uint]*models.Category, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+	return byID, nil
+}
+
+// sections builds the income and expense sections for validated opts
+func (s *ReportService) sections(opts ReportOptions, byID map[uint]*models.Category) (map[string]*ReportSection, error) {
+	totals, err := s.txRepo.GetTotalsByCategory(opts.AccountID, opts.From, opts.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to total transactions: %w", err)
+	}
+
+	sections := map[string]*ReportSection{
+		models.CategoryTypeIncome:  {Type: models.CategoryTypeIncome},
+		models.CategoryTypeExpense: {Type: models.CategoryTypeExpense},
+	}
+	lines := map[string]map[uint]*CategoryLine{
+		models.CategoryTypeIncome:  {},
+		models.CategoryTypeExpense: {},
+	}
+
+	for _, total := range totals {
+		var category *models.Category
+		if total.CategoryID != nil {
+			category = byID[*total.CategoryID]
+		}
+
+		sectionType := total.Type
+		if category != nil {
+			sectionType = category.Type
+		}
+		section, ok := sections[sectionType]
+		if !ok {
+			continue
+		}
+
+		var key uint // Zero collects uncategorized transactions
+		if category != nil {
+			if opts.Rollup {
+				category = rootCategory(category, byID)
+			}
+			key = category.ID
+		}
+		line := lines[sectionType][key]
+		if line == nil {
+			line = &CategoryLine{Name: uncategorizedName}
+			if category != nil {
+				id := category.ID
+				line.CategoryID = &id
+				line.Name = categoryPath(category, byID)
+			}
+			lines[sectionType][key] = line
+			section.Lines = append(section.Lines, line)
+		}
+
+		amount := total.TotalCents
+		if sectionType == models.CategoryTypeExpense {
+			amount = -amount
+		}
+		line.AmountCents += amount
+		line.Count += int(total.Count)
+		section.TotalCents += amount
+		section.Count += int(total.Count)
+	}
+
+	for _, section := range sections {
+		for _, line := range section.Lines {
+			if section.TotalCents != 0 {
+				line.Share = float64(line.AmountCents) / float64(section.TotalCents)
+			}
+		}
+		sortCategoryLines(section.Lines, opts.Sort)
+		if section.Lines == nil {
+			section.Lines = []*CategoryLine{}
+		}
+	}
+	return sections, nil
+}
+
+// rootCategory returns the top-level ancestor of category
+func rootCategory(category *models.Category, byID map[uint]*models.Category) *models.Category {
+	for depth := 0; category.ParentID != nil && depth < len(byID); depth++ {
+		parent, ok := byID[*category.ParentID]
+		if !ok {
+			break
+		}
+		category = parent
+	}
+	return category
+}
+
+// categoryPath names category with its ancestors, e.g. "Food > Groceries"
+func categoryPath(category *models.Category, byID map[uint]*models.Category) string {
+	names := []string{category.Name}
+	for depth := 0; category.ParentID != nil && depth < len(byID); depth++ {
+		parent, ok := byID[*category.ParentID]
+		if !ok {
+			break
+		}
+		names = append([]string{parent.Name}, names...)
+		category = parent
+	}
+	return strings.Join(names, " > ")
+}
+
+// sortCategoryLines orders lines by order, largest first for amount and count,
+// breaking ties by name
+func sortCategoryLines(lines []*CategoryLine, order string) {
+	sort.SliceStable(lines, func(i, j int) bool {
+		a, b := lines[i], lines[j]
+		switch order {
+		case ReportSortAmount:
+			if a.AmountCents != b.AmountCents {
+				return a.AmountCents > b.AmountCents
+			}
+		case ReportSortCount:
+			if a.Count != b.Count {
+				return a.Count > b.Count
+			}
+		}
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	})
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestReportPeriodRange(t *testing.T) {
+	date := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC) // A Friday
+
+	from, to, err := ReportPeriodRange(ReportPeriodWeek, date)
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-10-12", from.Format("2006-01-02"))
+	assert.Equal(t, "2026-10-18", to.Format("2006-01-02"))
+
+	from, to, err = ReportPeriodRange(ReportPeriodQuarter, date)
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-10-01", from.Format("2006-01-02"))
+	assert.Equal(t, "2026-12-31", to.Format("2006-01-02"))
+
+	_, _, err = ReportPeriodRange("fortnight", date)
+	assert.EqualError(t, err, "invalid period: fortnight (valid: week, month, quarter, year)")
+}
+
+// ReportTestSuite is the test suite for category reports
+type ReportTestSuite struct {
+	suite.Suite
+	db        *gorm.DB
+	svc       *ReportService
+	account   *models.Account
+	food      *models.Category
+	groceries *models.Category
+}
+
+// SetupTest runs before each test
+func (suite *ReportTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), db.AutoMigrate(&models.Account{}, &models.Category{}, &models.Transaction{}))
+
+	suite.db = db
+	suite.svc = NewReportService(db)
+
+	suite.account = &models.Account{Name: "Checking", Type: models.AccountTypeChecking, Currency: "USD", IsActive: true}
+	assert.NoError(suite.T(), repositories.NewAccountRepository(db).Create(suite.account))
+
+	salary := suite.createCategory("Salary", models.CategoryTypeIncome, nil)
+	suite.food = suite.createCategory("Food", models.CategoryTypeExpense, nil)
+	suite.groceries = suite.createCategory("Groceries", models.CategoryTypeExpense, &suite.food.ID)
+	dining := suite.createCategory("Dining", models.CategoryTypeExpense, &suite.food.ID)
+	rent := suite.createCategory("Rent", models.CategoryTypeExpense, nil)
+
+	suite.record("2026-10-01", 300000, models.TransactionTypeIncome, &salary.ID)
+	suite.record("2026-10-03", -10000, models.TransactionTypeExpense, &suite.groceries.ID)
+	suite.record("2026-10-10", -5000, models.TransactionTypeExpense, &suite.groceries.ID)
+	suite.record("2026-10-11", 2000, models.TransactionTypeIncome, &suite.groceries.ID) // Refund
+	suite.record("2026-10-05", -4000, models.TransactionTypeExpense, &dining.ID)
+	suite.record("2026-10-06", -1000, models.TransactionTypeExpense, &suite.food.ID)
+	suite.record("2026-10-01", -150000, models.TransactionTypeExpense, &rent.ID)
+	suite.record("2026-10-31", -500, models.TransactionTypeExpense, nil)
+	suite.record("2026-10-12", 700, models.TransactionTypeIncome, nil)
+
+	// Neither of these belong in an October report
+	suite.record("2026-10-15", -20000, models.TransactionTypeTransfer, nil)
+	suite.record("2026-09-30", -9999, models.TransactionTypeExpense, &suite.groceries.ID)
+}
+
+func (suite *ReportTestSuite) createCategory(name, categoryType string, parentID *uint) *models.Category {
+	category := &models.Category{Name: name, Type: categoryType, ParentID: parentID}
+	assert.NoError(suite.T(), repositories.NewCategoryRepository(suite.db).Create(category))
+	return category
+}
+
+func (suite *ReportTestSuite) record(date string, cents int64, txType string, categoryID *uint) {
+	day, err := time.Parse("2006-01-02", date)
+	assert.NoError(suite.T(), err)
+	tx := &models.Transaction{
+		AccountID:   suite.account.ID,
+		Date:        day,
+		AmountCents: cents,
+		Type:        txType,
+		CategoryID:  categoryID,
+		Tags:        models.StringArray{},
+	}
+	assert.NoError(suite.T(), suite.db.Create(tx).Error)
+}
+
+func (suite *ReportTestSuite) october(rollup bool, sort string) ReportOptions {
+	return ReportOptions{
+		From:   time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC),
+		Rollup: rollup,
+		Sort:   sort,
+	}
+}
+
+func lineNames(lines []*CategoryLine) []string {
+	names := make([]string, 0, len(lines))
+	for _, line := range lines {
+		names = append(names, line.Name)
+	}
+	return names
+}
+
+func (suite *ReportTestSuite) TestIncomeStatement() {
+	statement, err := suite.svc.IncomeStatement(suite.october(false, ReportSortAmount))
+	assert.NoError(suite.T(), err)
+
+	// Income excludes the grocery refund, which reduces grocery spending instead
+	assert.Equal(suite.T(), []string{"Salary", "Uncategorized"}, lineNames(statement.Income.Lines))
+	assert.Equal(suite.T(), int64(300700), statement.Income.TotalCents)
+
+	assert.Equal(suite.T(), []string{"Rent", "Food > Groceries", "Food > Dining", "Food", "Uncategorized"},
+		lineNames(statement.Expenses.Lines))
+	groceries := statement.Expenses.Lines[1]
+	assert.Equal(suite.T(), suite.groceries.ID, *groceries.CategoryID)
+	assert.Equal(suite.T(), int64(13000), groceries.AmountCents)
+	assert.Equal(suite.T(), 3, groceries.Count)
+	assert.InDelta(suite.T(), 13000.0/168500.0, groceries.Share, 0.0001)
+	assert.Nil(suite.T(), statement.Expenses.Lines[4].CategoryID)
+
+	assert.Equal(suite.T(), int64(168500), statement.Expenses.TotalCents)
+	assert.Equal(suite.T(), 7, statement.Expenses.Count)
+	assert.Equal(suite.T(), int64(132200), statement.NetCents)
+	assert.InDelta(suite.T(), 132200.0/300700.0, statement.SavingsRate, 0.0001)
+
+	if assert.Len(suite.T(), statement.Months, 1) {
+		assert.Equal(suite.T(), "2026-10-01", statement.Months[0].Month.Format("2006-01-02"))
+		assert.Equal(suite.T(), int64(132200), statement.Months[0].NetCents)
+	}
+}
+
+func (suite *ReportTestSuite) TestIncomeStatementMonths() {
+	opts := suite.october(false, ReportSortAmount)
+	opts.From = time.Date(2026, 9, 15, 0, 0, 0, 0, time.UTC)
+	opts.To = time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+
+	statement, err := suite.svc.IncomeStatement(opts)
+	assert.NoError(suite.T(), err)
+	if assert.Len(suite.T(), statement.Months, 2) {
+		september, october := statement.Months[0], statement.Months[1]
+		assert.Equal(suite.T(), "2026-09-01", september.Month.Format("2006-01-02"))
+		assert.Equal(suite.T(), int64(9999), september.ExpensesCents)
+
+		// Only up to the 5th: salary, rent, groceries and dining
+		assert.Equal(suite.T(), int64(300000), october.IncomeCents)
+		assert.Equal(suite.T(), int64(164000), october.ExpensesCents)
+		assert.Equal(suite.T(), int64(136000), october.NetCents)
+	}
+	assert.Equal(suite.T(), int64(300000-173999), statement.NetCents)
+}
+
+func (suite *ReportTestSuite) TestRollupFoldsSubcategories() {
+	statement, err := suite.svc.IncomeStatement(suite.october(true, ReportSortAmount))
+	assert.NoError(suite.T(), err)
+
+	assert.Equal(suite.T(), []string{"Rent", "Food", "Uncategorized"}, lineNames(statement.Expenses.Lines))
+	food := statement.Expenses.Lines[1]
+	assert.Equal(suite.T(), suite.food.ID, *food.CategoryID)
+	assert.Equal(suite.T(), int64(18000), food.AmountCents)
+	assert.Equal(suite.T(), 5, food.Count)
+	assert.Equal(suite.T(), int64(168500), statement.Expenses.TotalCents)
+}
+
+func (suite *ReportTestSuite) TestSortOrders() {
+	statement, err := suite.svc.IncomeStatement(suite.october(false, ReportSortCount))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"Food > Groceries", "Food", "Food > Dining", "Rent", "Uncategorized"},
+		lineNames(statement.Expenses.Lines))
+
+	statement, err = suite.svc.IncomeStatement(suite.october(false, ReportSortName))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"Food", "Food > Dining", "Food > Groceries", "Rent", "Uncategorized"},
+		lineNames(statement.Expenses.Lines))
+
+	_, err = suite.svc.IncomeStatement(suite.october(false, "size"))
+	assert.EqualError(suite.T(), err, "invalid sort: size (valid: name, amount, count)")
+}
+
+func (suite *ReportTestSuite) TestSpendingForMonth() {
+	report, err := suite.svc.Spending(ReportPeriodMonth, time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), ReportOptions{Rollup: true})
+	assert.NoError(suite.T(), err)
+
+	assert.Equal(suite.T(), "2026-10-01", report.From.Format("2006-01-02"))
+	assert.Equal(suite.T(), "2026-10-31", report.To.Format("2006-01-02"))
+	assert.Equal(suite.T(), int64(168500), report.Expenses.TotalCents)
+	assert.Equal(suite.T(), int64(168500/31), report.DailyAverageCents)
+
+	report, err = suite.svc.Spending(ReportPeriodMonth, time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), ReportOptions{})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"Food > Groceries"}, lineNames(report.Expenses.Lines))
+	assert.Equal(suite.T(), 1.0, report.Expenses.Lines[0].Share)
+}
+
+func (suite *ReportTestSuite) TestEmptyRange() {
+	opts := suite.october(false, ReportSortAmount)
+	opts.From = time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	opts.To = opts.From
+
+	statement, err := suite.svc.IncomeStatement(opts)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), statement.Income.Lines)
+	assert.NotNil(suite.T(), statement.Expenses.Lines)
+	assert.Zero(suite.T(), statement.SavingsRate)
+
+	opts.To = opts.From.AddDate(0, 0, -1)
+	_, err = suite.svc.IncomeStatement(opts)
+	assert.EqualError(suite.T(), err, "end date must not be before start date")
+}
+
+func TestReportTestSuite(t *testing.T) {
+	suite.Run(t, new(ReportTestSuite))
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/fintrack/fintrack/internal/recurrence"
+	"gorm.io/gorm"
+)
+
+// ScheduledOccurrence is one due date of a recurring item
+type ScheduledOccurrence struct {
+	Item         *models.RecurringItem `json:"item"`
+	Date         time.Time             `json:"date"`
+	NeedsConfirm bool                  `json:"needs_confirm"`         // Item is not auto-generated
+	Transaction  *models.Transaction   `json:"transaction,omitempty"` // Set when generated (or planned in a dry run)
+}
+
+// GenerateOptions controls a schedule generation run
+type GenerateOptions struct {
+	DaysAhead    int  // Generate occurrences due up to this many days from today
+	AutoGenerate bool // Treat every item as auto-generated (recurring.auto_generate)
+	DryRun       bool // Plan without saving anything
+
+	// Confirm is asked, in date order, about each occurrence that needs
+	// confirmation. Declining leaves that occurrence and the item's later ones
+	// pending. A nil Confirm leaves all of them pending.
+	Confirm func(occ *ScheduledOccurrence) bool
+}
+
+// GenerateResult reports the outcome of a schedule generation run
+type GenerateResult struct {
+	Through   time.Time              `json:"through"`
+	Generated []*ScheduledOccurrence `json:"generated"`
+	Pending   []*ScheduledOccurrence `json:"pending"` // Due but not confirmed
+}
+
+// ScheduleGenerator creates transactions from due recurring items
+type ScheduleGenerator struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+// NewScheduleGenerator creates a new schedule generator
+func NewScheduleGenerator(db *gorm.DB) *ScheduleGenerator {
+	return &ScheduleGenerator{db: db, now: time.Now}
+}
+
+// Due lists the occurrences of active recurring items from each item's next date
+// through the given day, grouped by item in date order
+func (g *ScheduleGenerator) Due(through time.Time, autoGenerate bool) ([]*ScheduledOccurrence, error) {
+	items, err := repositories.NewRecurringRepository(g.db).ListDue(through)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due recurring items: %w", err)
+	}
+
+	through = recurrence.Day(through)
+	var due []*ScheduledOccurrence
+	for _, item := range items {
+		for _, date := range itemOccurrences(item, through) {
+			due = append(due, &ScheduledOccurrence{
+				Item:         item,
+				Date:         date,
+				NeedsConfirm: !autoGenerate && !item.AutoGenerate,
+			})
+		}
+	}
+	return due, nil
+}
+
+// Generate creates the transactions for every occurrence due within the options'
+// window. All transactions are created, and every affected item's NextDate and
+// LastGeneratedDate advanced, in a single database transaction.
+func (g *ScheduleGenerator) Generate(opts GenerateOptions) (*GenerateResult, error) {
+	through := recurrence.Day(g.now()).AddDate(0, 0, opts.DaysAhead)
+	due, err := g.Due(through, opts.AutoGenerate)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &GenerateResult{Through: through, Generated: []*ScheduledOccurrence{}, Pending: []*ScheduledOccurrence{}}
+
+	// Ask for confirmation before opening the database transaction
+	declined := make(map[uint]bool)
+	for _, occ := range due {
+		approved := !declined[occ.Item.ID] &&
+			(!occ.NeedsConfirm || (!opts.DryRun && opts.Confirm != nil && opts.Confirm(occ)))
+		if !approved {
+			declined[occ.Item.ID] = true
+			result.Pending = append(result.Pending, occ)
+			continue
+		}
+		occ.Transaction = newScheduledTransaction(occ.Item, occ.Date)
+		result.Generated = append(result.Generated, occ)
+	}
+
+	if opts.DryRun || len(result.Generated) == 0 {
+		return result, nil
+	}
+
+	err = g.db.Transaction(func(tx *gorm.DB) error {
+		generated, err := applyOccurrences(tx, result.Generated)
+		if err != nil {
+			return err
+		}
+		result.Generated = generated
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate transactions: %w", err)
+	}
+
+	return result, nil
+}
+
+// applyOccurrences creates the transactions for approved occurrences and advances
+// their items. Items whose next date moved since planning (for example because
+// another run generated them first) are skipped.
+func applyOccurrences(tx *gorm.DB, occurrences []*ScheduledOccurrence) ([]*ScheduledOccurrence, error) {
+	recurringRepo := repositories.NewRecurringRepository(tx)
+	txRepo := repositories.NewTransactionRepository(tx)
+
+	var byItem [][]*ScheduledOccurrence
+	index := make(map[uint]int)
+	for _, occ := range occurrences {
+		i, ok := index[occ.Item.ID]
+		if !ok {
+			i = len(byItem)
+			index[occ.Item.ID] = i
+			byItem = append(byItem, nil)
+		}
+		byItem[i] = append(byItem[i], occ)
+	}
+
+	applied := []*ScheduledOccurrence{}
+	for _, group := range byItem {
+		item, err := recurringRepo.GetByID(group[0].Item.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !item.IsActive || !recurrence.Day(item.NextDate).Equal(group[0].Date) {
+			continue
+		}
+
+		for _, occ := range group {
+			if err := txRepo.Create(occ.Transaction); err != nil {
+				return nil, fmt.Errorf("failed to create transaction for %s: %w", item.Name, err)
+			}
+			occ.Item = item
+			applied = append(applied, occ)
+		}
+
+		last := group[len(group)-1].Date
+		item.LastGeneratedDate = &last
+		item.NextDate = nextAfter(item, last)
+		if err := recurringRepo.Update(item); err != nil {
+			return nil, fmt.Errorf("failed to advance %s: %w", item.Name, err)
+		}
+	}
+	return applied, nil
+}
+
+// itemOccurrences lists an item's occurrences from its NextDate through the given
+// day. NextDate is always the first one, even if it was moved off the schedule.
+func itemOccurrences(item *models.RecurringItem, through time.Time) []time.Time {
+	rule := recurrence.FromItem(item)
+
+	var dates []time.Time
+	date, ok := recurrence.Day(item.NextDate), true
+	for ok && !date.After(through) {
+		if item.EndDate != nil && date.After(recurrence.Day(*item.EndDate)) {
+			break
+		}
+		dates = append(dates, date)
+		date, ok = rule.Next(date)
+	}
+	return dates
+}
+
+// nextAfter returns the item's next due date after date. Once the schedule has
+// ended this is the date it would have fallen on, which is after EndDate.
+func nextAfter(item *models.RecurringItem, date time.Time) time.Time {
+	rule := recurrence.FromItem(item)
+	rule.End = nil
+	next, _ := rule.Next(date)
+	return next
+}
+
+// newScheduledTransaction builds the transaction for one occurrence of an item
+func newScheduledTransaction(item *models.RecurringItem, date time.Time) *models.Transaction {
+	txType := models.TransactionTypeExpense
+	if item.AmountCents > 0 {
+		txType = models.TransactionTypeIncome
+	}
+
+	itemID := item.ID
+	return &models.Transaction{
+		AccountID:   item.AccountID,
+		Date:        date,
+		AmountCents: item.AmountCents,
+		CategoryID:  item.CategoryID,
+		Payee:       item.Name,
+		Description: item.Description,
+		Type:        txType,
+		RecurringID: &itemID,
+	}
+}
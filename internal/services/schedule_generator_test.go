@@ -0,0 +1,201 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// ScheduleGeneratorTestSuite is the test suite for recurring transaction generation
+type ScheduleGeneratorTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	gen     *ScheduleGenerator
+	account *models.Account
+}
+
+// SetupTest runs before each test
+func (suite *ScheduleGeneratorTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), db.AutoMigrate(&models.Account{}, &models.Category{}, &models.Transaction{}, &models.RecurringItem{}))
+
+	suite.db = db
+	suite.gen = NewScheduleGenerator(db)
+	suite.gen.now = func() time.Time { return time.Date(2026, 10, 30, 14, 0, 0, 0, time.UTC) }
+
+	suite.account = &models.Account{Name: "Checking", Type: models.AccountTypeChecking, Currency: "USD", InitialBalanceCents: 500000, IsActive: true}
+	assert.NoError(suite.T(), repositories.NewAccountRepository(db).Create(suite.account))
+}
+
+func (suite *ScheduleGeneratorTestSuite) createItem(name string, amount int64, next time.Time, auto bool) *models.RecurringItem {
+	day := next.Day()
+	item := &models.RecurringItem{
+		AccountID:         suite.account.ID,
+		Name:              name,
+		AmountCents:       amount,
+		Frequency:         models.FrequencyMonthly,
+		FrequencyInterval: 1,
+		DayOfMonth:        &day,
+		StartDate:         next,
+		NextDate:          next,
+		AutoGenerate:      auto,
+		IsActive:          true,
+	}
+	assert.NoError(suite.T(), suite.db.Create(item).Error)
+	return item
+}
+
+func (suite *ScheduleGeneratorTestSuite) reload(item *models.RecurringItem) *models.RecurringItem {
+	reloaded, err := repositories.NewRecurringRepository(suite.db).GetByID(item.ID)
+	assert.NoError(suite.T(), err)
+	return reloaded
+}
+
+func (suite *ScheduleGeneratorTestSuite) countGenerated(item *models.RecurringItem) int64 {
+	var count int64
+	assert.NoError(suite.T(), suite.db.Model(&models.Transaction{}).Where("recurring_id = ?", item.ID).Count(&count).Error)
+	return count
+}
+
+func (suite *ScheduleGeneratorTestSuite) TestGenerate_AutoItem() {
+	// Given an auto-generated item due in two days
+	rent := suite.createItem("Rent", -150000, time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), true)
+
+	// When generating three days ahead
+	result, err := suite.gen.Generate(GenerateOptions{DaysAhead: 3})
+
+	// Then one transaction is created and the item advances a month
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Generated, 1)
+	assert.Empty(suite.T(), result.Pending)
+	assert.Equal(suite.T(), int64(1), suite.countGenerated(rent))
+
+	tx := result.Generated[0].Transaction
+	assert.Equal(suite.T(), rent.ID, *tx.RecurringID)
+	assert.Equal(suite.T(), models.TransactionTypeExpense, tx.Type)
+	assert.Equal(suite.T(), "Rent", tx.Payee)
+
+	reloaded := suite.reload(rent)
+	assert.Equal(suite.T(), "2026-12-01", reloaded.NextDate.Format("2006-01-02"))
+	assert.Equal(suite.T(), "2026-11-01", reloaded.LastGeneratedDate.Format("2006-01-02"))
+
+	account, err := repositories.NewAccountRepository(suite.db).GetByID(suite.account.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(350000), account.CurrentBalanceCents)
+}
+
+func (suite *ScheduleGeneratorTestSuite) TestGenerate_CatchesUpMissedOccurrences() {
+	gym := suite.createItem("Gym", -4000, time.Date(2026, 8, 30, 0, 0, 0, 0, time.UTC), true)
+
+	result, err := suite.gen.Generate(GenerateOptions{DaysAhead: 0})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Generated, 3)
+	assert.Equal(suite.T(), "2026-10-30", result.Generated[2].Date.Format("2006-01-02"))
+	assert.Equal(suite.T(), "2026-11-30", suite.reload(gym).NextDate.Format("2006-01-02"))
+}
+
+func (suite *ScheduleGeneratorTestSuite) TestGenerate_NonAutoNeedsConfirmation() {
+	rent := suite.createItem("Rent", -150000, time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), false)
+
+	result, err := suite.gen.Generate(GenerateOptions{DaysAhead: 3})
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), result.Generated)
+	assert.Len(suite.T(), result.Pending, 1)
+	assert.Equal(suite.T(), int64(0), suite.countGenerated(rent))
+
+	asked := 0
+	result, err = suite.gen.Generate(GenerateOptions{DaysAhead: 3, Confirm: func(occ *ScheduledOccurrence) bool {
+		asked++
+		return true
+	}})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, asked)
+	assert.Len(suite.T(), result.Generated, 1)
+	assert.Equal(suite.T(), int64(1), suite.countGenerated(rent))
+}
+
+func (suite *ScheduleGeneratorTestSuite) TestGenerate_ConfigAutoGenerate() {
+	rent := suite.createItem("Rent", -150000, time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), false)
+
+	result, err := suite.gen.Generate(GenerateOptions{DaysAhead: 3, AutoGenerate: true})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Generated, 1)
+	assert.Equal(suite.T(), int64(1), suite.countGenerated(rent))
+}
+
+func (suite *ScheduleGeneratorTestSuite) TestGenerate_DeclineStopsItem() {
+	gym := suite.createItem("Gym", -4000, time.Date(2026, 8, 30, 0, 0, 0, 0, time.UTC), false)
+
+	result, err := suite.gen.Generate(GenerateOptions{Confirm: func(occ *ScheduledOccurrence) bool {
+		return occ.Date.Month() == time.August
+	}})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Generated, 1)
+	assert.Len(suite.T(), result.Pending, 2)
+	assert.Equal(suite.T(), "2026-09-30", suite.reload(gym).NextDate.Format("2006-01-02"))
+}
+
+func (suite *ScheduleGeneratorTestSuite) TestGenerate_DryRun() {
+	rent := suite.createItem("Rent", -150000, time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), true)
+
+	result, err := suite.gen.Generate(GenerateOptions{DaysAhead: 3, DryRun: true})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Generated, 1)
+	assert.Equal(suite.T(), int64(0), suite.countGenerated(rent))
+	assert.Equal(suite.T(), "2026-11-01", suite.reload(rent).NextDate.Format("2006-01-02"))
+}
+
+func (suite *ScheduleGeneratorTestSuite) TestGenerate_RespectsEndDate() {
+	item := suite.createItem("Loan", -20000, time.Date(2026, 8, 15, 0, 0, 0, 0, time.UTC), true)
+	end := time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC)
+	item.EndDate = &end
+	assert.NoError(suite.T(), suite.db.Save(item).Error)
+
+	result, err := suite.gen.Generate(GenerateOptions{DaysAhead: 3})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Generated, 2)
+
+	reloaded := suite.reload(item)
+	assert.True(suite.T(), reloaded.NextDate.After(end))
+
+	result, err = suite.gen.Generate(GenerateOptions{DaysAhead: 60})
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), result.Generated)
+}
+
+func (suite *ScheduleGeneratorTestSuite) TestGenerate_FailureRollsBack() {
+	rent := suite.createItem("Rent", -150000, time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), true)
+
+	repositories.RegisterTransactionHook("test_fail", func(tx *gorm.DB, txs []*models.Transaction) error {
+		return assert.AnError
+	})
+	defer repositories.UnregisterTransactionHook("test_fail")
+
+	_, err := suite.gen.Generate(GenerateOptions{DaysAhead: 3})
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), int64(0), suite.countGenerated(rent))
+
+	reloaded := suite.reload(rent)
+	assert.Equal(suite.T(), "2026-11-01", reloaded.NextDate.Format("2006-01-02"))
+	assert.Nil(suite.T(), reloaded.LastGeneratedDate)
+}
+
+func (suite *ScheduleGeneratorTestSuite) TestGenerate_SkipsPausedItems() {
+	rent := suite.createItem("Rent", -150000, time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), true)
+	assert.NoError(suite.T(), repositories.NewRecurringRepository(suite.db).SetActive(rent.ID, false))
+
+	result, err := suite.gen.Generate(GenerateOptions{DaysAhead: 3})
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), result.Generated)
+}
+
+func TestScheduleGeneratorTestSuite(t *testing.T) {
+	suite.Run(t, new(ScheduleGeneratorTestSuite))
+}
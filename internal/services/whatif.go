@@ -0,0 +1,420 @@
+package services
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/fintrack/fintrack/internal/models"
+	"github.com/fintrack/fintrack/internal/recurrence"
+	"github.com/spf13/viper"
+)
+
+// What-if change actions
+const (
+	WhatIfAdd    = "add"    // Add a hypothetical recurring item
+	WhatIfRemove = "remove" // Cancel a recurring item, optionally from a date
+	WhatIfModify = "modify" // Change a recurring item's amount, optionally from a date
+	WhatIfOnce   = "once"   // Add a one-off transaction
+)
+
+// WhatIf is a named set of hypothetical changes to scheduled cash flows. It is
+// only ever applied to a projection; real recurring items are never changed.
+type WhatIf struct {
+	Name    string         `mapstructure:"name" json:"name"`
+	Changes []WhatIfChange `mapstructure:"changes" json:"changes"`
+}
+
+// WhatIfChange is one hypothetical change. Dates are YYYY-MM-DD and amounts are
+// in dollars, negative for expenses.
+type WhatIfChange struct {
+	Action    string   `mapstructure:"action" json:"action"`
+	Item      string   `mapstructure:"item" json:"item,omitempty"` // Existing item ID or name (remove, modify)
+	Name      string   `mapstructure:"name" json:"name,omitempty"` // New item or one-off name (add, once)
+	Amount    *float64 `mapstructure:"amount" json:"amount,omitempty"`
+	Account   string   `mapstructure:"account" json:"account,omitempty"` // Account ID or name (add, once)
+	Frequency string   `mapstructure:"frequency" json:"frequency,omitempty"`
+	Interval  int      `mapstructure:"interval" json:"interval,omitempty"`
+	Day       int      `mapstructure:"day" json:"day,omitempty"`
+	Start     string   `mapstructure:"start" json:"start,omitempty"` // First date of an added item (default: tomorrow)
+	End       string   `mapstructure:"end" json:"end,omitempty"`
+	From      string   `mapstructure:"from" json:"from,omitempty"` // When a remove or modify takes effect (default: now)
+	Date      string   `mapstructure:"date" json:"date,omitempty"` // Date of a one-off
+}
+
+// WhatIfComparison is a baseline projection next to the same projection with a
+// what-if applied
+type WhatIfComparison struct {
+	Name       string      `json:"name"`
+	Changes    []string    `json:"changes"`
+	Baseline   *Projection `json:"baseline"`
+	Scenario   *Projection `json:"scenario"`
+	DeltaCents int64       `json:"delta_cents"` // Scenario minus baseline ending balance
+}
+
+// LoadWhatIf reads a what-if from a YAML file. Without a name, the file name is used.
+func LoadWhatIf(path string) (*WhatIf, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read what-if file: %w", err)
+	}
+
+	whatIf := &WhatIf{}
+	if err := v.Unmarshal(whatIf, viper.DecodeHook(whatIfDateHook)); err != nil {
+		return nil, fmt.Errorf("failed to parse what-if file: %w", err)
+	}
+	if whatIf.Name == "" {
+		whatIf.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if len(whatIf.Changes) == 0 {
+		return nil, fmt.Errorf("what-if %s has no changes", whatIf.Name)
+	}
+	return whatIf, nil
+}
+
+// whatIfDateHook turns unquoted YAML dates, which the YAML parser reads as
+// timestamps, back into YYYY-MM-DD strings
+func whatIfDateHook(from, to reflect.Type, data interface{}) (interface{}, error) {
+	if t, ok := data.(time.Time); ok && to.Kind() == reflect.String {
+		return t.Format("2006-01-02"), nil
+	}
+	return data, nil
+}
+
+// CompareWhatIf projects the baseline and the what-if scenario from the same
+// balances and spending history. Neither projection is saved.
+func (s *ProjectionService) CompareWhatIf(opts ProjectionOptions, whatIf *WhatIf) (*WhatIfComparison, error) {
+	model, err := s.load(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	scenarioModel, changes, err := s.applyWhatIf(model, whatIf)
+	if err != nil {
+		return nil, fmt.Errorf("what-if %s: %w", whatIf.Name, err)
+	}
+
+	comparison := &WhatIfComparison{
+		Name:     whatIf.Name,
+		Changes:  changes,
+		Baseline: model.project(opts.Percentile),
+		Scenario: scenarioModel.project(opts.Percentile),
+	}
+	for _, projection := range []*Projection{comparison.Baseline, comparison.Scenario} {
+		projection.Scenario = opts.Scenario
+		projection.AccountID = opts.AccountID
+		projection.GeneratedAt = s.now()
+	}
+	comparison.DeltaCents = comparison.Scenario.EndingBalanceCents - comparison.Baseline.EndingBalanceCents
+	return comparison, nil
+}
+
+// oneOff is a single hypothetical transaction in a projection
+type oneOff struct {
+	accountID   uint
+	date        time.Time
+	amountCents int64
+	name        string
+}
+
+// applyWhatIf returns a copy of the model with the what-if's changes applied,
+// and a description of each change. Changes to accounts outside the projection
+// are skipped.
+func (s *ProjectionService) applyWhatIf(model *projectionModel, whatIf *WhatIf) (*projectionModel, []string, error) {
+	scenario := *model
+	scenario.items = append([]*models.RecurringItem(nil), model.items...)
+	scenario.oneOffs = append([]oneOff(nil), model.oneOffs...)
+
+	inScope := make(map[uint]bool)
+	for _, account := range model.accounts {
+		inScope[account.ID] = true
+	}
+
+	var descriptions []string
+	for i, change := range whatIf.Changes {
+		var (
+			description string
+			err         error
+		)
+		switch strings.ToLower(change.Action) {
+		case WhatIfAdd:
+			description, err = s.whatIfAdd(&scenario, change, inScope)
+		case WhatIfRemove, WhatIfModify:
+			description, err = s.whatIfChangeItem(&scenario, change)
+		case WhatIfOnce:
+			description, err = s.whatIfOnce(&scenario, change, inScope)
+		default:
+			err = fmt.Errorf("invalid action: %q (valid: add, remove, modify, once)", change.Action)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("change %d: %w", i+1, err)
+		}
+		if description != "" {
+			descriptions = append(descriptions, description)
+		}
+	}
+	return &scenario, descriptions, nil
+}
+
+// whatIfAdd adds a hypothetical recurring item
+func (s *ProjectionService) whatIfAdd(m *projectionModel, change WhatIfChange, inScope map[uint]bool) (string, error) {
+	if change.Amount == nil {
+		return "", fmt.Errorf("amount is required")
+	}
+	account, err := s.whatIfAccount(m, change.Account)
+	if err != nil {
+		return "", err
+	}
+
+	start := m.today.AddDate(0, 0, 1)
+	if change.Start != "" {
+		if start, err = parseWhatIfDate("start", change.Start); err != nil {
+			return "", err
+		}
+	}
+
+	item := &models.RecurringItem{
+		AccountID:         account.ID,
+		Name:              change.Name,
+		AmountCents:       models.DollarsToCents(*change.Amount),
+		Frequency:         strings.ToLower(change.Frequency),
+		FrequencyInterval: change.Interval,
+		StartDate:         start,
+		IsActive:          true,
+	}
+	if item.Frequency == "" {
+		item.Frequency = models.FrequencyMonthly
+	}
+	if item.FrequencyInterval == 0 {
+		item.FrequencyInterval = 1
+	}
+	if change.Day != 0 {
+		day := change.Day
+		item.DayOfMonth = &day
+	}
+	if change.End != "" {
+		end, err := parseWhatIfDate("end", change.End)
+		if err != nil {
+			return "", err
+		}
+		item.EndDate = &end
+	}
+	if err := ValidateRecurringItem(item); err != nil {
+		return "", err
+	}
+
+	next, ok := NextRecurringDate(item)
+	if !ok {
+		return "", fmt.Errorf("%s has no occurrences before its end date", item.Name)
+	}
+	item.NextDate = next
+
+	if !inScope[account.ID] {
+		return "", nil
+	}
+	m.items = append(m.items, item)
+	return fmt.Sprintf("add %s: %s %s from %s", item.Name, formatWhatIfAmount(item.AmountCents),
+		item.Frequency, next.Format("2006-01-02")), nil
+}
+
+// whatIfChangeItem removes or changes the amount of an existing recurring item,
+// from a date if one is given. Earlier changes leave the item split into
+// segments with different amounts; the segment in effect on the date is split
+// again, so changes apply in order.
+func (s *ProjectionService) whatIfChangeItem(m *projectionModel, change WhatIfChange) (string, error) {
+	action := strings.ToLower(change.Action)
+	if change.Item == "" {
+		return "", fmt.Errorf("item is required")
+	}
+	if action == WhatIfModify && change.Amount == nil {
+		return "", fmt.Errorf("amount is required")
+	}
+
+	var first *models.RecurringItem
+	for _, item := range m.items {
+		if strings.EqualFold(item.Name, change.Item) || strconv.FormatUint(uint64(item.ID), 10) == change.Item {
+			first = item
+			break
+		}
+	}
+	if first == nil {
+		// Paused items and items of accounts outside the projection don't affect it
+		if _, err := s.whatIfItem(change.Item); err != nil {
+			return "", err
+		}
+		return "", nil
+	}
+
+	from := m.today.AddDate(0, 0, 1)
+	if change.From != "" {
+		parsed, err := parseWhatIfDate("from", change.From)
+		if err != nil {
+			return "", err
+		}
+		if parsed.After(from) {
+			from = parsed
+		}
+	}
+
+	// Split the item at its first occurrence on or after the change
+	rule := recurrence.FromItem(first)
+	rule.End = nil
+	if next := recurrence.Day(first.NextDate); next.After(from) {
+		from = next
+	}
+	splitAt, ok := rule.OnOrAfter(from)
+	if !ok {
+		return "", nil
+	}
+	key := whatIfItemKey(first)
+	if removed, ok := m.removed[key]; ok && !splitAt.Before(removed) {
+		return "", fmt.Errorf("%s is already removed from %s", first.Name, removed.Format("2006-01-02"))
+	}
+
+	// Find the segment in effect on that date, and any that follow it
+	current := -1
+	var later []int
+	for i, item := range m.items {
+		if whatIfItemKey(item) != key {
+			continue
+		}
+		switch start := recurrence.Day(item.NextDate); {
+		case start.After(splitAt):
+			later = append(later, i)
+		case item.EndDate == nil || !splitAt.After(recurrence.Day(*item.EndDate)):
+			current = i
+		}
+	}
+	if current < 0 {
+		return "", nil // The item ends before the change
+	}
+	segment := m.items[current]
+
+	before := *segment
+	end := splitAt.AddDate(0, 0, -1)
+	before.EndDate = &end
+	m.items[current] = &before
+
+	if action == WhatIfRemove {
+		m.items = removeItems(m.items, later)
+		if m.removed == nil {
+			m.removed = make(map[string]time.Time)
+		}
+		m.removed[key] = splitAt
+		return fmt.Sprintf("remove %s from %s", first.Name, splitAt.Format("2006-01-02")), nil
+	}
+
+	after := *segment
+	after.AmountCents = models.DollarsToCents(*change.Amount)
+	after.NextDate = splitAt
+	m.items = append(m.items, &after)
+	return fmt.Sprintf("modify %s: %s to %s from %s", first.Name,
+		formatWhatIfAmount(segment.AmountCents), formatWhatIfAmount(after.AmountCents),
+		splitAt.Format("2006-01-02")), nil
+}
+
+// whatIfItemKey identifies a recurring item across the segments a what-if
+// splits it into
+func whatIfItemKey(item *models.RecurringItem) string {
+	return fmt.Sprintf("%d/%s", item.ID, item.Name)
+}
+
+// removeItems returns items without those at the given ascending indexes
+func removeItems(items []*models.RecurringItem, indexes []int) []*models.RecurringItem {
+	kept := items[:0]
+	for i, item := range items {
+		if len(indexes) > 0 && indexes[0] == i {
+			indexes = indexes[1:]
+			continue
+		}
+		kept = append(kept, item)
+	}
+	return kept
+}
+
+// whatIfOnce adds a one-off transaction
+func (s *ProjectionService) whatIfOnce(m *projectionModel, change WhatIfChange, inScope map[uint]bool) (string, error) {
+	if change.Amount == nil || *change.Amount == 0 {
+		return "", fmt.Errorf("amount is required")
+	}
+	if change.Date == "" {
+		return "", fmt.Errorf("date is required")
+	}
+	date, err := parseWhatIfDate("date", change.Date)
+	if err != nil {
+		return "", err
+	}
+	account, err := s.whatIfAccount(m, change.Account)
+	if err != nil {
+		return "", err
+	}
+	if !inScope[account.ID] {
+		return "", nil
+	}
+
+	name := change.Name
+	if name == "" {
+		name = "One-off"
+	}
+	amount := models.DollarsToCents(*change.Amount)
+	m.oneOffs = append(m.oneOffs, oneOff{accountID: account.ID, date: date, amountCents: amount, name: name})
+	return fmt.Sprintf("once %s: %s on %s", name, formatWhatIfAmount(amount), date.Format("2006-01-02")), nil
+}
+
+// whatIfAccount resolves an account ID or name. Without one, the projection's
+// only account is used.
+func (s *ProjectionService) whatIfAccount(m *projectionModel, idOrName string) (*models.Account, error) {
+	if idOrName == "" {
+		if len(m.accounts) == 1 {
+			return m.accounts[0], nil
+		}
+		return nil, fmt.Errorf("account is required")
+	}
+
+	repo := repositories.NewAccountRepository(s.db)
+	if id, err := strconv.ParseUint(idOrName, 10, 32); err == nil {
+		return repo.GetByID(uint(id))
+	}
+	account, err := repo.GetByName(idOrName)
+	if err != nil {
+		return nil, fmt.Errorf("account not found: %s", idOrName)
+	}
+	return account, nil
+}
+
+// whatIfItem resolves a recurring item ID or name
+func (s *ProjectionService) whatIfItem(idOrName string) (*models.RecurringItem, error) {
+	repo := repositories.NewRecurringRepository(s.db)
+	if id, err := strconv.ParseUint(idOrName, 10, 32); err == nil {
+		item, err := repo.GetByID(uint(id))
+		if err != nil {
+			return nil, fmt.Errorf("recurring item not found: %s", idOrName)
+		}
+		return item, nil
+	}
+	item, err := repo.GetByName(idOrName)
+	if err != nil {
+		return nil, fmt.Errorf("recurring item not found: %s", idOrName)
+	}
+	return item, nil
+}
+
+// parseWhatIfDate parses a YYYY-MM-DD what-if date
+func parseWhatIfDate(field, value string) (time.Time, error) {
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s date (use YYYY-MM-DD): %s", field, value)
+	}
+	return date, nil
+}
+
+// formatWhatIfAmount formats cents with an explicit sign
+func formatWhatIfAmount(cents int64) string {
+	return fmt.Sprintf("%+.2f", models.CentsToDollars(cents))
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/fintrack/fintrack/internal/db/repositories"
+	"github.com/stretchr/testify/assert"
+)
+
+func amount(dollars float64) *float64 {
+	return &dollars
+}
+
+func (suite *ProjectionTestSuite) compare(whatIf *WhatIf) *WhatIfComparison {
+	comparison, err := suite.svc.CompareWhatIf(ProjectionOptions{
+		Days:                 120,
+		Scenario:             ScenarioModerate,
+		Percentile:           0.5,
+		HistoricalWindowDays: 28,
+	}, whatIf)
+	assert.NoError(suite.T(), err)
+	return comparison
+}
+
+func (suite *ProjectionTestSuite) TestWhatIfModifyFromDate() {
+	// Rent goes up $200 from mid-December: January and February cost more
+	comparison := suite.compare(&WhatIf{Name: "Rent increase", Changes: []WhatIfChange{
+		{Action: WhatIfModify, Item: "rent", Amount: amount(-1700), From: "2026-12-15"},
+	}})
+
+	assert.Equal(suite.T(), "Rent increase", comparison.Name)
+	assert.Equal(suite.T(), []string{"modify Rent: -1500.00 to -1700.00 from 2027-01-01"}, comparison.Changes)
+	assert.Equal(suite.T(), int64(-40000), comparison.DeltaCents)
+	assert.Equal(suite.T(), comparison.Baseline.Days[45].BalanceCents, comparison.Scenario.Days[45].BalanceCents)
+
+	// And the real schedule is untouched
+	rent, err := repositories.NewRecurringRepository(suite.db).GetByName("Rent")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(-150000), rent.AmountCents)
+	assert.Nil(suite.T(), rent.EndDate)
+}
+
+func (suite *ProjectionTestSuite) TestWhatIfSeveralChangesToOneItem() {
+	// Rent rises twice: January and February each cost more than the last
+	comparison := suite.compare(&WhatIf{Name: "Two rises", Changes: []WhatIfChange{
+		{Action: WhatIfModify, Item: "Rent", Amount: amount(-1700), From: "2026-12-15"},
+		{Action: WhatIfModify, Item: "Rent", Amount: amount(-1800), From: "2027-01-15"},
+	}})
+	assert.Equal(suite.T(), []string{
+		"modify Rent: -1500.00 to -1700.00 from 2027-01-01",
+		"modify Rent: -1700.00 to -1800.00 from 2027-02-01",
+	}, comparison.Changes)
+	assert.Equal(suite.T(), int64(-20000-30000), comparison.DeltaCents)
+
+	// A rise, then moving out: January costs more and February nothing
+	comparison = suite.compare(&WhatIf{Name: "Rise then move", Changes: []WhatIfChange{
+		{Action: WhatIfModify, Item: "Rent", Amount: amount(-1700), From: "2026-12-15"},
+		{Action: WhatIfRemove, Item: "Rent", From: "2027-01-15"},
+	}})
+	assert.Equal(suite.T(), "remove Rent from 2027-02-01", comparison.Changes[1])
+	assert.Equal(suite.T(), int64(-20000+150000), comparison.DeltaCents)
+
+	// The same in the other order: the removal still holds from February
+	comparison = suite.compare(&WhatIf{Name: "Move then rise", Changes: []WhatIfChange{
+		{Action: WhatIfRemove, Item: "Rent", From: "2027-01-15"},
+		{Action: WhatIfModify, Item: "Rent", Amount: amount(-1700), From: "2026-12-15"},
+	}})
+	assert.Equal(suite.T(), int64(-20000+150000), comparison.DeltaCents)
+
+	// Nothing is left to change after a removal
+	_, err := suite.svc.CompareWhatIf(ProjectionOptions{Days: 120, Percentile: 0.5, HistoricalWindowDays: 28}, &WhatIf{Name: "bad", Changes: []WhatIfChange{
+		{Action: WhatIfRemove, Item: "Rent", From: "2026-12-15"},
+		{Action: WhatIfModify, Item: "Rent", Amount: amount(-1800), From: "2027-01-15"},
+	}})
+	assert.EqualError(suite.T(), err, "what-if bad: change 2: Rent is already removed from 2027-01-01")
+}
+
+func (suite *ProjectionTestSuite) TestWhatIfRemove() {
+	comparison := suite.compare(&WhatIf{Name: "Move home", Changes: []WhatIfChange{
+		{Action: WhatIfRemove, Item: "Rent"},
+	}})
+
+	// Four rent payments fall within 120 days
+	assert.Equal(suite.T(), int64(600000), comparison.DeltaCents)
+	// And the overdraft comes later, from spending alone
+	assert.Equal(suite.T(), "2026-11-01", comparison.Baseline.FirstNegative.Date.Format("2006-01-02"))
+	assert.Equal(suite.T(), "2026-11-26", comparison.Scenario.FirstNegative.Date.Format("2006-01-02"))
+}
+
+func (suite *ProjectionTestSuite) TestWhatIfAddAndOnce() {
+	comparison := suite.compare(&WhatIf{Name: "New car", Changes: []WhatIfChange{
+		{Action: WhatIfAdd, Name: "Car payment", Amount: amount(-350), Day: 15, Start: "2026-11-15"},
+		{Action: WhatIfOnce, Name: "Vacation", Amount: amount(-2000), Date: "2027-01-10"},
+	}})
+
+	assert.Len(suite.T(), comparison.Changes, 2)
+	assert.Equal(suite.T(), int64(-3*35000-200000), comparison.DeltaCents)
+
+	vacation := comparison.Scenario.Days[85]
+	assert.Equal(suite.T(), "2027-01-10", vacation.Date.Format("2006-01-02"))
+	assert.Equal(suite.T(), []string{"Vacation"}, vacation.Events)
+}
+
+func (suite *ProjectionTestSuite) TestWhatIfErrors() {
+	opts := ProjectionOptions{Days: 30, Scenario: ScenarioModerate, Percentile: 0.5}
+
+	_, err := suite.svc.CompareWhatIf(opts, &WhatIf{Name: "bad", Changes: []WhatIfChange{{Action: "double"}}})
+	assert.EqualError(suite.T(), err, `what-if bad: change 1: invalid action: "double" (valid: add, remove, modify, once)`)
+
+	_, err = suite.svc.CompareWhatIf(opts, &WhatIf{Name: "bad", Changes: []WhatIfChange{{Action: WhatIfRemove, Item: "Gym"}}})
+	assert.EqualError(suite.T(), err, "what-if bad: change 1: recurring item not found: Gym")
+
+	_, err = suite.svc.CompareWhatIf(opts, &WhatIf{Name: "bad", Changes: []WhatIfChange{{Action: WhatIfModify, Item: "Rent"}}})
+	assert.EqualError(suite.T(), err, "what-if bad: change 1: amount is required")
+}
+
+func (suite *ProjectionTestSuite) TestLoadWhatIf() {
+	path := filepath.Join(suite.T().TempDir(), "cancel-netflix.yaml")
+	content := `changes:
+  - action: remove
+    item: Netflix
+    from: 2026-12-01
+  - action: once
+    name: Tax refund
+    amount: 850.50
+    date: 2027-04-15
+`
+	assert.NoError(suite.T(), os.WriteFile(path, []byte(content), 0o600))
+
+	whatIf, err := LoadWhatIf(path)
+	if !assert.NoError(suite.T(), err) {
+		return
+	}
+	assert.Equal(suite.T(), "cancel-netflix", whatIf.Name)
+	if assert.Len(suite.T(), whatIf.Changes, 2) {
+		assert.Equal(suite.T(), WhatIfRemove, whatIf.Changes[0].Action)
+		assert.Equal(suite.T(), "2026-12-01", whatIf.Changes[0].From)
+		assert.Equal(suite.T(), 850.50, *whatIf.Changes[1].Amount)
+	}
+
+	empty := filepath.Join(suite.T().TempDir(), "empty.yaml")
+	assert.NoError(suite.T(), os.WriteFile(empty, []byte("name: Nothing\n"), 0o600))
+	_, err = LoadWhatIf(empty)
+	assert.EqualError(suite.T(), err, "what-if Nothing has no changes")
+}
@@ -0,0 +1,25 @@
+-- FinTrack Database Schema
+-- Migration 002: Envelope (zero-based) budgeting
+-- Version: 1.1
+
+-- ============================================================================
+-- ENVELOPE ALLOCATIONS
+-- ============================================================================
+CREATE TABLE envelope_allocations (
+    id SERIAL PRIMARY KEY,
+    month DATE NOT NULL,  -- First day of the budget month
+    category_id INTEGER NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    amount BIGINT NOT NULL,  -- Cents; negative removes money from the envelope
+    kind VARCHAR(20) NOT NULL DEFAULT 'assign' CHECK (kind IN ('assign', 'move')),
+    note TEXT,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_envelope_month_category ON envelope_allocations(month, category_id);
+
+COMMENT ON TABLE envelope_allocations IS 'Money assigned to or moved between category envelopes per month';
+COMMENT ON COLUMN envelope_allocations.kind IS 'assign: from to-be-budgeted; move: between envelopes (paired rows)';
+
+INSERT INTO schema_version (version) VALUES ('1.1.0');
+
+-- End of migration